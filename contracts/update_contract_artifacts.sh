@@ -3,6 +3,15 @@ rm -rf ../nodemgr/internal/lib/reti/artifacts/contracts/
 set -e
 mkdir -p ../nodemgr/internal/lib/reti/artifacts/contracts/
 cp ./contracts/artifacts/*arc32* ../nodemgr/internal/lib/reti/artifacts/contracts/
+cp ./contracts/artifacts/*arc56* ../nodemgr/internal/lib/reti/artifacts/contracts/
+(cd ../nodemgr/internal/lib/reti && go generate)
+# Add the approval program hashes of this release (per network) to the known hashes - needs an algod with the
+# developer api enabled (ALGOD_URL / ALGOD_TOKEN, localnet by default)
+version=$(node -p "require('./package.json').version")
+(cd ../nodemgr/internal/lib/reti && go run ../../tools/contracthashes \
+  -version "$version" \
+  -algod "${ALGOD_URL:-http://localhost:4001}" -token "${ALGOD_TOKEN:-$(printf 'a%.0s' {1..64})}" \
+  -out contracthashes_gen.go artifacts/contracts/ValidatorRegistry.arc32.json artifacts/contracts/StakingPool.arc32.json)
 
 # Update UI contract clients
 rm -rf ../ui/src/contracts/
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Kinds of the actions the daemon records
+const (
+	actionKey      = "key"
+	actionOnline   = "online"
+	actionOffline  = "offline"
+	actionPayout   = "payout"
+	actionEviction = "eviction"
+	actionPool     = "pool"
+	actionVersion  = "version"
+	actionError    = "error"
+)
+
+// maxDaemonActions is how many of the latest actions the daemon keeps
+const maxDaemonActions = 50
+
+// DaemonAction is something the daemon did (or failed to do) - served as json by the daemon's /actions endpoint
+type DaemonAction struct {
+	Time    time.Time `json:"time"`
+	Kind    string    `json:"kind"`
+	Message string    `json:"message"`
+}
+
+type daemonActionsResponse struct {
+	Actions []DaemonAction `json:"actions"`
+}
+
+// actionLog keeps the latest maxDaemonActions actions of the daemon, oldest first
+type actionLog struct {
+	sync.Mutex
+	actions []DaemonAction
+}
+
+func (a *actionLog) record(kind string, format string, args ...any) {
+	a.Lock()
+	defer a.Unlock()
+	a.actions = append(a.actions, DaemonAction{Time: time.Now(), Kind: kind, Message: fmt.Sprintf(format, args...)})
+	if len(a.actions) > maxDaemonActions {
+		a.actions = a.actions[len(a.actions)-maxDaemonActions:]
+	}
+}
+
+func (a *actionLog) recent() []DaemonAction {
+	a.Lock()
+	defer a.Unlock()
+	return append([]DaemonAction{}, a.actions...)
+}
+
+func (a *actionLog) handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(daemonActionsResponse{Actions: a.recent()})
+	})
+}
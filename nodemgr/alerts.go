@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/algorandfoundation/reti/internal/lib/misc"
+)
+
+// Alert events the daemon sends to the configured alert sinks
+const (
+	alertEpoch         = "epoch"
+	alertParticipation = "participation"
+	alertEviction      = "eviction"
+)
+
+// AlertSinkSpec is a webhook the daemon POSTs alerts to (as json) - for every event, or just those listed
+type AlertSinkSpec struct {
+	Webhook string   `yaml:"webhook"`
+	Events  []string `yaml:"events,omitempty"`
+}
+
+func (a AlertSinkSpec) validate() error {
+	if u, err := url.Parse(a.Webhook); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid alert webhook url - must be http(s)://host/path")
+	}
+	for _, event := range a.Events {
+		if !slices.Contains([]string{alertEpoch, alertParticipation, alertEviction}, event) {
+			return fmt.Errorf("unknown alert event:%s, must be %s, %s or %s", event, alertEpoch, alertParticipation, alertEviction)
+		}
+	}
+	return nil
+}
+
+// String describes the sink without its path or query - which commonly contain tokens
+func (a AlertSinkSpec) String() string {
+	u, _ := url.Parse(a.Webhook)
+	events := "all events"
+	if len(a.Events) > 0 {
+		events = fmt.Sprint(a.Events)
+	}
+	return fmt.Sprintf("webhook %s://%s/(redacted), %s", u.Scheme, u.Host, events)
+}
+
+type alertPayload struct {
+	Event     string    `json:"event"`
+	Message   string    `json:"message"`
+	Validator uint64    `json:"validator"`
+	Node      uint64    `json:"node"`
+	Time      time.Time `json:"time"`
+}
+
+// alerter sends alerts to the alert sinks - failures are logged but otherwise ignored
+type alerter struct {
+	logger *slog.Logger
+	sinks  []AlertSinkSpec
+	client *http.Client
+}
+
+func newAlerter(logger *slog.Logger, sinks []AlertSinkSpec) *alerter {
+	return &alerter{
+		logger: logger,
+		sinks:  sinks,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Alert sends the (already logged) message to every sink wanting the event, in the background
+func (a *alerter) Alert(ctx context.Context, event string, format string, args ...any) {
+	payload := alertPayload{
+		Event:     event,
+		Message:   fmt.Sprintf(format, args...),
+		Validator: App.retiClient.ValidatorId,
+		Node:      App.retiClient.NodeNum,
+		Time:      time.Now().UTC(),
+	}
+	body, _ := json.Marshal(payload)
+	for _, sink := range a.sinks {
+		if len(sink.Events) > 0 && !slices.Contains(sink.Events, event) {
+			continue
+		}
+		go func() {
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+			defer cancel()
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, sink.Webhook, bytes.NewReader(body))
+			if err != nil {
+				misc.Warnf(a.logger, "unable to send %s alert to %s, err:%v", event, sink, err)
+				return
+			}
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := a.client.Do(req)
+			if err != nil {
+				// the url.Error would include the full webhook url
+				var urlErr *url.Error
+				if errors.As(err, &urlErr) {
+					err = urlErr.Err
+				}
+				misc.Warnf(a.logger, "unable to send %s alert to %s, err:%v", event, sink, err)
+				return
+			}
+			resp.Body.Close()
+			if resp.StatusCode >= 300 {
+				misc.Warnf(a.logger, "%s alert to %s returned status %d", event, sink, resp.StatusCode)
+			}
+		}()
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
+	"net/http"
+	"net/url"
 	"os"
 	"runtime/debug"
 	"slices"
@@ -13,7 +15,6 @@ import (
 	"strings"
 
 	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
-	"github.com/joho/godotenv"
 	"github.com/urfave/cli/v3"
 	"golang.org/x/term"
 
@@ -31,7 +32,7 @@ func initApp() *RetiApp {
 	var logger *slog.Logger
 	if term.IsTerminal(int(os.Stdout.Fd())) {
 		// Are we running on something where output is a tty - so we're being run as CLI vs as a daemon
-		logger = slog.New(misc.NewMinimalHandler(os.Stdout,
+		logger = slog.New(misc.NewMinimalHandler(logOutput,
 			misc.MinimalHandlerOptions{SlogOpts: slog.HandlerOptions{Level: logLevel, AddSource: true}}))
 	} else {
 		// not on console - output as json, but change json key names to be more compatible w/ what google logging
@@ -48,7 +49,7 @@ func initApp() *RetiApp {
 				return a
 			},
 		}
-		logger = slog.New(slog.NewJSONHandler(os.Stdout, opts))
+		logger = slog.New(slog.NewJSONHandler(logOutput, opts))
 	}
 	slog.SetDefault(logger)
 	if os.Getenv("DEBUG") == "1" {
@@ -72,6 +73,17 @@ func initApp() *RetiApp {
 			return appConfig.initClients(ctx, cmd)
 		},
 		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "output",
+				Usage:   "Output format of read commands: table, json, yaml or csv.  Logs are written to stderr if not table",
+				Value:   outputTable,
+				Sources: cli.EnvVars("RETI_OUTPUT"),
+			},
+			&cli.StringFlag{
+				Name:    "configfile",
+				Usage:   "Config file (yaml) - see nodemgr.example.yaml.  Defaults to " + defaultConfigFile + " if present",
+				Sources: cli.EnvVars("RETI_CONFIG"),
+			},
 			&cli.StringFlag{
 				Name:    "envfile",
 				Usage:   "env file to load",
@@ -80,7 +92,7 @@ func initApp() *RetiApp {
 			},
 			&cli.StringFlag{
 				Name:    "network",
-				Usage:   "Algorand network to use",
+				Usage:   "Algorand network to use - mainnet, testnet, betanet, fnet, sandbox or a network profile from the config file",
 				Value:   "mainnet",
 				Aliases: []string{"n"},
 				Sources: cli.EnvVars("ALGO_NETWORK"),
@@ -101,9 +113,10 @@ func initApp() *RetiApp {
 				OnlyOnce:    true,
 			},
 			&cli.BoolFlag{
-				Name:  "usehostname",
-				Usage: "Use the hostname (assuming -0, -1, -2, etc. suffix) as node number.  For use when paired w/ Kubernetes statefulsets",
-				Value: false,
+				Name:    "usehostname",
+				Usage:   "Use the hostname (assuming -0, -1, -2, etc. suffix) as node number.  For use when paired w/ Kubernetes statefulsets",
+				Sources: cli.EnvVars("RETI_USEHOSTNAME"),
+				Value:   false,
 			},
 			&cli.UintFlag{
 				Name:        "node",
@@ -113,12 +126,58 @@ func initApp() *RetiApp {
 				Destination: &appConfig.retiNodeNum,
 				OnlyOnce:    true,
 			},
+			&cli.BoolFlag{
+				Name:    "strictcontracts",
+				Usage:   "Refuse to run if the deployed registry or pool contracts don't match the contract version this build embeds (contracts which can't be verified only warn)",
+				Sources: cli.EnvVars("RETI_STRICT_CONTRACTS"),
+				Value:   false,
+			},
+			&cli.BoolFlag{
+				Name:        "readonly",
+				Usage:       "Only allow read commands (info, state, constraints, pools, ledger, etc.) - for any validator id, without keys or a node number.  Every write is refused",
+				Sources:     cli.EnvVars("RETI_READONLY"),
+				Value:       false,
+				Destination: &appConfig.readOnly,
+			},
+			&cli.StringFlag{
+				Name:    "keystore",
+				Usage:   "Encrypted keystore file holding the owner/manager account keys (see the keystore command)",
+				Sources: cli.EnvVars("RETI_KEYSTORE"),
+			},
+			&cli.StringFlag{
+				Name:    "keystore-passfile",
+				Usage:   "File containing the keystore passphrase (or key).  Prompted for if not specified",
+				Sources: cli.EnvVars("RETI_KEYSTORE_PASSFILE"),
+			},
+			&cli.StringFlag{
+				Name:    "remote-signer",
+				Usage:   "Sign using a 'signer' service - http(s)://host:port or unix:///path/to/socket - rather than local keys",
+				Sources: cli.EnvVars("RETI_REMOTE_SIGNER"),
+			},
+			&cli.StringFlag{
+				Name:  "remote-signer-token-secret",
+				Usage: "Secret (env var or secrets provider key) holding the bearer token of the --remote-signer service",
+				Value: "RETI_SIGNER_TOKEN",
+			},
+			&cli.BoolFlag{
+				Name:    "env-mnemonics",
+				Usage:   "Also load account mnemonics from *_MNEMONIC env vars and secrets (files, encfile, http providers) - the pre-keystore behavior",
+				Sources: cli.EnvVars("RETI_ENV_MNEMONICS"),
+				Value:   false,
+			},
 		},
 		Commands: []*cli.Command{
 			GetDaemonCmdOpts(),
 			GetValidatorCmdOpts(),
 			GetPoolCmdOpts(),
 			GetKeyCmdOpts(),
+			GetTopCmdOpts(),
+			GetStakerCmdOpts(),
+			GetTxnCmdOpts(),
+			GetKeystoreCmdOpts(),
+			GetSignerCmdOpts(),
+			GetSecretsCmdOpts(),
+			GetConfigCmdOpts(),
 		},
 	}
 	return appConfig
@@ -128,11 +187,22 @@ type RetiApp struct {
 	cliCmd     *cli.Command
 	logger     *slog.Logger
 	signer     algo.MultipleWalletSigner
-	algoClient *algod.Client
+	chain      algo.Chain
 	nfdApi     *swagger.APIClient
 	nfdOnChain *nfdonchain.NfdApi
 
 	retiClient *reti.Reti
+	// endpoints is the algod endpoint pool (also chain) if failover endpoints are configured - nil otherwise
+	endpoints *algo.EndpointPool
+
+	// config is the config file (defaults if there isn't one) - configFile is its name
+	config     *NodemgrConfig
+	configFile string
+
+	// set when the command is exporting unsigned transactions rather than signing (and sending) them
+	exportingUnsigned bool
+	// set when only read commands are allowed, and no keys are loaded (--readonly)
+	readOnly bool
 
 	// just here for flag bootstrapping destination
 	retiAppID       uint64
@@ -144,47 +214,105 @@ type RetiApp struct {
 // also validates) and a nfd nfdApi client - for nfd updates or fetches if caller
 // desires
 func (ac *RetiApp) initClients(ctx context.Context, cmd *cli.Command) (context.Context, error) {
-	network := cmd.String("network")
+	if err := validateOutputFormat(cmd.String("output")); err != nil {
+		return ctx, err
+	}
+	if cmd.String("output") != outputTable {
+		// keep stdout for the output alone
+		logOutput.set(os.Stderr)
+	}
+	// Settings are resolved as documented on NodemgrConfig - flags, env vars (the process env, then env files), the
+	// config file, and then built-in defaults
+	config, configFile, err := LoadNodemgrConfig(cmd.String("configfile"))
+	if err != nil {
+		return ctx, err
+	}
+	ac.config, ac.configFile = config, configFile
+	misc.SetDefaultTransportOptions(config.HTTP)
+	if configFile != "" {
+		misc.Infof(ac.logger, "loaded config file:%s", configFile)
+	}
 
-	if envfile := cmd.String("envfile"); envfile != "" {
+	envfile := cmd.String("envfile")
+	if envfile == "" {
+		envfile = config.EnvFile
+	}
+	if envfile != "" {
 		newCtx, err := loadNamedEnvFile(ctx, envfile)
 		if err != nil {
 			return newCtx, err
 		}
 	}
-	// quick validity check on possible network names...
-	switch network {
-	case "sandbox", "fnet", "betanet", "testnet", "mainnet":
-	default:
-		return ctx, fmt.Errorf("unknown network:%s", network)
+	if !cmd.IsSet("network") {
+		// the network may be set by the env file just loaded, or the config file
+		misc.SetEnvDefault("ALGO_NETWORK", config.Network, "config file "+configFile)
+		if err := syncFlagsFromEnv(cmd); err != nil {
+			return ctx, err
+		}
+	}
+	network := cmd.String("network")
+	// quick validity check on possible network names - the built-in networks or network profiles in the config file
+	if err := config.addNetworks(); err != nil {
+		return ctx, err
+	}
+	if !algo.IsKnownNetwork(network) {
+		return ctx, fmt.Errorf("unknown network:%s - must be %s or defined in networks in the config file", network, strings.Join(algo.BuiltinNetworks, ", "))
 	}
 	var (
 		algoClient *algod.Client
 		api        *swagger.APIClient
-		err        error
 	)
 
 	// Now load .env.{network} overrides -ie: .env.sandbox containing generated mnemonics
-	// by bootstrap testing script
+	// by bootstrap testing script.  The config file values are then applied to anything still not set, and
+	// every flag not set on the command line updated from the env vars we've now loaded.
 	misc.LoadEnvForNetwork(ac.logger, network)
+	if err := applyConfigFile(cmd, config, configFile); err != nil {
+		return ctx, err
+	}
+
+	if isMarkedCommand(cmd, standaloneCommand) {
+		return ctx, nil
+	}
+	if ac.readOnly && !isMarkedCommand(cmd, readCommand) {
+		return ctx, errors.New("only read commands are allowed in read-only mode")
+	}
+	// Secrets (algod token/headers, mnemonics if --env-mnemonics) can come from files, an encrypted file or a secret
+	// store, not just the environment
+	if err := misc.ConfigureSecretProviders(ac.logger); err != nil {
+		return ctx, err
+	}
+	if isMarkedCommand(cmd, offlineCommand) {
+		// only local keys are needed - we may well be on an air-gapped machine
+		localSigner, err := ac.newLocalSigner(cmd)
+		if err != nil {
+			return ctx, err
+		}
+		ac.signer = algo.NewAuthAddrSigner(ac.logger, nil, localSigner)
+		return ctx, nil
+	}
 
 	// Initialize algod client / networks / reti validator app id (testing connectivity as well)
 	cfg := algo.GetNetworkConfig(network)
+	misc.Debugf(ac.logger, "network config: %s", cfg)
 	algoClient, err = algo.GetAlgoClient(ac.logger, cfg)
 	if err != nil {
 		return ctx, err
 	}
-	ac.retiAppID = cfg.RetiAppID
-	// allow secondary override of the IDs via the network specific .env file we just loaded which we couldn't
-	// have known until we'd processed the 'network' override - but only if not already set via CLI, etc.
-	if ac.retiAppID == 0 {
-		setIntFromEnv(&ac.retiAppID, "RETI_APPID")
+	ac.chain = algo.NewAlgodChain(algoClient)
+	if err := algo.VerifyGenesis(ctx, ac.chain, network, cfg); err != nil {
+		return ctx, err
 	}
-	if ac.retiValidatorID == 0 {
-		setIntFromEnv(&ac.retiValidatorID, "RETI_VALIDATORID")
+	if len(ac.config.Algod.Endpoints) > 0 {
+		// reads and submissions fail over to the further endpoints when the node is unavailable (ie: restarting)
+		ac.endpoints, err = ac.newEndpointPool(ctx, network, cfg)
+		if err != nil {
+			return ctx, err
+		}
+		ac.chain = ac.endpoints
 	}
-	if ac.retiNodeNum == 0 {
-		setIntFromEnv(&ac.retiNodeNum, "RETI_NODENUM")
+	if !cmd.IsSet("retiid") {
+		ac.retiAppID = cfg.RetiAppID
 	}
 	if ac.retiNodeNum == 0 && cmd.Bool("usehostname") {
 		// we're assumed in kubernetes environment, try getting the node number from our hostname suffix
@@ -204,42 +332,117 @@ func (ac *RetiApp) initClients(ctx context.Context, cmd *cli.Command) (context.C
 	if ac.retiAppID == 0 {
 		return ctx, fmt.Errorf("the id of the Reti Validator contract must be set using either -retiid or RETI_APPID env var!")
 	}
+	if ac.readOnly {
+		// belt and braces - even if a read command tried, nothing can be signed, sent or changed on the node
+		misc.Infof(ac.logger, "read-only mode - no keys loaded, writes are refused")
+		ac.chain = algo.NewReadOnlyChain(ac.chain)
+	}
 
-	// This will load and initialize mnemonics from the environment - and handles all 'local' signing for the app
-	ac.signer = algo.NewLocalKeyStore(ac.logger)
+	// This will load the keys from the keystore (and, if opted into, mnemonics from the environment) - and handles
+	// all 'local' signing for the app, signing for rekeyed accounts using the keys of their auth address.
+	// If a remote signer is used instead, no keys are loaded into this process at all.
+	var signer algo.MultipleWalletSigner
+	if ac.readOnly {
+		signer = algo.NewReadOnlySigner()
+	} else if remoteSigner := cmd.String("remote-signer"); remoteSigner != "" {
+		signer, err = algo.NewRemoteSigner(ac.logger, remoteSigner, misc.GetSecret(cmd.String("remote-signer-token-secret")))
+	} else {
+		signer, err = ac.newLocalSigner(cmd)
+	}
+	if err != nil {
+		return ctx, err
+	}
+	ac.signer = algo.NewAuthAddrSigner(ac.logger, ac.chain, signer)
 
 	// Inititialize NFD API (if even used)
 	nfdApiCfg := swagger.NewConfiguration()
 	nfdApiCfg.BasePath = cfg.NFDAPIUrl
+	if nfdURL, err := url.Parse(cfg.NFDAPIUrl); err == nil {
+		nfdApiCfg.HTTPClient = &http.Client{Transport: misc.NewResilientTransport(ac.logger, nfdURL.Host, nil, misc.DefaultTransportOptions())}
+	}
 	api = swagger.NewAPIClient(nfdApiCfg)
-	_, _ = algoClient, api
-
-	ac.algoClient = algoClient
 	ac.nfdApi = api
-	nfdOnChain, err := nfdonchain.NewNfdApi(algoClient, cmd.String("network"))
-	if err != nil {
-		return ctx, fmt.Errorf("failed to initialize nfd onchain api client: %v", err)
-	}
-	ac.nfdOnChain = nfdOnChain
+	ac.nfdOnChain = nfdonchain.NewNfdApi(ac.chain, cfg.NFDRegistryID)
 
 	// Initialize the 'reti' client
-	retiClient, err := reti.New(ac.retiAppID, ac.logger, ac.algoClient, ac.signer, ac.retiValidatorID, ac.retiNodeNum)
+	retiClient, err := reti.New(ac.retiAppID, ac.logger, ac.chain, ac.signer, ac.retiValidatorID, ac.retiNodeNum)
 	if err != nil {
 		return ctx, err
 	}
+	retiClient.StrictContractCheck = cmd.Bool("strictcontracts")
+	retiClient.ReadOnly = ac.readOnly
 	ac.retiClient = retiClient
 	return ctx, retiClient.LoadState(ctx)
 }
 
-func setIntFromEnv(val *uint64, envName string) error {
-	if strVal := os.Getenv(envName); strVal != "" {
-		intVal, err := strconv.ParseUint(strVal, 10, 64)
+// newEndpointPool returns the algod node (cfg) as the admin endpoint, and preferred read/submit endpoint, of a pool
+// with the config file's failover endpoints.  Endpoints on another network are refused, but those not reachable
+// are just passed over until they are.
+func (ac *RetiApp) newEndpointPool(ctx context.Context, network string, cfg algo.NetworkConfig) (*algo.EndpointPool, error) {
+	nodeName := cfg.NodeURL
+	if cfg.NodeDataDir != "" {
+		nodeName = cfg.NodeDataDir
+	}
+	endpoints := []algo.AlgodEndpoint{{
+		Name:  nodeName,
+		Roles: []string{algo.RoleAdmin, algo.RoleRead, algo.RoleSubmit},
+		Chain: ac.chain,
+	}}
+	for _, spec := range ac.config.Algod.Endpoints {
+		endpointCfg := cfg
+		endpointCfg.NodeDataDir, endpointCfg.NodeURL, endpointCfg.NodeToken, endpointCfg.NodeHeaders = "", spec.URL, "", nil
+		if spec.TokenSecret != "" {
+			endpointCfg.NodeToken = misc.GetSecret(spec.TokenSecret)
+		}
+		client, err := algo.MakeAlgoClient(ac.logger, endpointCfg)
+		if err != nil {
+			return nil, err
+		}
+		chain := algo.NewAlgodChain(client)
+		if err := algo.VerifyGenesis(ctx, chain, network, cfg); err != nil {
+			if errors.Is(err, algo.ErrGenesisMismatch) {
+				return nil, fmt.Errorf("algod endpoint %s: %w", spec.name(), err)
+			}
+			misc.Warnf(ac.logger, "algod endpoint %s not currently available, err:%v", spec.name(), err)
+		}
+		endpoints = append(endpoints, algo.AlgodEndpoint{Name: spec.name(), Roles: spec.roles(), Chain: chain})
+	}
+	return algo.NewEndpointPool(ac.logger, endpoints)
+}
+
+// newNodeChain returns the chain of another node's algod (ie: for its participation keys) - the token is read from
+// the secret (env var or secrets provider key) if set.  The node must be reachable and on the network.
+func (ac *RetiApp) newNodeChain(ctx context.Context, network string, nodeURL string, tokenSecret string) (algo.Chain, error) {
+	cfg := algo.GetNetworkConfig(network)
+	nodeCfg := cfg
+	nodeCfg.NodeDataDir, nodeCfg.NodeURL, nodeCfg.NodeToken, nodeCfg.NodeHeaders = "", nodeURL, "", nil
+	if tokenSecret != "" {
+		nodeCfg.NodeToken = misc.GetSecret(tokenSecret)
+	}
+	client, err := algo.MakeAlgoClient(ac.logger, nodeCfg)
+	if err != nil {
+		return nil, err
+	}
+	chain := algo.NewAlgodChain(client)
+	if err := algo.VerifyGenesis(ctx, chain, network, cfg); err != nil {
+		return nil, fmt.Errorf("algod %s: %w", nodeURL, err)
+	}
+	return chain, nil
+}
+
+// newLocalSigner returns the signer for the keys in the keystore (if specified - unlocking it via the passphrase file
+// or prompting) and, only if explicitly enabled, the *_MNEMONIC env vars and secrets.
+func (ac *RetiApp) newLocalSigner(cmd *cli.Command) (algo.MultipleWalletSigner, error) {
+	var keystores []*algo.Keystore
+	if filename := cmd.String("keystore"); filename != "" {
+		ks, err := unlockKeystore(filename, cmd.String("keystore-passfile"))
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("unable to unlock keystore %s: %w", filename, err)
 		}
-		*val = intVal
+		misc.Infof(ac.logger, "unlocked keystore %s with %d accounts", filename, len(ks.Accounts()))
+		keystores = append(keystores, ks)
 	}
-	return nil
+	return algo.NewLocalKeyStore(ac.logger, cmd.Bool("env-mnemonics"), keystores...)
 }
 
 func checkConfigured(ctx context.Context, command *cli.Command) (context.Context, error) {
@@ -251,7 +454,7 @@ func checkConfigured(ctx context.Context, command *cli.Command) (context.Context
 
 func loadNamedEnvFile(ctx context.Context, envFile string) (context.Context, error) {
 	misc.Infof(App.logger, "loading env file:%s", envFile)
-	return ctx, godotenv.Load(envFile)
+	return ctx, misc.LoadNamedEnvFile(envFile)
 }
 
 // Version is replaced at build time during docker builds w/ 'release' version
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/algorandfoundation/reti/internal/lib/algo"
+	"github.com/algorandfoundation/reti/internal/lib/misc"
+)
+
+// CapacityOutput is the headroom of the validator, its nodes and pools (validator capacity) - amounts in microAlgo
+type CapacityOutput struct {
+	CurrentRound uint64         `json:"currentRound" yaml:"currentRound"`
+	Pools        []PoolCapacity `json:"pools" yaml:"pools"`
+	// Nodes are every node of the validator - including those without pools
+	Nodes       []NodeCapacity `json:"nodes" yaml:"nodes"`
+	TotalStaked uint64         `json:"totalStaked" yaml:"totalStaked"`
+	// SaturationStake is the stake past which the validator is saturated (and rewards are reduced), MaxStake the
+	// most stake the validator can have
+	SaturationStake uint64 `json:"saturationStake" yaml:"saturationStake"`
+	MaxStake        uint64 `json:"maxStake" yaml:"maxStake"`
+	// FreePoolSlots is how many more pools the validator can add, across all its nodes
+	FreePoolSlots int `json:"freePoolSlots" yaml:"freePoolSlots"`
+	// InflowPerDay is the total of the pools' inflow, and ProjectedSaturated when the validator saturates at that rate
+	InflowPerDay       uint64     `json:"inflowPerDay" yaml:"inflowPerDay"`
+	ProjectedSaturated *time.Time `json:"projectedSaturated,omitempty" yaml:"projectedSaturated,omitempty"`
+}
+
+type PoolCapacity struct {
+	Pool       int    `json:"pool" yaml:"pool"`
+	Node       int    `json:"node" yaml:"node"`
+	AppID      uint64 `json:"appId" yaml:"appId"`
+	Staked     uint64 `json:"staked" yaml:"staked"`
+	MaxStake   uint64 `json:"maxStake" yaml:"maxStake"`
+	Stakers    uint64 `json:"stakers" yaml:"stakers"`
+	MaxStakers uint64 `json:"maxStakers" yaml:"maxStakers"`
+	// FillPct is how full the pool is (0-100) - of its max stake or max stakers, whichever is fuller
+	FillPct float64 `json:"fillPct" yaml:"fillPct"`
+	// InflowPerDay is the stake of the stakers who (re)entered the pool within the projection window, per day.  It's
+	// an estimate - adding stake resets a staker's entry, so their earlier stake is counted too.  ProjectedFull is
+	// when the pool's stake fills at that rate - unset if there's no inflow (or no projection).
+	InflowPerDay  uint64     `json:"inflowPerDay" yaml:"inflowPerDay"`
+	ProjectedFull *time.Time `json:"projectedFull,omitempty" yaml:"projectedFull,omitempty"`
+}
+
+type NodeCapacity struct {
+	Node      int    `json:"node" yaml:"node"`
+	Pools     int    `json:"pools" yaml:"pools"`
+	FreeSlots int    `json:"freeSlots" yaml:"freeSlots"`
+	Staked    uint64 `json:"staked" yaml:"staked"`
+	// Headroom is the stake the node's pools can still take
+	Headroom uint64 `json:"headroom" yaml:"headroom"`
+}
+
+// getCapacity returns the capacity of the validator, projecting when pools fill from the inflow over the window
+// (which fetches every pool's ledger) - no projection if window is 0.
+func getCapacity(ctx context.Context, window time.Duration) (CapacityOutput, error) {
+	info := App.retiClient.Info()
+	constraints, err := App.retiClient.GetProtocolConstraints()
+	if err != nil {
+		return CapacityOutput{}, fmt.Errorf("unable to get protocol constraints: %w", err)
+	}
+	status, err := App.chain.Status(ctx)
+	if err != nil {
+		return CapacityOutput{}, fmt.Errorf("unable to fetch node status: %w", err)
+	}
+	output := CapacityOutput{
+		CurrentRound:    status.LastRound,
+		Pools:           []PoolCapacity{},
+		Nodes:           []NodeCapacity{},
+		SaturationStake: constraints.AmtConsideredSaturated,
+		MaxStake:        constraints.MaxAlgoPerValidator,
+	}
+
+	maxPerPool := maxStakePerPool(info.Config, len(info.Pools), constraints)
+
+	var windowRounds uint64
+	if window > 0 {
+		blockTime, err := algo.CalcBlockTimes(ctx, App.chain, 20)
+		if err != nil {
+			return CapacityOutput{}, err
+		}
+		windowRounds = uint64(window / blockTime)
+	}
+	projectFull := func(headroom uint64, inflowPerDay uint64) *time.Time {
+		if headroom > 0 && inflowPerDay == 0 {
+			return nil
+		}
+		full := time.Now()
+		if headroom == 0 {
+			return &full
+		}
+		full = full.Add(time.Duration(float64(headroom) / float64(inflowPerDay) * float64(24*time.Hour)))
+		return &full
+	}
+
+	for nodeIdx, nodeConfig := range info.NodePoolAssignments.Nodes {
+		output.FreePoolSlots += max(info.Config.PoolsPerNode-len(nodeConfig.PoolAppIds), 0)
+		node := NodeCapacity{
+			Node:      nodeIdx + 1,
+			Pools:     len(nodeConfig.PoolAppIds),
+			FreeSlots: max(info.Config.PoolsPerNode-len(nodeConfig.PoolAppIds), 0),
+		}
+		for poolIdx, pool := range info.Pools {
+			if !slices.Contains(nodeConfig.PoolAppIds, pool.PoolAppId) {
+				continue
+			}
+			poolCapacity := PoolCapacity{
+				Pool:       poolIdx + 1,
+				Node:       nodeIdx + 1,
+				AppID:      pool.PoolAppId,
+				Staked:     pool.TotalAlgoStaked,
+				MaxStake:   maxPerPool,
+				Stakers:    uint64(pool.TotalStakers),
+				MaxStakers: constraints.MaxStakersPerPool,
+			}
+			poolCapacity.FillPct = 100 * max(float64(poolCapacity.Staked)/float64(max(poolCapacity.MaxStake, 1)),
+				float64(poolCapacity.Stakers)/float64(max(poolCapacity.MaxStakers, 1)))
+			headroom := poolCapacity.MaxStake - min(poolCapacity.Staked, poolCapacity.MaxStake)
+			if window > 0 {
+				ledger, err := App.retiClient.GetLedgerForPool(pool.PoolAppId)
+				if err != nil {
+					return CapacityOutput{}, fmt.Errorf("unable to get ledger for pool app id:%d: %w", pool.PoolAppId, err)
+				}
+				var inflow uint64
+				for _, staker := range ledger {
+					if staker.Account != types.ZeroAddress && staker.EntryRound+windowRounds > status.LastRound {
+						inflow += staker.Balance
+					}
+				}
+				poolCapacity.InflowPerDay = uint64(float64(inflow) / window.Hours() * 24)
+				poolCapacity.ProjectedFull = projectFull(headroom, poolCapacity.InflowPerDay)
+				output.InflowPerDay += poolCapacity.InflowPerDay
+			}
+			node.Staked += poolCapacity.Staked
+			node.Headroom += headroom
+			output.TotalStaked += poolCapacity.Staked
+			output.Pools = append(output.Pools, poolCapacity)
+		}
+		output.Nodes = append(output.Nodes, node)
+	}
+	if window > 0 {
+		output.ProjectedSaturated = projectFull(output.SaturationStake-min(output.TotalStaked, output.SaturationStake), output.InflowPerDay)
+	}
+	return output, nil
+}
+
+// checkAutoPools adds a pool once every pool is at least the fill threshold full (daemon.autoPools) - on the node,
+// out of every node of the validator (with pools or not), with the most free pool slots (the lowest numbered if tied).
+// Every node's daemon makes the same choice and only the chosen node's daemon adds the pool, so it's added once - a
+// daemon has to be running for each node number of the validator.
+func (d *Daemon) checkAutoPools(ctx context.Context) error {
+	capacity, err := getCapacity(ctx, 0)
+	if err != nil {
+		return err
+	}
+	if len(capacity.Pools) == 0 || capacity.TotalStaked >= capacity.MaxStake {
+		return nil
+	}
+	for _, pool := range capacity.Pools {
+		if pool.FillPct < d.config.AutoPools.FillThreshold {
+			return nil
+		}
+	}
+	var target NodeCapacity
+	for _, node := range capacity.Nodes {
+		if node.FreeSlots > target.FreeSlots {
+			target = node
+		}
+	}
+	if target.Node == 0 {
+		if !d.autoPoolsBlocked {
+			misc.Warnf(d.logger, "all pools are at least %.0f%% full but no node has a free pool slot", d.config.AutoPools.FillThreshold)
+			d.autoPoolsBlocked = true
+		}
+		return nil
+	}
+	d.autoPoolsBlocked = false
+	if uint64(target.Node) != App.retiClient.NodeNum {
+		misc.Debugf(d.logger, "all pools are at least %.0f%% full, a pool is to be added by the daemon of node %d", d.config.AutoPools.FillThreshold, target.Node)
+		return nil
+	}
+
+	misc.Infof(d.logger, "all pools are at least %.0f%% full, adding a pool to node %d", d.config.AutoPools.FillThreshold, target.Node)
+	poolKey, err := App.retiClient.AddStakingPool(uint64(target.Node))
+	if err != nil {
+		return fmt.Errorf("unable to add pool to node %d: %w", target.Node, err)
+	}
+	err = App.retiClient.CheckAndInitStakingPoolStorage(poolKey)
+	if err != nil {
+		return fmt.Errorf("unable to initialize storage of new pool app id:%d: %w", poolKey.PoolAppId, err)
+	}
+	d.actions.record(actionPool, "pool %d [app id:%d] added to node %d - all pools were at least %.0f%% full",
+		poolKey.PoolId, poolKey.PoolAppId, target.Node, d.config.AutoPools.FillThreshold)
+	return App.retiClient.LoadState(ctx)
+}
@@ -0,0 +1,471 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/algorandfoundation/reti/internal/lib/algo"
+	"github.com/algorandfoundation/reti/internal/lib/misc"
+)
+
+// defaultConfigFile is loaded (if present) when no config file is specified
+const defaultConfigFile = "nodemgr.yaml"
+
+// NodemgrConfig is the nodemgr config file (yaml) - see nodemgr.example.yaml for every setting.
+//
+// Settings are resolved in this order, the first found winning:
+//  1. command line flags
+//  2. environment variables - the process environment, then --envfile, .env.{network}, .env.local and .env
+//  3. the config file
+//  4. secret providers (secret values only - see misc.ConfigureSecretProviders)
+//  5. built-in defaults (per network)
+type NodemgrConfig struct {
+	Network         string `yaml:"network,omitempty"`
+	EnvFile         string `yaml:"envfile,omitempty"`
+	RetiAppID       uint64 `yaml:"retiAppId,omitempty"`
+	ValidatorID     uint64 `yaml:"validatorId,omitempty"`
+	Node            uint64 `yaml:"node,omitempty"`
+	UseHostname     bool   `yaml:"useHostname,omitempty"`
+	StrictContracts bool   `yaml:"strictContracts,omitempty"`
+	ReadOnly        bool   `yaml:"readOnly,omitempty"`
+
+	Keystore         string `yaml:"keystore,omitempty"`
+	KeystorePassfile string `yaml:"keystorePassfile,omitempty"`
+	RemoteSigner     string `yaml:"remoteSigner,omitempty"`
+	EnvMnemonics     bool   `yaml:"envMnemonics,omitempty"`
+
+	Algod   AlgodConfig   `yaml:"algod,omitempty"`
+	Secrets SecretsConfig `yaml:"secrets,omitempty"`
+	Daemon  DaemonConfig  `yaml:"daemon,omitempty"`
+	// HTTP is the throttling, retries and circuit breaking of requests to each algod endpoint and the NFD API
+	HTTP misc.TransportOptions `yaml:"http"`
+
+	// Networks are custom networks (private networks, localnets) selectable by name via network, alongside the
+	// built-in mainnet, testnet, betanet, fnet and sandbox
+	Networks map[string]NetworkProfile `yaml:"networks,omitempty"`
+}
+
+// NetworkProfile defines a custom network - its values are the network's defaults, so algod settings, env vars and
+// flags still override them just as they do for the built-in networks
+type NetworkProfile struct {
+	AlgodURL string `yaml:"algodUrl,omitempty"`
+	// AlgodToken is a secret - but localnets commonly use a well known token
+	AlgodToken    string `yaml:"algodToken,omitempty"`
+	RetiAppID     uint64 `yaml:"retiAppId,omitempty"`
+	NFDRegistryID uint64 `yaml:"nfdRegistryId,omitempty"`
+	NFDAPIUrl     string `yaml:"nfdApiUrl,omitempty"`
+	// GenesisID and GenesisHash (base64) are verified against algod at startup, if set
+	GenesisID   string `yaml:"genesisId,omitempty"`
+	GenesisHash string `yaml:"genesisHash,omitempty"`
+}
+
+func (p NetworkProfile) networkConfig() algo.NetworkConfig {
+	return algo.NetworkConfig{
+		NodeURL:       p.AlgodURL,
+		NodeToken:     p.AlgodToken,
+		RetiAppID:     p.RetiAppID,
+		NFDRegistryID: p.NFDRegistryID,
+		NFDAPIUrl:     p.NFDAPIUrl,
+		GenesisID:     p.GenesisID,
+		GenesisHash:   p.GenesisHash,
+	}
+}
+
+// validNetworkName restricts network names to those usable in .env.{network} filenames
+var validNetworkName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+type AlgodConfig struct {
+	DataDir string `yaml:"dataDir,omitempty"`
+	URL     string `yaml:"url,omitempty"`
+	// Token and AdminToken are secrets - prefer a secrets provider over putting them in the config file
+	Token      string            `yaml:"token,omitempty"`
+	AdminToken string            `yaml:"adminToken,omitempty"`
+	Headers    map[string]string `yaml:"headers,omitempty"`
+	NFDAPIUrl  string            `yaml:"nfdApiUrl,omitempty"`
+	// Endpoints are further algod nodes reads and transaction submission fail over to when the node above is
+	// unavailable.  The node above remains the only one used for participation keys.
+	Endpoints []AlgodEndpointSpec `yaml:"endpoints,omitempty"`
+}
+
+// AlgodEndpointSpec is a failover algod endpoint
+type AlgodEndpointSpec struct {
+	URL string `yaml:"url"`
+	// TokenSecret names the secret (env var or secrets provider key) holding the endpoint's token, if it needs one
+	TokenSecret string `yaml:"tokenSecret,omitempty"`
+	// Roles are read and/or submit - both if not specified
+	Roles []string `yaml:"roles,omitempty"`
+}
+
+func (e AlgodEndpointSpec) validate() error {
+	if u, err := url.Parse(e.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid algod endpoint url:%s - must be http(s)://host[:port]", e.URL)
+	}
+	for _, role := range e.Roles {
+		if role != algo.RoleRead && role != algo.RoleSubmit {
+			return fmt.Errorf("invalid algod endpoint role:%s - must be %s or %s (only the algod node itself is %s)", role, algo.RoleRead, algo.RoleSubmit, algo.RoleAdmin)
+		}
+	}
+	return nil
+}
+
+// name identifies the endpoint without its path or query - which might contain tokens
+func (e AlgodEndpointSpec) name() string {
+	u, _ := url.Parse(e.URL)
+	return fmt.Sprintf("%s://%s", u.Scheme, u.Host)
+}
+
+func (e AlgodEndpointSpec) roles() []string {
+	if len(e.Roles) == 0 {
+		return []string{algo.RoleRead, algo.RoleSubmit}
+	}
+	return e.Roles
+}
+
+func (e AlgodEndpointSpec) String() string {
+	return fmt.Sprintf("%s %v", e.name(), e.roles())
+}
+
+type SecretsConfig struct {
+	Providers       []string `yaml:"providers,omitempty"`
+	Dir             string   `yaml:"dir,omitempty"`
+	EncFile         string   `yaml:"encFile,omitempty"`
+	EncFilePassfile string   `yaml:"encFilePassfile,omitempty"`
+	HTTPURL         string   `yaml:"httpUrl,omitempty"`
+	HTTPToken       string   `yaml:"httpToken,omitempty"`
+}
+
+// DaemonConfig is the daemon tuning - unset values use the defaults of defaultNodemgrConfig
+type DaemonConfig struct {
+	Port int `yaml:"port,omitempty"`
+	// KeyCheckInterval is how often pools, participation keys and the validator config are checked
+	KeyCheckInterval time.Duration `yaml:"keyCheckInterval,omitempty"`
+	// BlockTimeInterval is how often the average block time is recalculated
+	BlockTimeInterval time.Duration   `yaml:"blockTimeInterval,omitempty"`
+	Keys              KeyPolicy       `yaml:"keys,omitempty"`
+	Evictions         EvictionPolicy  `yaml:"evictions,omitempty"`
+	AutoPools         AutoPoolPolicy  `yaml:"autoPools,omitempty"`
+	Alerts            []AlertSinkSpec `yaml:"alerts,omitempty"`
+}
+
+// KeyPolicy controls the participation keys the daemon generates
+type KeyPolicy struct {
+	// LengthDays is how long generated keys are valid for
+	LengthDays int `yaml:"lengthDays,omitempty"`
+	// RenewDaysBefore is how long before expiry a replacement key is generated
+	RenewDaysBefore int `yaml:"renewDaysBefore,omitempty"`
+}
+
+// EvictionPolicy controls the removal of stakers no longer meeting the validator's entry gating
+type EvictionPolicy struct {
+	// Disabled turns off evictions entirely
+	Disabled bool          `yaml:"disabled,omitempty"`
+	Interval time.Duration `yaml:"interval,omitempty"`
+}
+
+// AutoPoolPolicy controls the daemon adding pools as the validator's pools fill up
+type AutoPoolPolicy struct {
+	// Enabled has a pool added once every pool is at least FillThreshold percent full - of its max stake or max
+	// stakers
+	Enabled       bool    `yaml:"enabled,omitempty"`
+	FillThreshold float64 `yaml:"fillThreshold,omitempty"`
+}
+
+func defaultNodemgrConfig() *NodemgrConfig {
+	return &NodemgrConfig{
+		HTTP: misc.DefaultTransportOptions(),
+		Daemon: DaemonConfig{
+			Port:              6260,
+			KeyCheckInterval:  1 * time.Minute,
+			BlockTimeInterval: 30 * time.Minute,
+			Keys: KeyPolicy{
+				LengthDays:      GeneratedKeyLengthInDays,
+				RenewDaysBefore: DaysPriorToExpToRenew,
+			},
+			Evictions: EvictionPolicy{Interval: 5 * time.Minute},
+			AutoPools: AutoPoolPolicy{FillThreshold: 90},
+		},
+	}
+}
+
+// LoadNodemgrConfig reads the config file - unknown fields are rejected so typos don't silently leave settings at
+// their defaults.  If filename is empty, defaultConfigFile is loaded if it exists.
+func LoadNodemgrConfig(filename string) (*NodemgrConfig, string, error) {
+	config := defaultNodemgrConfig()
+	if filename == "" {
+		if _, err := os.Stat(defaultConfigFile); err != nil {
+			return config, "", nil
+		}
+		filename = defaultConfigFile
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, "", err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, "", fmt.Errorf("invalid config file %s: %w", filename, err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, "", fmt.Errorf("invalid config file %s: %w", filename, err)
+	}
+	return config, filename, nil
+}
+
+func (c *NodemgrConfig) validate() error {
+	d := c.Daemon
+	if d.KeyCheckInterval < 10*time.Second || d.BlockTimeInterval < time.Minute || d.Evictions.Interval < time.Minute {
+		return errors.New("daemon keyCheckInterval must be at least 10s, blockTimeInterval and evictions interval at least 1m")
+	}
+	if d.Keys.LengthDays < 1 || d.Keys.RenewDaysBefore < 1 || d.Keys.RenewDaysBefore >= d.Keys.LengthDays {
+		return errors.New("daemon keys lengthDays and renewDaysBefore must be at least 1, with renewDaysBefore less than lengthDays")
+	}
+	if d.AutoPools.FillThreshold <= 0 || d.AutoPools.FillThreshold > 100 {
+		return errors.New("daemon autoPools fillThreshold must be a percentage, more than 0 and at most 100")
+	}
+	for _, sink := range d.Alerts {
+		if err := sink.validate(); err != nil {
+			return err
+		}
+	}
+	h := c.HTTP
+	if h.RateLimit < 0 || (h.RateLimit > 0 && h.Burst < 1) || h.Timeout < 0 || h.MaxRetries < 0 || h.MaxRetries > 10 {
+		return errors.New("http rateLimit can't be negative (0 for no limit) with burst at least 1, and maxRetries must be 0-10")
+	}
+	if h.BreakerThreshold < 0 || (h.BreakerThreshold > 0 && h.BreakerCooldown < time.Second) {
+		return errors.New("http breakerThreshold can't be negative (0 to disable) with breakerCooldown at least 1s")
+	}
+	for _, endpoint := range c.Algod.Endpoints {
+		if err := endpoint.validate(); err != nil {
+			return err
+		}
+	}
+	for name, profile := range c.Networks {
+		if !validNetworkName.MatchString(name) {
+			return fmt.Errorf("invalid network name:%s - only letters, digits, _ and - are allowed", name)
+		}
+		if slices.Contains(algo.BuiltinNetworks, name) {
+			return fmt.Errorf("network:%s is a built-in network and can't be redefined - use the algod settings instead", name)
+		}
+		if profile.GenesisHash != "" {
+			if hash, err := base64.StdEncoding.DecodeString(profile.GenesisHash); err != nil || len(hash) != 32 {
+				return fmt.Errorf("network:%s genesisHash must be the base64 encoded 32 byte hash", name)
+			}
+		}
+	}
+	return nil
+}
+
+// addNetworks makes the config file's network profiles selectable networks
+func (c *NodemgrConfig) addNetworks() error {
+	for name, profile := range c.Networks {
+		if err := algo.AddNetwork(name, profile.networkConfig()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// configSetting is a setting which can come from a flag, env var or the config file
+type configSetting struct {
+	// key is the config file key
+	key  string
+	flag string
+	env  string
+	// secret values are never displayed
+	secret bool
+	// fromConfig returns the config file value - "" if not set
+	fromConfig func(c *NodemgrConfig) string
+	// fromDefault returns the built-in default, if any
+	fromDefault func(net algo.NetworkConfig) string
+}
+
+func uintSetting(val uint64) string {
+	if val == 0 {
+		return ""
+	}
+	return strconv.FormatUint(val, 10)
+}
+
+func boolSetting(val bool) string {
+	if !val {
+		return ""
+	}
+	return "true"
+}
+
+// configSettings are every setting which can be set by flags or env vars - in the order they're displayed
+var configSettings = []configSetting{
+	{key: "network", flag: "network", env: "ALGO_NETWORK", fromConfig: func(c *NodemgrConfig) string { return c.Network },
+		fromDefault: func(algo.NetworkConfig) string { return "mainnet" }},
+	{key: "envfile", flag: "envfile", env: "RETI_ENVFILE", fromConfig: func(c *NodemgrConfig) string { return c.EnvFile }},
+	{key: "retiAppId", flag: "retiid", env: "RETI_APPID", fromConfig: func(c *NodemgrConfig) string { return uintSetting(c.RetiAppID) },
+		fromDefault: func(net algo.NetworkConfig) string { return uintSetting(net.RetiAppID) }},
+	{key: "validatorId", flag: "validator", env: "RETI_VALIDATORID", fromConfig: func(c *NodemgrConfig) string { return uintSetting(c.ValidatorID) }},
+	{key: "node", flag: "node", env: "RETI_NODENUM", fromConfig: func(c *NodemgrConfig) string { return uintSetting(c.Node) }},
+	{key: "useHostname", flag: "usehostname", env: "RETI_USEHOSTNAME", fromConfig: func(c *NodemgrConfig) string { return boolSetting(c.UseHostname) }},
+	{key: "strictContracts", flag: "strictcontracts", env: "RETI_STRICT_CONTRACTS", fromConfig: func(c *NodemgrConfig) string { return boolSetting(c.StrictContracts) }},
+	{key: "readOnly", flag: "readonly", env: "RETI_READONLY", fromConfig: func(c *NodemgrConfig) string { return boolSetting(c.ReadOnly) }},
+	{key: "keystore", flag: "keystore", env: "RETI_KEYSTORE", fromConfig: func(c *NodemgrConfig) string { return c.Keystore }},
+	{key: "keystorePassfile", flag: "keystore-passfile", env: "RETI_KEYSTORE_PASSFILE", fromConfig: func(c *NodemgrConfig) string { return c.KeystorePassfile }},
+	{key: "remoteSigner", flag: "remote-signer", env: "RETI_REMOTE_SIGNER", fromConfig: func(c *NodemgrConfig) string { return c.RemoteSigner }},
+	{key: "envMnemonics", flag: "env-mnemonics", env: "RETI_ENV_MNEMONICS", fromConfig: func(c *NodemgrConfig) string { return boolSetting(c.EnvMnemonics) }},
+	{key: "algod.dataDir", env: "ALGORAND_DATA", fromConfig: func(c *NodemgrConfig) string { return c.Algod.DataDir }},
+	{key: "algod.url", env: "ALGO_ALGOD_URL", fromConfig: func(c *NodemgrConfig) string { return c.Algod.URL },
+		fromDefault: func(net algo.NetworkConfig) string { return net.NodeURL }},
+	{key: "algod.token", env: "ALGO_ALGOD_TOKEN", secret: true, fromConfig: func(c *NodemgrConfig) string { return c.Algod.Token },
+		fromDefault: func(net algo.NetworkConfig) string { return net.NodeToken }},
+	{key: "algod.adminToken", env: "ALGO_ALGOD_ADMIN_TOKEN", secret: true, fromConfig: func(c *NodemgrConfig) string { return c.Algod.AdminToken }},
+	{key: "algod.headers", env: "ALGO_ALGOD_HEADERS", secret: true, fromConfig: func(c *NodemgrConfig) string {
+		var headers []string
+		for _, name := range slices.Sorted(maps.Keys(c.Algod.Headers)) {
+			headers = append(headers, name+":"+c.Algod.Headers[name])
+		}
+		return strings.Join(headers, ",")
+	}},
+	{key: "algod.nfdApiUrl", env: "ALGO_NFD_URL", fromConfig: func(c *NodemgrConfig) string { return c.Algod.NFDAPIUrl },
+		fromDefault: func(net algo.NetworkConfig) string { return net.NFDAPIUrl }},
+	{key: "secrets.providers", env: "RETI_SECRETS_PROVIDERS", fromConfig: func(c *NodemgrConfig) string { return strings.Join(c.Secrets.Providers, ",") },
+		fromDefault: func(algo.NetworkConfig) string { return misc.FileSecretsProvider }},
+	{key: "secrets.dir", env: "RETI_SECRETS_DIR", fromConfig: func(c *NodemgrConfig) string { return c.Secrets.Dir },
+		fromDefault: func(algo.NetworkConfig) string { return "/run/secrets" }},
+	{key: "secrets.encFile", env: "RETI_SECRETS_ENCFILE", fromConfig: func(c *NodemgrConfig) string { return c.Secrets.EncFile }},
+	{key: "secrets.encFilePassfile", env: "RETI_SECRETS_ENCFILE_PASSFILE", fromConfig: func(c *NodemgrConfig) string { return c.Secrets.EncFilePassfile }},
+	{key: "secrets.httpUrl", env: "RETI_SECRETS_HTTP_URL", fromConfig: func(c *NodemgrConfig) string { return c.Secrets.HTTPURL }},
+	{key: "secrets.httpToken", env: "RETI_SECRETS_HTTP_TOKEN", secret: true, fromConfig: func(c *NodemgrConfig) string { return c.Secrets.HTTPToken }},
+}
+
+// applyConfigFile makes the config file values the defaults for any settings not set by flags or env vars.  Values
+// are applied as env vars (so everything reading them sees the same value) and then flags are updated from any
+// env vars set after the flags were parsed (env files and the config file).
+func applyConfigFile(cmd *cli.Command, config *NodemgrConfig, filename string) error {
+	for _, setting := range configSettings {
+		misc.SetEnvDefault(setting.env, setting.fromConfig(config), "config file "+filename)
+	}
+	return syncFlagsFromEnv(cmd)
+}
+
+// syncFlagsFromEnv sets any flags not already set from their env var - env vars loaded from env files after the
+// command line was parsed (ie: .env.{network}) are otherwise not seen by the flags
+func syncFlagsFromEnv(cmd *cli.Command) error {
+	for _, setting := range configSettings {
+		if setting.flag == "" || cmd.IsSet(setting.flag) {
+			continue
+		}
+		if value := os.Getenv(setting.env); value != "" {
+			if err := cmd.Set(setting.flag, value); err != nil {
+				return fmt.Errorf("invalid %s value from %s: %w", setting.env, settingSource(cmd, setting, value), err)
+			}
+		}
+	}
+	return nil
+}
+
+// effectiveSetting is the resolved value of a setting along with where it came from
+type effectiveSetting struct {
+	Key    string `json:"key" yaml:"key"`
+	Value  string `json:"value" yaml:"value"`
+	Source string `json:"source" yaml:"source"`
+}
+
+// settingSource describes where the setting's current value came from
+func settingSource(cmd *cli.Command, setting configSetting, value string) string {
+	envValue := os.Getenv(setting.env)
+	if setting.flag != "" && cmd.IsSet(setting.flag) && envValue != value {
+		return "flag --" + setting.flag
+	}
+	if envValue != "" {
+		if source := misc.EnvSource(setting.env); source != "" {
+			return fmt.Sprintf("%s (as %s)", source, setting.env)
+		}
+		return "env " + setting.env
+	}
+	return ""
+}
+
+// effectiveSettings resolves every setting, redacting secrets
+func effectiveSettings(cmd *cli.Command, config *NodemgrConfig, network string) []effectiveSetting {
+	var (
+		settings   []effectiveSetting
+		netDefault = algo.GetNetworkDefaults(network)
+	)
+	for _, setting := range configSettings {
+		var value string
+		if setting.flag != "" && cmd.IsSet(setting.flag) {
+			value = fmt.Sprint(cmd.Value(setting.flag))
+		}
+		if value == "" {
+			value = os.Getenv(setting.env)
+		}
+		source := settingSource(cmd, setting, value)
+		if value == "" && setting.secret {
+			if value = misc.GetProviderSecret(setting.env); value != "" {
+				source = "secret provider"
+			}
+		}
+		if value == "" && setting.fromDefault != nil {
+			if value = setting.fromDefault(netDefault); value != "" {
+				source = "default"
+				if _, isProfile := config.Networks[network]; isProfile && setting.key != "network" {
+					source = "config file network profile " + network
+				}
+			}
+		}
+		if value == "" {
+			source = "not set"
+		}
+		if setting.secret && value != "" {
+			value = fmt.Sprintf("(redacted, length:%d)", len(value))
+		}
+		settings = append(settings, effectiveSetting{Key: setting.key, Value: value, Source: source})
+	}
+
+	for i, endpoint := range config.Algod.Endpoints {
+		settings = append(settings, effectiveSetting{Key: fmt.Sprintf("algod.endpoints[%d]", i), Value: endpoint.String(), Source: "config file"})
+	}
+
+	defaults := defaultNodemgrConfig()
+	configSetting := func(key string, value any, defValue any) {
+		source := "default"
+		if value != defValue {
+			source = "config file"
+		}
+		settings = append(settings, effectiveSetting{Key: key, Value: fmt.Sprint(value), Source: source})
+	}
+	configSetting("http.rateLimit", config.HTTP.RateLimit, defaults.HTTP.RateLimit)
+	configSetting("http.burst", config.HTTP.Burst, defaults.HTTP.Burst)
+	configSetting("http.timeout", config.HTTP.Timeout, defaults.HTTP.Timeout)
+	configSetting("http.maxRetries", config.HTTP.MaxRetries, defaults.HTTP.MaxRetries)
+	configSetting("http.breakerThreshold", config.HTTP.BreakerThreshold, defaults.HTTP.BreakerThreshold)
+	configSetting("http.breakerCooldown", config.HTTP.BreakerCooldown, defaults.HTTP.BreakerCooldown)
+	daemonSetting := func(key string, value any, defValue any) {
+		configSetting("daemon."+key, value, defValue)
+	}
+	daemonSetting("port", config.Daemon.Port, defaults.Daemon.Port)
+	daemonSetting("keyCheckInterval", config.Daemon.KeyCheckInterval, defaults.Daemon.KeyCheckInterval)
+	daemonSetting("blockTimeInterval", config.Daemon.BlockTimeInterval, defaults.Daemon.BlockTimeInterval)
+	daemonSetting("keys.lengthDays", config.Daemon.Keys.LengthDays, defaults.Daemon.Keys.LengthDays)
+	daemonSetting("keys.renewDaysBefore", config.Daemon.Keys.RenewDaysBefore, defaults.Daemon.Keys.RenewDaysBefore)
+	daemonSetting("evictions.disabled", config.Daemon.Evictions.Disabled, defaults.Daemon.Evictions.Disabled)
+	daemonSetting("evictions.interval", config.Daemon.Evictions.Interval, defaults.Daemon.Evictions.Interval)
+	daemonSetting("autoPools.enabled", config.Daemon.AutoPools.Enabled, defaults.Daemon.AutoPools.Enabled)
+	daemonSetting("autoPools.fillThreshold", config.Daemon.AutoPools.FillThreshold, defaults.Daemon.AutoPools.FillThreshold)
+	for i, sink := range config.Daemon.Alerts {
+		settings = append(settings, effectiveSetting{Key: fmt.Sprintf("daemon.alerts[%d]", i), Value: sink.String(), Source: "config file"})
+	}
+	return settings
+}
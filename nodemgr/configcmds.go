@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/algorandfoundation/reti/internal/lib/misc"
+)
+
+func GetConfigCmdOpts() *cli.Command {
+	return &cli.Command{
+		Name:     "config",
+		Usage:    "Display the nodemgr configuration (see --configfile)",
+		Metadata: map[string]any{standaloneCommand: true},
+		Commands: []*cli.Command{
+			{
+				Name:     "show",
+				Usage:    "Display the config file settings - or, with --effective, every resolved setting and where it came from",
+				Action:   ConfigShow,
+				Metadata: map[string]any{standaloneCommand: true},
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "effective",
+						Usage: "Display the effective value (secrets redacted) and source of every setting",
+					},
+				},
+			},
+		},
+	}
+}
+
+func ConfigShow(ctx context.Context, command *cli.Command) error {
+	if !command.Bool("effective") {
+		if App.configFile == "" {
+			fmt.Println("# no config file - using defaults")
+		} else {
+			fmt.Printf("# %s\n", App.configFile)
+		}
+		// never display secrets - even those in the config file itself
+		config := *App.config
+		for _, secret := range []*string{&config.Algod.Token, &config.Algod.AdminToken, &config.Secrets.HTTPToken} {
+			if *secret != "" {
+				*secret = "(redacted)"
+			}
+		}
+		if len(config.Algod.Headers) > 0 {
+			config.Algod.Headers = map[string]string{}
+			for name := range App.config.Algod.Headers {
+				config.Algod.Headers[name] = "(redacted)"
+			}
+		}
+		if len(config.Networks) > 0 {
+			config.Networks = map[string]NetworkProfile{}
+			for name, profile := range App.config.Networks {
+				if profile.AlgodToken != "" {
+					profile.AlgodToken = "(redacted)"
+				}
+				config.Networks[name] = profile
+			}
+		}
+		// endpoint urls might have tokens in their path or query
+		config.Algod.Endpoints = nil
+		for _, endpoint := range App.config.Algod.Endpoints {
+			config.Algod.Endpoints = append(config.Algod.Endpoints, AlgodEndpointSpec{URL: endpoint.String(), TokenSecret: endpoint.TokenSecret})
+		}
+		config.Daemon.Alerts = nil
+		for _, sink := range App.config.Daemon.Alerts {
+			config.Daemon.Alerts = append(config.Daemon.Alerts, AlertSinkSpec{Webhook: "(redacted)", Events: sink.Events})
+		}
+		out, err := yaml.Marshal(config)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(out))
+		return nil
+	}
+
+	// resolve secrets from the providers too - but only as a source, so don't fail if they're misconfigured
+	if err := misc.ConfigureSecretProviders(App.logger); err != nil {
+		misc.Warnf(App.logger, "secret providers not available: %v", err)
+	}
+	settings := effectiveSettingsOutput(effectiveSettings(command, App.config, command.String("network")))
+	return printOutput(command, settings, func(out io.Writer) {
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "Setting\tValue\tSource\t")
+		for _, setting := range settings {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t\n", setting.Key, setting.Value, setting.Source)
+		}
+		tw.Flush()
+	})
+}
+
+type effectiveSettingsOutput []effectiveSetting
+
+func (e effectiveSettingsOutput) csvRecords() [][]string {
+	records := [][]string{{"key", "value", "source"}}
+	for _, setting := range e {
+		records = append(records, []string{setting.Key, setting.Value, setting.Source})
+	}
+	return records
+}
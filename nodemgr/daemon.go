@@ -13,7 +13,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
 	"github.com/algorand/go-algorand-sdk/v2/crypto"
 	"github.com/algorand/go-algorand-sdk/v2/types"
 	"github.com/mailgun/holster/v4/syncutil"
@@ -29,26 +28,38 @@ const (
 	OnlineStatus             = "Online"
 	GeneratedKeyLengthInDays = 7
 	DaysPriorToExpToRenew    = 1
+	// EndpointCheckInterval is how often the health of the algod endpoints is checked (if failover endpoints are
+	// configured)
+	EndpointCheckInterval = 10 * time.Second
 )
 
 // Daemon provides a 'little' separation in that we initalize it with some data from the App global set up by
 // the process startup, but the Daemon tries to be fairly retrieval with its data retrieval and use.
 type Daemon struct {
-	logger     *slog.Logger
-	algoClient *algod.Client
+	logger *slog.Logger
+	chain  algo.Chain
 
 	listenPort int
+	config     DaemonConfig
+	alerts     *alerter
+	// actions are the latest things the daemon did - for 'top' (via /actions)
+	actions *actionLog
+	// autoPoolsBlocked is set once it's been logged that a pool can't be added automatically
+	autoPoolsBlocked bool
 
 	// embed mutex for locking state for members below the mutex
 	sync.RWMutex
 	avgBlockTime time.Duration
 }
 
-func newDaemon(listenPort int) *Daemon {
+func newDaemon(listenPort int, config DaemonConfig) *Daemon {
 	return &Daemon{
 		logger:     App.retiClient.Logger,
-		algoClient: App.algoClient,
+		chain:      App.chain,
 		listenPort: listenPort,
+		config:     config,
+		alerts:     newAlerter(App.retiClient.Logger, config.Alerts),
+		actions:    &actionLog{},
 	}
 }
 
@@ -63,6 +74,14 @@ func (d *Daemon) start(ctx context.Context, wg *sync.WaitGroup, cancel context.C
 		d.KeyWatcher(ctx, cancel)
 	}()
 
+	if App.endpoints != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			App.endpoints.Monitor(ctx, EndpointCheckInterval)
+		}()
+	}
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -73,7 +92,7 @@ func (d *Daemon) start(ctx context.Context, wg *sync.WaitGroup, cancel context.C
 	go func() {
 		defer wg.Done()
 		info := App.retiClient.Info()
-		if info.Config.EntryGatingType == reti.GatingTypeNone {
+		if info.Config.EntryGatingType == reti.GatingTypeNone || d.config.Evictions.Disabled {
 			return
 		}
 		d.StakerEvictor(ctx)
@@ -85,6 +104,7 @@ func (d *Daemon) start(ctx context.Context, wg *sync.WaitGroup, cancel context.C
 		defer wg.Done()
 		http.Handle("/ready", isReady())
 		http.Handle("/metrics", promhttp.Handler())
+		http.Handle("/actions", d.actions.handler())
 
 		host := fmt.Sprintf(":%d", d.listenPort)
 		srv := &http.Server{Addr: host}
@@ -124,12 +144,12 @@ func (d *Daemon) KeyWatcher(ctx context.Context, cancel context.CancelFunc) {
 	}
 	d.checkPools(ctx)
 
-	checkTime := time.NewTicker(1 * time.Minute)
-	blockTimeUpdate := time.NewTicker(30 * time.Minute)
+	checkTime := time.NewTicker(d.config.KeyCheckInterval)
+	blockTimeUpdate := time.NewTicker(d.config.BlockTimeInterval)
 	defer checkTime.Stop()
 	defer blockTimeUpdate.Stop()
 
-	// Check our key validity once a minute
+	// Check our key validity every KeyCheckInterval (once a minute by default)
 	for {
 		select {
 		case <-ctx.Done():
@@ -151,6 +171,12 @@ func (d *Daemon) KeyWatcher(ctx context.Context, cancel context.CancelFunc) {
 
 			d.updatePoolVersions(ctx)
 			d.checkPools(ctx)
+			if d.config.AutoPools.Enabled {
+				if err := d.checkAutoPools(ctx); err != nil {
+					misc.Errorf(d.logger, "error adding pool: %v", err)
+					d.actions.record(actionError, "error adding pool: %v", err)
+				}
+			}
 		case <-blockTimeUpdate.C:
 			_ = d.setAverageBlockTime(ctx)
 		}
@@ -168,7 +194,7 @@ func (d *Daemon) checkPools(ctx context.Context) {
 	// get online status and partkey info for all our accounts (ignoring any that don't have balances yet)
 	var poolAccounts = map[string]onlineInfo{}
 	for poolId, poolAppId := range App.retiClient.Info().LocalPools {
-		acctInfo, err := algo.GetBareAccount(ctx, d.algoClient, crypto.GetApplicationAddress(poolAppId).String())
+		acctInfo, err := algo.GetBareAccount(ctx, d.chain, crypto.GetApplicationAddress(poolAppId).String())
 		if err != nil {
 			d.logger.Warn("account fetch error", "account", crypto.GetApplicationAddress(poolAppId).String(), "error", err)
 			return
@@ -190,11 +216,13 @@ func (d *Daemon) checkPools(ctx context.Context) {
 		})
 		if err != nil {
 			misc.Errorf(d.logger, "error ensuring participation init: %v", err)
+			d.alerts.Alert(ctx, alertParticipation, "error ensuring participation init: %v", err)
+			d.actions.record(actionError, "error ensuring participation init: %v", err)
 			return
 		}
 	}
 	// now get all the current participation keys for our node
-	partKeys, err := algo.GetParticipationKeys(ctx, d.algoClient)
+	partKeys, err := algo.GetParticipationKeys(ctx, d.chain)
 	if err != nil {
 		d.logger.Warn("participation key fetch error", "error", err)
 		return
@@ -203,11 +231,13 @@ func (d *Daemon) checkPools(ctx context.Context) {
 	anyRemoved, err := d.removeExpiredKeys(ctx, partKeys)
 	if err != nil {
 		misc.Errorf(d.logger, "error removing an expired key: %v", err)
+		d.alerts.Alert(ctx, alertParticipation, "error removing an expired key: %v", err)
+		d.actions.record(actionError, "error removing an expired key: %v", err)
 		return
 	}
 	if anyRemoved {
 		// get part key list again because we removed some...
-		partKeys, err = algo.GetParticipationKeys(ctx, d.algoClient)
+		partKeys, err = algo.GetParticipationKeys(ctx, d.chain)
 		if err != nil {
 			d.logger.Warn("participation key fetch error", "error", err)
 			return
@@ -223,6 +253,8 @@ func (d *Daemon) checkPools(ctx context.Context) {
 	err = d.ensureParticipation(ctx, poolAccounts, partKeys)
 	if err != nil {
 		misc.Errorf(d.logger, "error ensuring participation: %v", err)
+		d.alerts.Alert(ctx, alertParticipation, "error ensuring participation: %v", err)
+		d.actions.record(actionError, "error ensuring participation: %v", err)
 		return
 	}
 }
@@ -230,7 +262,7 @@ func (d *Daemon) checkPools(ctx context.Context) {
 func (d *Daemon) updatePoolVersions(ctx context.Context) {
 	managerAddr, _ := types.DecodeAddress(App.retiClient.Info().Config.Manager)
 
-	versString, err := algo.GetVersionString(ctx, d.algoClient)
+	versString, err := algo.GetVersionString(ctx, d.chain)
 	if err != nil {
 		misc.Errorf(d.logger, "unable to fetch version string from algod instance, err:%v", err)
 		return
@@ -238,12 +270,12 @@ func (d *Daemon) updatePoolVersions(ctx context.Context) {
 	versString = fmt.Sprintf("%s : %s", versString, getVersionInfo())
 
 	for poolId, poolAppId := range App.retiClient.Info().LocalPools {
-		algodVer, err := App.retiClient.GetAlgodVer(poolAppId)
-		if err != nil && !errors.Is(err, algo.ErrStateKeyNotFound) {
+		poolState, err := App.retiClient.GetPoolState(poolAppId)
+		if err != nil {
 			misc.Errorf(d.logger, "unable to fetch algod version from staking pool app id:%d, err:%v", poolAppId, err)
 			return
 		}
-		if algodVer != versString {
+		if poolState.AlgodVer != versString {
 			// Update version in staking pool
 			err = App.retiClient.UpdateAlgodVer(poolAppId, versString, managerAddr)
 			if err != nil {
@@ -251,6 +283,7 @@ func (d *Daemon) updatePoolVersions(ctx context.Context) {
 				return
 			}
 			misc.Infof(d.logger, "new algod version detected. Updated to:%s in pool:%d", versString, poolId)
+			d.actions.record(actionVersion, "algod version updated to %s in pool %d", versString, poolId)
 		}
 	}
 }
@@ -262,12 +295,12 @@ func (d *Daemon) AverageBlockTime() time.Duration {
 }
 
 func (d *Daemon) setAverageBlockTime(ctx context.Context) error {
-	// Get the latest block via the algoClient.Status() call, then
+	// Get the latest block via the chain.Status() call, then
 	// fetch the most recent X blocks - fetching the timestamps from each and
 	// determining the approximate current average block time.
 	const numRounds = 20
 
-	blockTime, err := algo.CalcBlockTimes(ctx, d.algoClient, numRounds)
+	blockTime, err := algo.CalcBlockTimes(ctx, d.chain, numRounds)
 	if err != nil {
 		return err
 	}
@@ -309,21 +342,26 @@ func (d *Daemon) refetchConfig() error {
 
 func (d *Daemon) createPartKey(ctx context.Context, account string, firstValid uint64) (*algo.ParticipationKey, error) {
 	// generate keys good for one month based on current avg block time - nothing is returned until key is actually created
-	status, err := d.algoClient.Status().Do(context.Background())
+	status, err := d.chain.Status(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("unable to fetch node status: %w", err)
 	}
 	if firstValid == 0 {
 		firstValid = status.LastRound
 	}
-	keyDurationInSeconds := GeneratedKeyLengthInDays * 60 * 60 * 24
+	keyDurationInSeconds := d.config.Keys.LengthDays * 60 * 60 * 24
 	lastValid := firstValid + uint64(float64(keyDurationInSeconds)/d.AverageBlockTime().Seconds())
-	return algo.GenerateParticipationKey(ctx, d.algoClient, d.logger, account, firstValid, lastValid)
+	key, err := algo.GenerateParticipationKey(ctx, d.chain, d.logger, account, firstValid, lastValid, 0)
+	if err != nil {
+		return nil, err
+	}
+	d.actions.record(actionKey, "participation key generated for account:%s, rounds %d - %d", account, firstValid, lastValid)
+	return key, nil
 }
 
 // 1) Part key found but expired - delete it
 func (d *Daemon) removeExpiredKeys(ctx context.Context, partKeys algo.PartKeysByAddress) (bool, error) {
-	status, err := d.algoClient.Status().Do(ctx)
+	status, err := d.chain.Status(ctx)
 	if err != nil {
 		return false, fmt.Errorf("unable to fetch node status: %w", err)
 	}
@@ -332,10 +370,11 @@ func (d *Daemon) removeExpiredKeys(ctx context.Context, partKeys algo.PartKeysBy
 		for _, key := range keys {
 			if key.Key.VoteLastValid < status.LastRound {
 				misc.Infof(d.logger, "key:%s for account:%s is expired, removing", key.Id, key.Address)
-				err = algo.DeleteParticipationKey(ctx, d.algoClient, d.logger, key.Id)
+				err = algo.DeleteParticipationKey(ctx, d.chain, d.logger, key.Id)
 				if err != nil {
 					return false, fmt.Errorf("error deleting participation key for id:%s, err:%w", key.Id, err)
 				}
+				d.actions.record(actionKey, "expired participation key:%s for account:%s removed", key.Id, key.Address)
 				anyRemoved = true
 			}
 		}
@@ -347,7 +386,7 @@ func (d *Daemon) ensureParticipation(ctx context.Context, poolAccounts map[strin
 	/** conditions to cover for participation keys / accounts
 	1) Pool account is marked as sunsetted - ensure OFFLINE (!) - skip - do NOT online it again
 	2) account has NO local participation key (online or offline) (ie: they could've moved to new node)
-		Create brand new 'GeneratedKeyLengthInDays' length key - will go online as part of subsequent checks once part.
+		Create brand new 'Keys.LengthDays' (GeneratedKeyLengthInDays by default) length key - will go online as part of subsequent checks once part.
 		key reaches first valid.
 	3) account is NOT online but has one or more part keys
 		Go online against newest part key - done
@@ -405,6 +444,7 @@ func (d *Daemon) ensureSunsetPoolsOffline(_ context.Context, poolAccounts map[st
 			if err != nil {
 				return fmt.Errorf("unable to go offline for account:%s, pool app id:%d, err:%w", account, info.poolAppId, err)
 			}
+			d.actions.record(actionOffline, "account:%s marked offline - validator past sunset", account)
 			misc.Infof(d.logger, "account:%s marked offline.  Make SURE TO LEAVE DAEMON RUNNING FOR 320 ronds AND INTO NEXT EPOCH so stakes can be refunded!", account)
 			continue
 		}
@@ -455,6 +495,7 @@ func (d *Daemon) ensureParticipationGoesOnline(_ context.Context, poolAccounts m
 				return fmt.Errorf("unable to go online for key:%s, account:%s [pool app id:%d], err:%w", keyToUse.Id, account, info.poolAppId, err)
 			}
 			misc.Infof(d.logger, "participation key:%s went online for account:%s [pool app id:%d]", keyToUse.Id, account, info.poolAppId)
+			d.actions.record(actionOnline, "participation key:%s went online for account:%s [pool app id:%d]", keyToUse.Id, account, info.poolAppId)
 		}
 	}
 	return nil
@@ -468,7 +509,7 @@ account has 1 part key AND IS ONLINE
 	If expiring soon, create new key w/ firstValid set to existing key's lastValid - 1 day of rounds.  done
 */
 func (d *Daemon) ensureParticipationCheckNeedsRenewed(ctx context.Context, poolAccounts map[string]onlineInfo, partKeys algo.PartKeysByAddress) error {
-	status, err := d.algoClient.Status().Do(ctx)
+	status, err := d.chain.Status(ctx)
 	if err != nil {
 		d.logger.Warn("failure in getting current node status w/in getExpiringKeys", "error", err)
 		return nil
@@ -490,7 +531,7 @@ func (d *Daemon) ensureParticipationCheckNeedsRenewed(ctx context.Context, poolA
 				continue
 			}
 			expValidDistance := time.Duration(activeKey.Key.VoteLastValid-curRound) * avgBlockTime
-			if expValidDistance.Hours() <= 24*DaysPriorToExpToRenew {
+			if expValidDistance.Hours() <= float64(24*d.config.Keys.RenewDaysBefore) {
 				oneDayOfBlocks := (24 * time.Hour) / avgBlockTime
 				misc.Infof(d.logger, "activeKey: %s for %s expiring in %v, creating new key with ~1 day lead-time", activeKey.Id, activeKey.Address, expValidDistance)
 				_, err = d.createPartKey(ctx, account, activeKey.Key.VoteLastValid-uint64(oneDayOfBlocks))
@@ -516,7 +557,7 @@ Handle: account is online and has multiple local part keys
 func (d *Daemon) ensureParticipationCheckNeedsSwitched(ctx context.Context, poolAccounts map[string]onlineInfo, partKeys algo.PartKeysByAddress) error {
 	managerAddr, _ := types.DecodeAddress(App.retiClient.Info().Config.Manager)
 
-	status, err := d.algoClient.Status().Do(ctx)
+	status, err := d.chain.Status(ctx)
 	if err != nil {
 		d.logger.Warn("failure in getting current node status w/in getExpiringKeys", "error", err)
 		return nil
@@ -549,6 +590,7 @@ func (d *Daemon) ensureParticipationCheckNeedsSwitched(ctx context.Context, pool
 			if err != nil {
 				return fmt.Errorf("unable to go offline for account:%s [pool app id:%d], err: %w", account, info.poolAppId, err)
 			}
+			d.actions.record(actionOffline, "account:%s marked offline - its online part. key isn't present locally", account)
 			return nil
 		}
 		// sort the part keys by whichever has highest firstValid
@@ -571,6 +613,7 @@ func (d *Daemon) ensureParticipationCheckNeedsSwitched(ctx context.Context, pool
 			return fmt.Errorf("unable to go online for account:%s [pool app id:%d], err: %w", account, info.poolAppId, err)
 		}
 		misc.Infof(d.logger, "participation key went online for account:%s [pool app id:%d]", account, info.poolAppId)
+		d.actions.record(actionOnline, "switched to participation key:%s for account:%s [pool app id:%d]", keyToCheck.Id, account, info.poolAppId)
 	}
 	return nil
 }
@@ -579,7 +622,7 @@ func (d *Daemon) EpochUpdater(ctx context.Context) {
 	d.logger.Info("EpochUpdater started")
 	defer d.logger.Info("EpochUpdater stopped")
 
-	status, err := d.algoClient.Status().Do(context.Background())
+	status, err := d.chain.Status(context.Background())
 	if err != nil {
 		misc.Errorf(d.logger, "failed to get algod status at start: %v", err)
 		os.Exit(1)
@@ -614,17 +657,18 @@ func (d *Daemon) EpochUpdater(ctx context.Context) {
 					continue
 				}
 				wg.Run(func(val any) error {
-					if !accountHasAtLeast(ctx, App.algoClient, info.Config.Manager, 100_000 /* .1 spendable */) {
+					if !accountHasAtLeast(ctx, App.chain, info.Config.Manager, 100_000 /* .1 spendable */) {
 						return errors.New("manager account should have at least .1 ALGO spendable.  Aborting epochUpdate call")
 					}
 
 					// Retry up to 5 times - waiting 5 seconds between each try
 					err := repeat.Repeat(
 						repeat.Fn(func() error {
-							lastPayout, err := App.retiClient.GetLastPayout(pool.PoolAppId)
+							poolState, err := App.retiClient.GetPoolState(pool.PoolAppId)
 							if err != nil {
 								return repeat.HintTemporary(fmt.Errorf("error fetching payout from pool:%d, app id:%d, err:%w", i+1, pool.PoolAppId, err))
 							}
+							lastPayout := poolState.LastPayout
 							if lastPayout != 0 && lastPayout-(lastPayout%epochRoundLength) == blockWaitResult.atRound-(blockWaitResult.atRound%epochRoundLength) {
 								misc.Infof(d.logger, "already ran epoch update for this epoch on pool:%d, round:%d", i+1, blockWaitResult.atRound)
 								return nil
@@ -634,6 +678,7 @@ func (d *Daemon) EpochUpdater(ctx context.Context) {
 								// Assume epoch update failed because it's just 'slightly' too early?
 								return repeat.HintTemporary(fmt.Errorf("epoch balance update failed for pool app id:%d, err:%w", i+1, err))
 							}
+							d.actions.record(actionPayout, "epoch payout sent for pool:%d, round:%d", i+1, blockWaitResult.atRound)
 							return nil
 						}),
 						repeat.StopOnSuccess(),
@@ -663,6 +708,8 @@ func (d *Daemon) EpochUpdater(ctx context.Context) {
 			errs := wg.Wait()
 			for _, err := range errs {
 				d.logger.Error("error returned from EpochUpdater", "error", err)
+				d.alerts.Alert(ctx, alertEpoch, "epoch update failed: %v", err)
+				d.actions.record(actionError, "epoch update failed: %v", err)
 			}
 		}
 	}
@@ -678,7 +725,7 @@ func (d *Daemon) waitUntilBlock(ctx context.Context, round uint64) chan BlockOrE
 	go func() {
 		defer close(chReturn)
 
-		status, err := d.algoClient.Status().Do(context.Background())
+		status, err := d.chain.Status(context.Background())
 		if err != nil {
 			chReturn <- BlockOrError{err: fmt.Errorf("unable to fetch node status: %w", err)}
 			return
@@ -698,7 +745,7 @@ func (d *Daemon) waitUntilBlock(ctx context.Context, round uint64) chan BlockOrE
 				// Since the call is wait AFTER block X we wait until 'after' round - 1
 				// We'll wait up to 10 blocks at a time (since StatusAfterBlock has fixed 1m timeout)
 				curRound = min(round-1, curRound+10)
-				status, err = d.algoClient.StatusAfterBlock(curRound).Do(context.Background())
+				status, err = d.chain.StatusAfterBlock(context.Background(), curRound)
 				if err != nil {
 					chReturn <- BlockOrError{err: fmt.Errorf("unable to fetch node status: %w", err)}
 					return
@@ -716,14 +763,14 @@ func (d *Daemon) getFirstEligibleEpochRound(curRound uint64, epochRoundLength ui
 		curRoundEpochStart = curRound - (curRound % epochRoundLength)
 		earliestEpochToUse = curRoundEpochStart
 	)
-	for i, pool := range info.Pools {
-		if _, found := info.LocalPools[uint64(i+1)]; !found {
+	// a pool whose state can't be fetched just doesn't move the epoch earlier - the others still can
+	for poolID, poolAppId := range info.LocalPools {
+		poolState, err := App.retiClient.GetPoolState(poolAppId)
+		if err != nil {
+			misc.Warnf(d.logger, "unable to get state of pool:%d [app id:%d] for its last payout, err:%v", poolID, poolAppId, err)
 			continue
 		}
-		lastPayout, err := App.retiClient.GetLastPayout(pool.PoolAppId)
-		if err == nil {
-			earliestEpochToUse = min(earliestEpochToUse, nextEpoch(lastPayout, epochRoundLength))
-		}
+		earliestEpochToUse = min(earliestEpochToUse, nextEpoch(poolState.LastPayout, epochRoundLength))
 	}
 	return earliestEpochToUse
 }
@@ -736,10 +783,12 @@ func (d *Daemon) StakerEvictor(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
-		case <-time.After(5 * time.Minute):
+		case <-time.After(d.config.Evictions.Interval):
 			err := d.checkForEvictions(ctx)
 			if err != nil {
 				misc.Errorf(d.logger, "error in eviction check: checking for evictions, err:%v", err)
+				d.alerts.Alert(ctx, alertEviction, "error in eviction check: %v", err)
+				d.actions.record(actionError, "error in eviction check: %v", err)
 			}
 		}
 	}
@@ -747,8 +796,8 @@ func (d *Daemon) StakerEvictor(ctx context.Context) {
 
 // accountHasAtLeast checks if an account has at least a certain amount of microAlgos (spendable)
 // Errors are just treated as failures
-func accountHasAtLeast(ctx context.Context, algoClient *algod.Client, accountAddr string, microAlgos uint64) bool {
-	acctInfo, err := algo.GetBareAccount(ctx, algoClient, accountAddr)
+func accountHasAtLeast(ctx context.Context, chain algo.Chain, accountAddr string, microAlgos uint64) bool {
+	acctInfo, err := algo.GetBareAccount(ctx, chain, accountAddr)
 	if err != nil {
 		return false
 	}
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+
+	"github.com/algorandfoundation/reti/internal/lib/algo"
+	"github.com/algorandfoundation/reti/internal/lib/algo/fakechain"
+	"github.com/algorandfoundation/reti/internal/lib/reti"
+	"github.com/algorandfoundation/reti/internal/lib/reti/fakereti"
+)
+
+const testStartRound = 10_000
+
+// testValidator is a validator on a fake chain - pools are added before start sets up App and the daemon for node 1
+type testValidator struct {
+	chain    *fakechain.Chain
+	registry *fakereti.Registry
+	owner    crypto.Account
+	manager  crypto.Account
+}
+
+func newTestValidator(t *testing.T, config reti.ABIValidatorConfig) *testValidator {
+	t.Helper()
+	tv := &testValidator{
+		chain:   fakechain.New(testStartRound),
+		owner:   crypto.GenerateAccount(),
+		manager: crypto.GenerateAccount(),
+	}
+	tv.chain.SetAccount(tv.manager.Address.String(), fakechain.Account{Amount: 100_000_000, MinBalance: 100_000})
+	config.Owner, config.Manager, config.ValidatorCommissionAddress = tv.owner.Address, tv.manager.Address, tv.owner.Address
+	config.EpochRoundLength, config.PercentToValidator, config.PoolsPerNode = 100, 50_000, 3
+	config.MinEntryStake, config.MaxAlgoPerPool = 1_000_000, 70_000_000_000_000
+	var err error
+	tv.registry, err = fakereti.New(tv.chain, config)
+	if err != nil {
+		t.Fatalf("fakereti.New: %v", err)
+	}
+	return tv
+}
+
+func (tv *testValidator) addPool(t *testing.T, node int, stakers ...reti.StakedInfo) uint64 {
+	t.Helper()
+	if len(stakers) == 0 {
+		stakers = []reti.StakedInfo{testStaker()}
+	}
+	poolAppID, err := tv.registry.AddPool(node, 1_000_000, stakers...)
+	if err != nil {
+		t.Fatalf("AddPool: %v", err)
+	}
+	return poolAppID
+}
+
+// start sets App up for node 1 of the validator (restoring it when the test ends) and returns its daemon
+func (tv *testValidator) start(t *testing.T) *Daemon {
+	t.Helper()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	signer, err := fakereti.NewSigner(log, t.TempDir(), tv.manager)
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+	retiClient, err := reti.New(fakereti.RegistryAppID, log, tv.chain, signer, fakereti.ValidatorID, 1)
+	if err != nil {
+		t.Fatalf("reti.New: %v", err)
+	}
+	if err := retiClient.LoadState(context.Background()); err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	prevApp := App
+	t.Cleanup(func() { App = prevApp })
+	App = &RetiApp{
+		logger:     log,
+		signer:     signer,
+		chain:      tv.chain,
+		retiClient: retiClient,
+		config:     defaultNodemgrConfig(),
+	}
+	d := newDaemon(0, App.config.Daemon)
+	d.avgBlockTime = fakechain.DefaultBlockDur
+	return d
+}
+
+// addKey adds a local participation key for the pool, valid for the specified rounds
+func (tv *testValidator) addKey(t *testing.T, poolAppID uint64, firstValid, lastValid uint64) algo.ParticipationKey {
+	t.Helper()
+	address := crypto.GetApplicationAddress(poolAppID).String()
+	if err := tv.chain.GenerateParticipationKey(context.Background(), address, firstValid, lastValid, 0); err != nil {
+		t.Fatalf("GenerateParticipationKey: %v", err)
+	}
+	keys, err := tv.chain.ParticipationKeys(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx := slices.IndexFunc(keys, func(key algo.ParticipationKey) bool {
+		return key.Address == address && key.Key.VoteFirstValid == firstValid
+	})
+	return keys[idx]
+}
+
+func (tv *testValidator) goOnline(poolAppID uint64, key algo.ParticipationKey) {
+	tv.chain.GoOnline(crypto.GetApplicationAddress(poolAppID).String(), key.Key.VoteParticipationKey,
+		key.Key.SelectionParticipationKey, key.Key.StateProofKey, key.Key.VoteFirstValid, key.Key.VoteLastValid,
+		key.Key.VoteKeyDilution)
+}
+
+func (tv *testValidator) pool(poolAppID uint64) fakechain.Account {
+	return tv.chain.GetAccount(crypto.GetApplicationAddress(poolAppID).String())
+}
+
+func (tv *testValidator) hasKey(t *testing.T, keyID string) bool {
+	t.Helper()
+	keys, err := tv.chain.ParticipationKeys(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return slices.ContainsFunc(keys, func(key algo.ParticipationKey) bool { return key.Id == keyID })
+}
+
+func testStaker() reti.StakedInfo {
+	return reti.StakedInfo{Account: crypto.GenerateAccount().Address, Balance: 10_000_000, EntryRound: 1}
+}
+
+func TestCheckPoolsGoesOnline(t *testing.T) {
+	tv := newTestValidator(t, reti.ABIValidatorConfig{})
+	poolAppID := tv.addPool(t, 1)
+	otherNodePool := tv.addPool(t, 2)
+	older := tv.addKey(t, poolAppID, testStartRound-1000, testStartRound+100_000)
+	newer := tv.addKey(t, poolAppID, testStartRound-10, testStartRound+100_000)
+	tv.addKey(t, otherNodePool, testStartRound-10, testStartRound+100_000)
+	d := tv.start(t)
+
+	d.checkPools(context.Background())
+
+	pool := tv.pool(poolAppID)
+	if !pool.Online || !bytes.Equal(pool.Participation.SelectionParticipationKey, newer.Key.SelectionParticipationKey) {
+		t.Fatalf("expected pool online against the newest key %s, got online:%v", newer.Id, pool.Online)
+	}
+	if !tv.hasKey(t, older.Id) {
+		t.Fatal("the older key shouldn't have been removed")
+	}
+	if tv.pool(otherNodePool).Online {
+		t.Fatal("a pool of another node shouldn't have gone online")
+	}
+}
+
+func TestCheckPoolsSwitchesToNewerKey(t *testing.T) {
+	tv := newTestValidator(t, reti.ABIValidatorConfig{})
+	poolAppID := tv.addPool(t, 1)
+	active := tv.addKey(t, poolAppID, testStartRound-1000, testStartRound+100_000)
+	tv.goOnline(poolAppID, active)
+	future := tv.addKey(t, poolAppID, testStartRound+50, testStartRound+200_000)
+	d := tv.start(t)
+
+	// the newer key isn't in range yet
+	d.checkPools(context.Background())
+	if pool := tv.pool(poolAppID); !bytes.Equal(pool.Participation.SelectionParticipationKey, active.Key.SelectionParticipationKey) {
+		t.Fatal("switched to a key that isn't valid yet")
+	}
+
+	tv.chain.AdvanceRounds(100)
+	d.checkPools(context.Background())
+	if pool := tv.pool(poolAppID); !pool.Online || !bytes.Equal(pool.Participation.SelectionParticipationKey, future.Key.SelectionParticipationKey) {
+		t.Fatalf("expected pool online against the newer key %s, got online:%v", future.Id, pool.Online)
+	}
+}
+
+func TestCheckPoolsRemovesExpiredKeys(t *testing.T) {
+	tv := newTestValidator(t, reti.ABIValidatorConfig{})
+	poolAppID := tv.addPool(t, 1)
+	expired := tv.addKey(t, poolAppID, testStartRound-5000, testStartRound-1)
+	active := tv.addKey(t, poolAppID, testStartRound-1000, testStartRound+100_000)
+	tv.goOnline(poolAppID, active)
+	d := tv.start(t)
+
+	d.checkPools(context.Background())
+
+	if tv.hasKey(t, expired.Id) {
+		t.Fatal("expired key wasn't removed")
+	}
+	if !tv.hasKey(t, active.Id) {
+		t.Fatal("active key was removed")
+	}
+	if pool := tv.pool(poolAppID); !pool.Online || !bytes.Equal(pool.Participation.SelectionParticipationKey, active.Key.SelectionParticipationKey) {
+		t.Fatal("pool should have stayed online against its active key")
+	}
+}
+
+func TestCheckPoolsOfflineWithoutLocalKey(t *testing.T) {
+	tv := newTestValidator(t, reti.ABIValidatorConfig{})
+	poolAppID := tv.addPool(t, 1)
+	// online against a key from elsewhere, with only an unrelated key present locally
+	tv.chain.GoOnline(crypto.GetApplicationAddress(poolAppID).String(), bytes.Repeat([]byte{1}, 32),
+		bytes.Repeat([]byte{2}, 32), bytes.Repeat([]byte{3}, 64), testStartRound-1000, testStartRound+100_000, 100)
+	tv.addKey(t, poolAppID, testStartRound+500, testStartRound+100_000)
+	d := tv.start(t)
+
+	d.checkPools(context.Background())
+
+	if tv.pool(poolAppID).Online {
+		t.Fatal("pool online against a key that isn't present locally should have gone offline")
+	}
+}
+
+func TestCheckPoolsSunsetGoesOffline(t *testing.T) {
+	tv := newTestValidator(t, reti.ABIValidatorConfig{SunsettingOn: uint64(time.Now().Add(-time.Hour).Unix())})
+	onlinePool := tv.addPool(t, 1)
+	offlinePool := tv.addPool(t, 1)
+	tv.goOnline(onlinePool, tv.addKey(t, onlinePool, testStartRound-1000, testStartRound+100_000))
+	tv.addKey(t, offlinePool, testStartRound-1000, testStartRound+100_000)
+	d := tv.start(t)
+
+	d.checkPools(context.Background())
+
+	if tv.pool(onlinePool).Online {
+		t.Fatal("pool of a sunset validator should have gone offline")
+	}
+	if tv.pool(offlinePool).Online {
+		t.Fatal("pool of a sunset validator mustn't go online")
+	}
+}
+
+// runEpochUpdater runs the daemon's EpochUpdater until the registry has paid out at least numPayouts times
+func runEpochUpdater(t *testing.T, tv *testValidator, d *Daemon, numPayouts int) []uint64 {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		d.EpochUpdater(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if payouts := tv.registry.Payouts(); len(payouts) >= numPayouts {
+			return payouts[:numPayouts]
+		}
+	}
+	t.Fatalf("timed out waiting for %d payouts, got %v", numPayouts, tv.registry.Payouts())
+	return nil
+}
+
+func TestEpochUpdaterPaysLocalPools(t *testing.T) {
+	tv := newTestValidator(t, reti.ABIValidatorConfig{})
+	pool1 := tv.addPool(t, 1)
+	pool2 := tv.addPool(t, 2)
+	pool3 := tv.addPool(t, 1)
+	d := tv.start(t)
+
+	// neither pool has been paid, so both are paid right away - in the current epoch
+	payouts := runEpochUpdater(t, tv, d, 2)
+	slices.Sort(payouts)
+	if !slices.Equal(payouts, []uint64{pool1, pool3}) {
+		t.Fatalf("expected payouts of the local pools %d and %d, got %v", pool1, pool3, payouts)
+	}
+	if slices.Contains(tv.registry.Payouts(), pool2) {
+		t.Fatalf("pool %d of another node was paid", pool2)
+	}
+}
+
+func TestEpochUpdaterSkipsPaidPools(t *testing.T) {
+	tv := newTestValidator(t, reti.ABIValidatorConfig{})
+	paidPool := tv.addPool(t, 1)
+	unpaidPool := tv.addPool(t, 1)
+	tv.registry.SetLastPayout(paidPool, testStartRound)
+	d := tv.start(t)
+
+	// the pool already paid in this epoch is only paid once the next epoch starts - along with the other pool again
+	payouts := runEpochUpdater(t, tv, d, 3)
+	if payouts[0] != unpaidPool || !slices.Contains(payouts[1:], paidPool) {
+		t.Fatalf("expected a payout of pool %d, then of pool %d in the next epoch, got %v", unpaidPool, paidPool, payouts)
+	}
+}
+
+func TestGetFirstEligibleEpochRound(t *testing.T) {
+	tv := newTestValidator(t, reti.ABIValidatorConfig{})
+	behindPool := tv.addPool(t, 1)
+	currentPool := tv.addPool(t, 1)
+	deletedPool := tv.addPool(t, 1)
+	tv.registry.SetLastPayout(behindPool, testStartRound-150)
+	tv.registry.SetLastPayout(currentPool, testStartRound)
+	d := tv.start(t)
+	// a pool whose state can't be fetched is skipped rather than treated as never paid
+	tv.chain.DeleteApplication(deletedPool)
+
+	if round := d.getFirstEligibleEpochRound(testStartRound+50, 100); round != testStartRound-100 {
+		t.Fatalf("expected the epoch after the oldest payout, round %d, got %d", testStartRound-100, round)
+	}
+}
+
+func TestCheckForEvictions(t *testing.T) {
+	const gatingAsset = 111
+	tv := newTestValidator(t, reti.ABIValidatorConfig{
+		EntryGatingType:       reti.GatingTypeAssetId,
+		EntryGatingAssets:     [4]uint64{gatingAsset},
+		GatingAssetMinBalance: 5,
+	})
+	holder, tooFew, nonHolder := testStaker(), testStaker(), testStaker()
+	tv.chain.SetAccount(holder.Account.String(), fakechain.Account{Amount: 1_000_000, Assets: []models.AssetHolding{{AssetId: gatingAsset, Amount: 10}}})
+	tv.chain.SetAccount(tooFew.Account.String(), fakechain.Account{Amount: 1_000_000, Assets: []models.AssetHolding{{AssetId: gatingAsset, Amount: 1}}})
+	pool1 := tv.addPool(t, 1, holder, nonHolder)
+	pool2 := tv.addPool(t, 2, tooFew, nonHolder)
+	d := tv.start(t)
+
+	if err := d.checkForEvictions(context.Background()); err != nil {
+		t.Fatalf("checkForEvictions: %v", err)
+	}
+
+	removed := tv.registry.Removed()
+	slices.SortFunc(removed, func(a, b fakereti.RemovedStake) int { return int(a.PoolAppID) - int(b.PoolAppID) })
+	expected := []fakereti.RemovedStake{
+		{PoolAppID: pool1, Staker: nonHolder.Account, Amount: nonHolder.Balance},
+		{PoolAppID: pool2, Staker: tooFew.Account, Amount: tooFew.Balance},
+		{PoolAppID: pool2, Staker: nonHolder.Account, Amount: nonHolder.Balance},
+	}
+	if len(removed) != len(expected) {
+		t.Fatalf("expected %d stakes removed, got %+v", len(expected), removed)
+	}
+	for _, stake := range expected {
+		if !slices.Contains(removed, stake) {
+			t.Errorf("expected stake %+v to be removed, got %+v", stake, removed)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mailgun/holster/v4/syncutil"
+
+	"github.com/algorandfoundation/reti/internal/lib/misc"
+	"github.com/algorandfoundation/reti/internal/lib/reti"
+)
+
+// Sunset status of a validator (see ValidatorListEntry.SunsetStatus)
+const (
+	sunsetNone      = "none"
+	sunsetScheduled = "sunsetting"
+	sunsetSunsetted = "sunset"
+)
+
+var (
+	sunsetStatuses = []string{sunsetNone, sunsetScheduled, sunsetSunsetted}
+	validatorSorts = []string{"id", "stake", "stakers", "apr"}
+)
+
+// ValidatorListEntry is a validator of the directory (validator list) - amounts in microAlgo
+type ValidatorListEntry struct {
+	ID       uint64 `json:"id" yaml:"id"`
+	Owner    string `json:"owner" yaml:"owner"`
+	NFD      string `json:"nfd,omitempty" yaml:"nfd,omitempty"`
+	NFDAppID uint64 `json:"nfdAppId" yaml:"nfdAppId"`
+	// Commission is the percentage of rewards going to the validator
+	Commission      float64 `json:"commission" yaml:"commission"`
+	EntryGatingType uint8   `json:"entryGatingType" yaml:"entryGatingType"`
+	RewardTokenID   uint64  `json:"rewardTokenId" yaml:"rewardTokenId"`
+	SunsettingOn    uint64  `json:"sunsettingOn" yaml:"sunsettingOn"`
+	SunsettingTo    uint64  `json:"sunsettingTo" yaml:"sunsettingTo"`
+	Pools           int     `json:"pools" yaml:"pools"`
+	Stakers         uint64  `json:"stakers" yaml:"stakers"`
+	Staked          uint64  `json:"staked" yaml:"staked"`
+	// MaxStake is the most the validator's current pools can hold, FreeCapacity what they can still take (0 once
+	// sunset) - pools full of stakers have no capacity left, whatever their stake
+	MaxStake     uint64 `json:"maxStake" yaml:"maxStake"`
+	FreeCapacity uint64 `json:"freeCapacity" yaml:"freeCapacity"`
+	// APR is the stake weighted average of the pools' APR (%)
+	APR float64 `json:"apr" yaml:"apr"`
+}
+
+// SunsetStatus returns whether the validator isn't sunsetting (sunsetNone), has a sunset date set (sunsetScheduled),
+// or is past it (sunsetSunsetted)
+func (v ValidatorListEntry) SunsetStatus() string {
+	switch {
+	case v.SunsettingOn == 0:
+		return sunsetNone
+	case time.Now().Before(time.Unix(int64(v.SunsettingOn), 0)):
+		return sunsetScheduled
+	default:
+		return sunsetSunsetted
+	}
+}
+
+// validatorDirectory is every validator of the registry, as cached (see getValidatorDirectory)
+type validatorDirectory struct {
+	RetiAppID  uint64               `json:"retiAppId"`
+	Fetched    time.Time            `json:"fetched"`
+	Validators []ValidatorListEntry `json:"validators"`
+	// Skipped are the ids of validators which couldn't be loaded - a directory with any isn't cached
+	Skipped []uint64 `json:"skipped,omitempty"`
+}
+
+// maxStakePerPool returns the max stake of each pool of a validator - as the contract determines it: the validator's
+// max per pool (or the protocol's if unset), but never more than the validator's share of the max stake per validator
+func maxStakePerPool(config reti.ValidatorConfig, numPools int, constraints *reti.ProtocolConstraints) uint64 {
+	maxPerPool := config.MaxAlgoPerPool
+	if maxPerPool == 0 {
+		maxPerPool = constraints.MaxAlgoPerPool
+	}
+	if numPools > 0 {
+		maxPerPool = min(maxPerPool, constraints.MaxAlgoPerValidator/uint64(numPools))
+	}
+	return maxPerPool
+}
+
+// getValidatorDirectory returns every validator - from the cache file if fetched within maxAge, otherwise from the
+// chain (updating the cache file).  No caching if cacheFile is empty.
+func getValidatorDirectory(ctx context.Context, cacheFile string, maxAge time.Duration) (validatorDirectory, error) {
+	if cacheFile != "" && maxAge > 0 {
+		var cached validatorDirectory
+		if data, err := os.ReadFile(cacheFile); err == nil && json.Unmarshal(data, &cached) == nil &&
+			cached.RetiAppID == App.retiClient.RetiAppId && time.Since(cached.Fetched) < maxAge {
+			misc.Debugf(App.logger, "using validators cached at %s from %s", cached.Fetched.Format(time.RFC3339), cacheFile)
+			return cached, nil
+		}
+	}
+	directory, err := fetchValidatorDirectory(ctx)
+	if err != nil {
+		return validatorDirectory{}, err
+	}
+	if cacheFile != "" && len(directory.Skipped) == 0 {
+		data, _ := json.Marshal(directory)
+		if err := os.MkdirAll(filepath.Dir(cacheFile), 0o700); err == nil {
+			err = os.WriteFile(cacheFile, data, 0o600)
+		}
+		if err != nil {
+			misc.Warnf(App.logger, "unable to cache validators in %s, err:%v", cacheFile, err)
+		}
+	}
+	return directory, nil
+}
+
+// fetchValidatorDirectory loads the config and state of every validator (and its pools) from the chain, in parallel.
+// Validators which can't be loaded are skipped (with a warning) rather than failing the whole directory.
+func fetchValidatorDirectory(ctx context.Context) (validatorDirectory, error) {
+	numValidators, err := App.retiClient.GetNumValidators()
+	if err != nil {
+		return validatorDirectory{}, fmt.Errorf("unable to GetNumValidators: %w", err)
+	}
+	constraints, err := App.retiClient.GetProtocolConstraints()
+	if err != nil {
+		return validatorDirectory{}, fmt.Errorf("unable to get protocol constraints: %w", err)
+	}
+	var (
+		fanOut = syncutil.NewFanOut(20)
+		mutex  sync.Mutex
+	)
+	directory := validatorDirectory{
+		RetiAppID:  App.retiClient.RetiAppId,
+		Fetched:    time.Now().UTC().Truncate(time.Second),
+		Validators: []ValidatorListEntry{},
+	}
+	for id := uint64(1); id <= numValidators; id++ {
+		fanOut.Run(func(val any) error {
+			entry, err := fetchValidatorListEntry(ctx, val.(uint64), constraints)
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				misc.Warnf(App.logger, "skipping validator:%d, %v", val.(uint64), err)
+				directory.Skipped = append(directory.Skipped, val.(uint64))
+				return nil
+			}
+			directory.Validators = append(directory.Validators, entry)
+			return nil
+		}, id)
+	}
+	fanOut.Wait()
+	slices.SortFunc(directory.Validators, func(a, b ValidatorListEntry) int { return cmp.Compare(a.ID, b.ID) })
+	slices.Sort(directory.Skipped)
+	misc.Infof(App.logger, "fetched %d validators, skipped %d", len(directory.Validators), len(directory.Skipped))
+	return directory, nil
+}
+
+func fetchValidatorListEntry(ctx context.Context, id uint64, constraints *reti.ProtocolConstraints) (ValidatorListEntry, error) {
+	info, err := App.retiClient.GetValidator(id)
+	if err != nil {
+		return ValidatorListEntry{}, fmt.Errorf("unable to get validator:%d, err:%w", id, err)
+	}
+	entry := ValidatorListEntry{
+		ID:              id,
+		Owner:           info.Config.Owner,
+		NFDAppID:        info.Config.NFDForInfo,
+		Commission:      float64(info.Config.PercentToValidator) / 10000,
+		EntryGatingType: info.Config.EntryGatingType,
+		RewardTokenID:   info.Config.RewardTokenId,
+		SunsettingOn:    info.Config.SunsettingOn,
+		SunsettingTo:    info.Config.SunsettingTo,
+		Pools:           len(info.Pools),
+		Stakers:         info.State.TotalStakers,
+		Staked:          info.State.TotalAlgoStaked,
+	}
+	if info.Config.NFDForInfo != 0 {
+		if nfdInfo, err := App.nfdOnChain.GetNFD(ctx, info.Config.NFDForInfo, false); err == nil {
+			entry.NFD = nfdInfo.Internal["name"]
+		}
+	}
+
+	maxPerPool := maxStakePerPool(info.Config, len(info.Pools), constraints)
+	var (
+		aprStake  = new(big.Float)
+		poolStake uint64
+	)
+	for _, pool := range info.Pools {
+		entry.MaxStake += maxPerPool
+		if uint64(pool.TotalStakers) < constraints.MaxStakersPerPool {
+			entry.FreeCapacity += maxPerPool - min(pool.TotalAlgoStaked, maxPerPool)
+		}
+		poolState, err := App.retiClient.GetPoolState(pool.PoolAppId)
+		if err != nil {
+			return ValidatorListEntry{}, fmt.Errorf("unable to fetch state of pool app id:%d, err:%w", pool.PoolAppId, err)
+		}
+		floatApr, _, _ := new(big.Float).Parse(poolState.AvgApr.String(), 10)
+		floatApr.Quo(floatApr, big.NewFloat(100.0))
+		aprStake.Add(aprStake, floatApr.Mul(floatApr, new(big.Float).SetUint64(pool.TotalAlgoStaked)))
+		poolStake += pool.TotalAlgoStaked
+	}
+	entry.MaxStake = min(entry.MaxStake, constraints.MaxAlgoPerValidator)
+	entry.FreeCapacity = min(entry.FreeCapacity, constraints.MaxAlgoPerValidator-min(entry.Staked, constraints.MaxAlgoPerValidator))
+	if entry.SunsetStatus() == sunsetSunsetted {
+		entry.FreeCapacity = 0
+	}
+	if poolStake > 0 {
+		entry.APR, _ = aprStake.Quo(aprStake, new(big.Float).SetUint64(poolStake)).Float64()
+	}
+	return entry, nil
+}
+
+// validatorCacheFile returns the file validators of the registry on the network are cached in - empty if there's no
+// user cache directory
+func validatorCacheFile(network string) string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		misc.Debugf(App.logger, "no user cache directory, validators won't be cached, err:%v", err)
+		return ""
+	}
+	return filepath.Join(cacheDir, "reti", fmt.Sprintf("validators-%s-%d.json", network, App.retiClient.RetiAppId))
+}
@@ -1 +1 @@
-@docker run -d --env-file .env.docker --network algokit_sandbox_regular_default reti d
+@docker run -d --env-file .env.docker -e RETI_ENV_MNEMONICS=true --network algokit_sandbox_regular_default reti d
@@ -45,6 +45,8 @@ func (d *Daemon) checkForEvictions(ctx context.Context) error {
 				return fmt.Errorf("error removing stake for pool %d, appid:%d: %v", pool.PoolId, pool.PoolAppId, err)
 			}
 			misc.Infof(d.logger, "[EVICTION] Staker:%s removed from pool %d because no longer meeting gating criteria", staker, pool.PoolId)
+			d.alerts.Alert(ctx, alertEviction, "staker:%s removed from pool %d because no longer meeting gating criteria", staker, pool.PoolId)
+			d.actions.record(actionEviction, "staker:%s removed from pool %d because no longer meeting gating criteria", staker, pool.PoolId)
 		}
 	}
 	return nil
@@ -118,7 +120,7 @@ func (d *Daemon) isAccountEligible(ctx context.Context, account string) (bool, e
 	gatingMinBalance := info.Config.GatingAssetMinBalance
 
 	// get all assets held by the staking account first
-	accountInfo, err := d.algoClient.AccountInformation(account).Do(ctx)
+	accountInfo, err := d.chain.AccountInformation(ctx, account, false)
 	if err != nil {
 		return false, fmt.Errorf("error getting account info for account %s: %v", account, err)
 	}
@@ -179,7 +181,7 @@ func (d *Daemon) isAccountEligible(ctx context.Context, account string) (bool, e
 func (d *Daemon) collectCreatedAssets(ctx context.Context, addresses []string) ([]uint64, error) {
 	assetIdMap := make(map[uint64]bool)
 	for _, address := range addresses {
-		creatorAccountInfo, err := d.algoClient.AccountInformation(address).Do(ctx)
+		creatorAccountInfo, err := d.chain.AccountInformation(ctx, address, false)
 		if err != nil {
 			return nil, fmt.Errorf("error getting account info for creator address %s: %v", address, err)
 		}
@@ -1,14 +1,19 @@
 package algo
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
+	"math"
 	"math/big"
 	"net/http"
 	"net/url"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,7 +35,44 @@ func FormattedAlgoAmount(microAlgos uint64) string {
 	return formattedAmount
 }
 
+// ParseAlgoAmount parses an ALGO amount (up to 6 decimal places, ie: 12.5) into microAlgo
+func ParseAlgoAmount(amount string) (uint64, error) {
+	whole, fraction, _ := strings.Cut(strings.TrimSpace(amount), ".")
+	if whole == "" {
+		whole = "0"
+	}
+	if len(fraction) > 6 {
+		return 0, fmt.Errorf("invalid ALGO amount:%s - at most 6 decimal places", amount)
+	}
+	fraction += strings.Repeat("0", 6-len(fraction))
+	algos, err := strconv.ParseUint(whole, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ALGO amount:%s", amount)
+	}
+	microAlgos, err := strconv.ParseUint(fraction, 10, 64)
+	if err != nil || algos > (math.MaxUint64-microAlgos)/1e6 {
+		return 0, fmt.Errorf("invalid ALGO amount:%s", amount)
+	}
+	return algos*1e6 + microAlgos, nil
+}
+
+// GetAlgoClient returns an algod client for the config, verifying it can be reached
 func GetAlgoClient(log *slog.Logger, config NetworkConfig) (*algod.Client, error) {
+	client, err := MakeAlgoClient(log, config)
+	if err != nil {
+		return nil, err
+	}
+	// Immediately hit server to verify connectivity
+	_, err = client.SuggestedParams().Do(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get suggested params from algod client, error:%w", err)
+	}
+	return client, nil
+}
+
+// MakeAlgoClient returns an algod client for the config - the url and token are read from the data directory if
+// set.  Connectivity isn't verified - see GetAlgoClient.
+func MakeAlgoClient(log *slog.Logger, config NetworkConfig) (*algod.Client, error) {
 	var (
 		apiURL     string
 		apiToken   string
@@ -74,16 +116,50 @@ func GetAlgoClient(log *slog.Logger, config NetworkConfig) (*algod.Client, error
 	customTransport.MaxIdleConns = 100
 	customTransport.MaxConnsPerHost = 100
 	customTransport.MaxIdleConnsPerHost = 100
-	client, err := algod.MakeClientWithTransport(serverAddr.String(), apiToken, apiHeaders, customTransport)
+	// Throttling, retries and circuit breaking - shared by everything using the client (including nfd lookups)
+	transport := misc.NewResilientTransport(log, serverAddr.Host, customTransport, misc.DefaultTransportOptions())
+	client, err := algod.MakeClientWithTransport(serverAddr.String(), apiToken, apiHeaders, &alreadySentTransport{base: transport})
 	if err != nil {
 		return nil, fmt.Errorf(`failed to make algod client (url:%s), error:%w`, serverAddr.String(), err)
 	}
-	// Immediately hit server to verify connectivity
-	_, err = client.SuggestedParams().Do(context.Background())
+	return client, nil
+}
+
+// alreadySentTransport has algod rejecting a transaction group as already in the ledger (or already pending) count
+// as a successful send.  A group is only resent like that when the response to the first send was lost - ie: when
+// failing over to another endpoint - and counting it as sent lets the composer carry on waiting for it, and decoding
+// its results, rather than reporting a group which went through as failed.
+type alreadySentTransport struct {
+	base http.RoundTripper
+}
+
+func (t *alreadySentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || req.Method != http.MethodPost || !strings.HasSuffix(req.URL.Path, "/v2/transactions") || resp.StatusCode != http.StatusBadRequest {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get suggested params from algod client, error:%w", err)
+		return nil, err
 	}
-	return client, nil
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	var response struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &response) != nil {
+		return resp, nil
+	}
+	// ie: "TransactionPool.Remember: transaction already in ledger: <txid>"
+	_, txID, found := strings.Cut(response.Message, "transaction already in ledger: ")
+	if !found || len(strings.Fields(txID)) == 0 {
+		return resp, nil
+	}
+	body, _ = json.Marshal(models.PostTransactionsResponse{Txid: strings.Fields(txID)[0]})
+	resp.StatusCode, resp.Status = http.StatusOK, "200 OK"
+	resp.Header.Set("Content-Type", "application/json")
+	resp.Body, resp.ContentLength = io.NopCloser(bytes.NewReader(body)), int64(len(body))
+	return resp, nil
 }
 
 func GetUint64FromGlobalState(globalState []models.TealKeyValue, keyName string) (uint64, error) {
@@ -119,26 +195,26 @@ func GetStringFromGlobalState(globalState []models.TealKeyValue, keyName string)
 }
 
 // GetBareAccount just returns account information without asset data
-func GetBareAccount(ctx context.Context, algoClient *algod.Client, account string) (models.Account, error) {
-	return algoClient.AccountInformation(account).Exclude("all").Do(ctx)
+func GetBareAccount(ctx context.Context, chain Chain, account string) (models.Account, error) {
+	return chain.AccountInformation(ctx, account, true)
 }
 
-func GetVersionString(ctx context.Context, algoClient *algod.Client) (string, error) {
-	vers, err := algoClient.Versions().Do(ctx)
+func GetVersionString(ctx context.Context, chain Chain) (string, error) {
+	vers, err := chain.Versions(ctx)
 	if err != nil {
 		return "", fmt.Errorf("error fetching /versions from algod: %w", err)
 	}
 	return fmt.Sprintf("%d.%d.%d %s [%s]", vers.Build.Major, vers.Build.Minor, vers.Build.BuildNumber, vers.Build.Branch, vers.Build.CommitHash), nil
 }
 
-func CalcBlockTimes(ctx context.Context, algoClient *algod.Client, numRounds uint64) (time.Duration, error) {
-	status, err := algoClient.Status().Do(ctx)
+func CalcBlockTimes(ctx context.Context, chain Chain, numRounds uint64) (time.Duration, error) {
+	status, err := chain.Status(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("unable to fetch node status: %w", err)
 	}
 	var blockTimes []time.Time
 	for round := status.LastRound - numRounds; round < status.LastRound; round++ {
-		block, err := algoClient.Block(round).Do(ctx)
+		block, err := chain.Block(ctx, round)
 		if err != nil {
 			return 0, fmt.Errorf("unable to fetch block in getAverageBlockTime, err:%w", err)
 		}
@@ -0,0 +1,42 @@
+package algo_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/algorandfoundation/reti/internal/lib/algo"
+)
+
+func TestResendAlreadyInLedger(t *testing.T) {
+	var message string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/transactions" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"message":"`+message+`"}`)
+	}))
+	defer server.Close()
+	client, err := algo.MakeAlgoClient(slog.New(slog.NewTextHandler(io.Discard, nil)), algo.NetworkConfig{NodeURL: server.URL, NodeToken: "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := algo.NewAlgodChain(client)
+
+	// a resend of a group which made it through counts as sent
+	message = "TransactionPool.Remember: transaction already in ledger: TXID1"
+	if txID, err := chain.SendRawTransaction(context.Background(), []byte{0x80}); err != nil || txID != "TXID1" {
+		t.Fatalf("resend returned txid %q, err:%v", txID, err)
+	}
+	// anything else algod rejects is still an error
+	message = "TransactionPool.Remember: transaction FAKE: overspend"
+	if _, err := chain.SendRawTransaction(context.Background(), []byte{0x80}); err == nil {
+		t.Fatal("rejected send counted as sent")
+	}
+}
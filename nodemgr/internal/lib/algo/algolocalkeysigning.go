@@ -2,38 +2,68 @@ package algo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
 	"strings"
 
 	"golang.org/x/crypto/ed25519"
 
 	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
 	"github.com/algorand/go-algorand-sdk/v2/mnemonic"
 	"github.com/algorand/go-algorand-sdk/v2/types"
 
 	"github.com/algorandfoundation/reti/internal/lib/misc"
 )
 
-func NewLocalKeyStore(log *slog.Logger) MultipleWalletSigner {
+// NewLocalKeyStore returns a signer using the keys of the (already unlocked) keystores.  Mnemonics are only loaded
+// from the environment and the secret providers if fromEnv is set.  Multisig account definitions are always loaded from the
+// environment as they contain no secrets.
+func NewLocalKeyStore(log *slog.Logger, fromEnv bool, keystores ...*Keystore) (MultipleWalletSigner, error) {
 	keyStore := &localKeyStore{
-		log:  log,
-		keys: map[string]ed25519.PrivateKey{},
+		log:       log,
+		keys:      map[string]ed25519.PrivateKey{},
+		multisigs: map[string]crypto.MultisigAccount{},
 	}
-	keyStore.loadFromEnvironment()
-	return keyStore
+	for _, ks := range keystores {
+		keys, err := ks.Keys()
+		if err != nil {
+			return nil, fmt.Errorf("unable to load keys from keystore %s: %w", ks.Filename(), err)
+		}
+		for address, key := range keys {
+			keyStore.keys[address] = key
+		}
+		misc.Infof(log, "loaded %d accounts from keystore %s", len(keys), ks.Filename())
+	}
+	if fromEnv {
+		keyStore.loadFromEnvironment()
+		keyStore.loadFromSecretProviders()
+	}
+	keyStore.loadMultisigsFromEnvironment()
+	return keyStore, nil
 }
 
 type localKeyStore struct {
 	log *slog.Logger
 
 	keys map[string]ed25519.PrivateKey
+	// multisig accounts, keyed by multisig address, whose subkeys may (partially) be in keys
+	multisigs map[string]crypto.MultisigAccount
 }
 
+// HasAccount returns whether the account can be fully signed for locally - for multisig accounts, at least
+// threshold subkeys have to be present.
 func (lk *localKeyStore) HasAccount(publicAddress string) bool {
-	_, found := lk.keys[publicAddress]
-	return found
+	if _, found := lk.keys[publicAddress]; found {
+		return true
+	}
+	if msig, found := lk.multisigs[publicAddress]; found {
+		return lk.numLocalSubkeys(msig) >= int(msig.Threshold)
+	}
+	return false
 }
 
 // FindFirstSigner finds the first signer among the given addresses.
@@ -57,6 +87,16 @@ func (lk *localKeyStore) FindFirstSigner(addresses []string) (string, error) {
 }
 
 func (lk *localKeyStore) SignWithAccount(ctx context.Context, tx types.Transaction, publicAddress string) (string, []byte, error) {
+	if msig, found := lk.multisigs[publicAddress]; found {
+		signed, complete, err := lk.signMultisig(tx, msig, nil)
+		if err != nil {
+			return "", nil, fmt.Errorf("unable to sign for multisig address %s: %w", publicAddress, err)
+		}
+		if !complete {
+			return "", nil, fmt.Errorf("%w for multisig address %s - export the transactions and collect the remaining signatures", ErrMultisigIncomplete, publicAddress)
+		}
+		return crypto.GetTxID(tx), signed, nil
+	}
 	key, found := lk.keys[publicAddress]
 	if !found {
 		return "", nil, fmt.Errorf("key not found for address %s", publicAddress)
@@ -64,6 +104,77 @@ func (lk *localKeyStore) SignWithAccount(ctx context.Context, tx types.Transacti
 	return crypto.SignTransaction(key, tx)
 }
 
+func (lk *localKeyStore) SignPartial(ctx context.Context, tx types.Transaction, publicAddress string, partial []byte) ([]byte, bool, error) {
+	if msig, found := lk.multisigs[publicAddress]; found {
+		return lk.signMultisig(tx, msig, partial)
+	}
+	key, found := lk.keys[publicAddress]
+	if !found {
+		return nil, false, ErrNoSigningKeys
+	}
+	_, signed, err := crypto.SignTransaction(key, tx)
+	return signed, err == nil, err
+}
+
+// signMultisig adds the signatures of every local subkey of the multisig account to partial (if not nil), returning
+// the signed transaction and whether the threshold has been met
+func (lk *localKeyStore) signMultisig(tx types.Transaction, msig crypto.MultisigAccount, partial []byte) ([]byte, bool, error) {
+	var (
+		signed   = partial
+		numAdded int
+		err      error
+	)
+	if partial != nil {
+		// only ever add signatures to the transaction we were asked to sign
+		var stxn types.SignedTxn
+		if err := msgpack.Decode(partial, &stxn); err != nil {
+			return nil, false, fmt.Errorf("invalid partially signed transaction: %w", err)
+		}
+		if crypto.GetTxID(stxn.Txn) != crypto.GetTxID(tx) {
+			return nil, false, errors.New("partially signed transaction doesn't match the transaction")
+		}
+	}
+	for _, pk := range msig.Pks {
+		key, found := lk.keys[subkeyAddress(pk)]
+		if !found {
+			continue
+		}
+		if signed == nil {
+			_, signed, err = crypto.SignMultisigTransaction(key, msig, tx)
+		} else {
+			_, signed, err = crypto.AppendMultisigTransaction(key, msig, signed)
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		numAdded++
+	}
+	if numAdded == 0 {
+		return partial, false, ErrNoSigningKeys
+	}
+	var stxn types.SignedTxn
+	if err := msgpack.Decode(signed, &stxn); err != nil {
+		return nil, false, err
+	}
+	return signed, isFullySigned(stxn), nil
+}
+
+func (lk *localKeyStore) numLocalSubkeys(msig crypto.MultisigAccount) int {
+	var numLocal int
+	for _, pk := range msig.Pks {
+		if _, found := lk.keys[subkeyAddress(pk)]; found {
+			numLocal++
+		}
+	}
+	return numLocal
+}
+
+func subkeyAddress(pk ed25519.PublicKey) string {
+	var addr types.Address
+	copy(addr[:], pk)
+	return addr.String()
+}
+
 // loadFromEnvironment loads mnemonics from environment variables (can be in .env files as well) containing "xxxxxx_MNEMONIC=(mnemonic string)"
 // and adds them to the localKeyStore's keys map. The number of loaded mnemonics is logged as well as the pks of each.
 // If an error occurs while adding a mnemonic, a fatal error is logged and the application exits.
@@ -74,6 +185,10 @@ func (lk *localKeyStore) loadFromEnvironment() {
 			continue
 		}
 		key := envVal[0:strings.IndexByte(envVal, '=')]
+		if strings.HasSuffix(key, "_FILE") {
+			// indirection to a secret file - loaded via the secret providers
+			continue
+		}
 		envMnemonic := os.Getenv(key)
 		// Skip empty keys - ie: blank demonstration env examples
 		if envMnemonic == "" {
@@ -88,6 +203,27 @@ func (lk *localKeyStore) loadFromEnvironment() {
 	misc.Debugf(lk.log, "loaded %d mnemonics", numMnemonics)
 }
 
+// loadFromSecretProviders loads mnemonics from the secret providers (see misc.ConfigureSecretProviders) - any secret
+// whose key contains "_MNEMONIC".  Like plain env vars, only loaded if mnemonics outside keystores are enabled.
+func (lk *localKeyStore) loadFromSecretProviders() {
+	var numMnemonics int
+	for _, key := range misc.ProviderSecretKeys() {
+		if !strings.Contains(key, "_MNEMONIC") {
+			continue
+		}
+		secretMnemonic := misc.GetProviderSecret(key)
+		if secretMnemonic == "" {
+			continue
+		}
+		if err := lk.addMnemonic(secretMnemonic); err != nil {
+			lk.log.Error(fmt.Sprintf("fatal error in secret mnemonic load, key:%s, err:%v", key, err))
+			os.Exit(1)
+		}
+		numMnemonics++
+	}
+	misc.Debugf(lk.log, "loaded %d mnemonics from secret providers", numMnemonics)
+}
+
 func (lk *localKeyStore) addMnemonic(mnemonicPhrase string) error {
 	key, err := mnemonic.ToPrivateKey(mnemonicPhrase)
 	if err != nil {
@@ -101,3 +237,51 @@ func (lk *localKeyStore) addMnemonic(mnemonicPhrase string) error {
 	misc.Infof(lk.log, "Mnemonics available for account:%s", account.Address.String())
 	return nil
 }
+
+// loadMultisigsFromEnvironment loads multisig account definitions from environment variables containing
+// "xxxxxx_MULTISIG=threshold:addr1,addr2,..." - the subkey addresses in the same order used to create the
+// multisig account.  Mnemonics for any number of the subkeys can be provided via the usual _MNEMONIC vars.
+// If a definition is invalid, a fatal error is logged and the application exits.
+func (lk *localKeyStore) loadMultisigsFromEnvironment() {
+	for _, envVal := range os.Environ() {
+		key := envVal[0:strings.IndexByte(envVal, '=')]
+		if !strings.HasSuffix(key, "_MULTISIG") || os.Getenv(key) == "" {
+			continue
+		}
+		if err := lk.addMultisig(os.Getenv(key)); err != nil {
+			lk.log.Error(fmt.Sprintf("fatal error in multisig load, idx key:%s, err:%v", key, err))
+			os.Exit(1)
+		}
+	}
+}
+
+func (lk *localKeyStore) addMultisig(definition string) error {
+	thresholdStr, addrList, found := strings.Cut(definition, ":")
+	if !found {
+		return fmt.Errorf("multisig definition must be threshold:addr1,addr2,...")
+	}
+	threshold, err := strconv.ParseUint(thresholdStr, 10, 8)
+	if err != nil {
+		return fmt.Errorf("invalid multisig threshold:%s", thresholdStr)
+	}
+	var addrs []types.Address
+	for _, addrStr := range strings.Split(addrList, ",") {
+		addr, err := types.DecodeAddress(strings.TrimSpace(addrStr))
+		if err != nil {
+			return fmt.Errorf("invalid multisig subkey address:%s: %w", addrStr, err)
+		}
+		addrs = append(addrs, addr)
+	}
+	msig, err := crypto.MultisigAccountWithParams(1, uint8(threshold), addrs)
+	if err != nil {
+		return err
+	}
+	msigAddr, err := msig.Address()
+	if err != nil {
+		return err
+	}
+	lk.multisigs[msigAddr.String()] = msig
+	misc.Infof(lk.log, "Multisig account:%s available, %d of %d subkeys present locally, threshold:%d",
+		msigAddr.String(), lk.numLocalSubkeys(msig), len(msig.Pks), msig.Threshold)
+	return nil
+}
@@ -26,6 +26,10 @@ type MultipleWalletSigner interface {
 	HasAccount(publicAddress string) bool
 	FindFirstSigner(addresses []string) (string, error)
 	SignWithAccount(ctx context.Context, tx types.Transaction, publicAddress string) (string, []byte, error)
+	// SignPartial adds whatever signatures it can for publicAddress to the (possibly nil) partially signed
+	// transaction - returning the signed transaction bytes and whether the signature is now complete.
+	// Only multisig accounts can be partially signed.  Returns ErrNoSigningKeys if no signatures could be added.
+	SignPartial(ctx context.Context, tx types.Transaction, publicAddress string, partial []byte) ([]byte, bool, error)
 }
 
 // SignGroupTransactions takes the slice of Transactions and of TxnSigner implementations and signs each according to the
@@ -0,0 +1,98 @@
+package algo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/algorandfoundation/reti/internal/lib/misc"
+)
+
+// AuthAddrSigner wraps a MultipleWalletSigner so accounts which have been rekeyed are signed for using the keys of
+// their auth address - which can itself be a multisig account known to the wrapped signer.
+type AuthAddrSigner struct {
+	log    *slog.Logger
+	chain  Chain
+	signer MultipleWalletSigner
+
+	sync.Mutex
+	// authAddrs caches the auth address of every account looked up - empty if the account isn't rekeyed
+	authAddrs map[string]string
+}
+
+// NewAuthAddrSigner returns an AuthAddrSigner looking up auth addresses via chain (once per account).  chain can be
+// nil when offline, in which case only auth addresses set via SetAuthAddr are used.
+func NewAuthAddrSigner(log *slog.Logger, chain Chain, signer MultipleWalletSigner) *AuthAddrSigner {
+	return &AuthAddrSigner{
+		log:       log,
+		chain:     chain,
+		signer:    signer,
+		authAddrs: map[string]string{},
+	}
+}
+
+// SetAuthAddr sets the auth address of an account explicitly rather than looking it up
+func (a *AuthAddrSigner) SetAuthAddr(address string, authAddr string) {
+	a.Lock()
+	defer a.Unlock()
+	a.authAddrs[address] = authAddr
+}
+
+// signingAddress returns the address whose keys sign for the specified account - its auth address if rekeyed
+func (a *AuthAddrSigner) signingAddress(ctx context.Context, address string) string {
+	a.Lock()
+	defer a.Unlock()
+	authAddr, found := a.authAddrs[address]
+	if !found {
+		if a.chain == nil {
+			return address
+		}
+		account, err := a.chain.AccountInformation(ctx, address, true)
+		if err != nil {
+			// not cached - so we'll try again next time
+			misc.Warnf(a.log, "unable to fetch auth address for account:%s, err:%v", address, err)
+			return address
+		}
+		authAddr = account.AuthAddr
+		if authAddr == types.ZeroAddress.String() {
+			authAddr = ""
+		}
+		a.authAddrs[address] = authAddr
+		if authAddr != "" {
+			misc.Infof(a.log, "account:%s is rekeyed to %s", address, authAddr)
+		}
+	}
+	if authAddr == "" {
+		return address
+	}
+	return authAddr
+}
+
+func (a *AuthAddrSigner) HasAccount(publicAddress string) bool {
+	return a.signer.HasAccount(a.signingAddress(context.Background(), publicAddress))
+}
+
+// FindFirstSigner returns the first of the addresses which can be signed for.  If addresses is empty, the first
+// account of the wrapped signer is returned.
+func (a *AuthAddrSigner) FindFirstSigner(addresses []string) (string, error) {
+	if len(addresses) == 0 {
+		return a.signer.FindFirstSigner(addresses)
+	}
+	for _, address := range addresses {
+		if a.HasAccount(address) {
+			return address, nil
+		}
+	}
+	return "", fmt.Errorf("no signer found for any of the addresses")
+}
+
+func (a *AuthAddrSigner) SignWithAccount(ctx context.Context, tx types.Transaction, publicAddress string) (string, []byte, error) {
+	return a.signer.SignWithAccount(ctx, tx, a.signingAddress(ctx, publicAddress))
+}
+
+func (a *AuthAddrSigner) SignPartial(ctx context.Context, tx types.Transaction, publicAddress string, partial []byte) ([]byte, bool, error) {
+	return a.signer.SignPartial(ctx, tx, a.signingAddress(ctx, publicAddress), partial)
+}
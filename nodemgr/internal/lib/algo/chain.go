@@ -0,0 +1,145 @@
+package algo
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// Chain is the narrow set of algod operations nodemgr depends on.  Everything that reads from or writes to the
+// chain (the daemon, the reti client, nfd lookups) goes through this so an in-memory implementation can be
+// swapped in for testing.
+type Chain interface {
+	Status(ctx context.Context) (models.NodeStatus, error)
+	StatusAfterBlock(ctx context.Context, round uint64) (models.NodeStatus, error)
+	SuggestedParams(ctx context.Context) (types.SuggestedParams, error)
+	Versions(ctx context.Context) (models.Version, error)
+	Block(ctx context.Context, round uint64) (types.Block, error)
+
+	// AccountInformation returns account data - if bare is true, asset/app data is excluded
+	AccountInformation(ctx context.Context, address string, bare bool) (models.Account, error)
+	AccountApplicationInformation(ctx context.Context, address string, appID uint64) (models.AccountApplicationResponse, error)
+	ApplicationByID(ctx context.Context, appID uint64) (models.Application, error)
+	ApplicationBoxes(ctx context.Context, appID uint64) (models.BoxesResponse, error)
+	ApplicationBoxByName(ctx context.Context, appID uint64, name []byte) (models.Box, error)
+	// TealCompile compiles TEAL source to program bytes - requires the developer API to be enabled on the node
+	TealCompile(ctx context.Context, source []byte) ([]byte, error)
+
+	ParticipationKeys(ctx context.Context) ([]ParticipationKey, error)
+	GenerateParticipationKey(ctx context.Context, account string, firstValid, lastValid, dilution uint64) error
+	DeleteParticipationKey(ctx context.Context, partKeyID string) error
+
+	// SimulateATC simulates the transaction group in the composer, decoding any ABI method results
+	SimulateATC(ctx context.Context, atc *transaction.AtomicTransactionComposer, request models.SimulateRequest) (transaction.SimulateResult, error)
+	// ExecuteATC signs and sends the transaction group in the composer, waiting up to waitRounds for confirmation
+	ExecuteATC(ctx context.Context, atc *transaction.AtomicTransactionComposer, waitRounds uint64) (transaction.ExecuteResult, error)
+	SendRawTransaction(ctx context.Context, txns []byte) (string, error)
+	WaitForConfirmation(ctx context.Context, txid string, waitRounds uint64) (models.PendingTransactionInfoResponse, error)
+}
+
+// NewAlgodChain returns a Chain implementation backed by a real algod client
+func NewAlgodChain(client *algod.Client) Chain {
+	return &algodChain{client: client}
+}
+
+type algodChain struct {
+	client *algod.Client
+}
+
+func (a *algodChain) Status(ctx context.Context) (models.NodeStatus, error) {
+	return a.client.Status().Do(ctx)
+}
+
+func (a *algodChain) StatusAfterBlock(ctx context.Context, round uint64) (models.NodeStatus, error) {
+	return a.client.StatusAfterBlock(round).Do(ctx)
+}
+
+func (a *algodChain) SuggestedParams(ctx context.Context) (types.SuggestedParams, error) {
+	return a.client.SuggestedParams().Do(ctx)
+}
+
+func (a *algodChain) Versions(ctx context.Context) (models.Version, error) {
+	return a.client.Versions().Do(ctx)
+}
+
+func (a *algodChain) Block(ctx context.Context, round uint64) (types.Block, error) {
+	return a.client.Block(round).Do(ctx)
+}
+
+func (a *algodChain) AccountInformation(ctx context.Context, address string, bare bool) (models.Account, error) {
+	if bare {
+		return a.client.AccountInformation(address).Exclude("all").Do(ctx)
+	}
+	return a.client.AccountInformation(address).Do(ctx)
+}
+
+func (a *algodChain) AccountApplicationInformation(ctx context.Context, address string, appID uint64) (models.AccountApplicationResponse, error) {
+	return a.client.AccountApplicationInformation(address, appID).Do(ctx)
+}
+
+func (a *algodChain) ApplicationByID(ctx context.Context, appID uint64) (models.Application, error) {
+	return a.client.GetApplicationByID(appID).Do(ctx)
+}
+
+func (a *algodChain) ApplicationBoxes(ctx context.Context, appID uint64) (models.BoxesResponse, error) {
+	return a.client.GetApplicationBoxes(appID).Do(ctx)
+}
+
+func (a *algodChain) ApplicationBoxByName(ctx context.Context, appID uint64, name []byte) (models.Box, error) {
+	return a.client.GetApplicationBoxByName(appID, name).Do(ctx)
+}
+
+func (a *algodChain) TealCompile(ctx context.Context, source []byte) ([]byte, error) {
+	response, err := a.client.TealCompile(source).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(response.Result)
+}
+
+func (a *algodChain) ParticipationKeys(ctx context.Context) ([]ParticipationKey, error) {
+	var response []ParticipationKey
+
+	err := (*common.Client)(a.client).Get(ctx, &response, "/v2/participation", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get participation keys: %w", err)
+	}
+	return response, nil
+}
+
+func (a *algodChain) GenerateParticipationKey(ctx context.Context, account string, firstValid, lastValid, dilution uint64) error {
+	var response struct{}
+	var params = GenerateParticipationKeysParams{
+		Dilution: dilution,
+		First:    firstValid,
+		Last:     lastValid,
+	}
+	return (*common.Client)(a.client).Post(ctx, &response, fmt.Sprintf("/v2/participation/generate/%s", account), params, nil, nil)
+}
+
+func (a *algodChain) DeleteParticipationKey(ctx context.Context, partKeyID string) error {
+	var response string
+	return (*common.Client)(a.client).Delete(ctx, &response, fmt.Sprintf("/v2/participation/%s", partKeyID), nil, nil)
+}
+
+func (a *algodChain) SimulateATC(ctx context.Context, atc *transaction.AtomicTransactionComposer, request models.SimulateRequest) (transaction.SimulateResult, error) {
+	return atc.Simulate(ctx, a.client, request)
+}
+
+func (a *algodChain) ExecuteATC(ctx context.Context, atc *transaction.AtomicTransactionComposer, waitRounds uint64) (transaction.ExecuteResult, error) {
+	return atc.Execute(a.client, ctx, waitRounds)
+}
+
+func (a *algodChain) SendRawTransaction(ctx context.Context, txns []byte) (string, error) {
+	return a.client.SendRawTransaction(txns).Do(ctx)
+}
+
+func (a *algodChain) WaitForConfirmation(ctx context.Context, txid string, waitRounds uint64) (models.PendingTransactionInfoResponse, error) {
+	return transaction.WaitForConfirmation(a.client, txid, waitRounds, ctx)
+}
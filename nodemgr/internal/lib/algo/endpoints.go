@@ -0,0 +1,306 @@
+package algo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/algorandfoundation/reti/internal/lib/misc"
+)
+
+// Algod endpoint roles - see NewEndpointPool
+const (
+	// RoleAdmin is the local node - participation keys are node specific, so key operations (and the node version)
+	// only ever use it
+	RoleAdmin = "admin"
+	// RoleRead endpoints handle chain reads and simulation
+	RoleRead = "read"
+	// RoleSubmit endpoints handle sending transactions and waiting for their confirmation
+	RoleSubmit = "submit"
+)
+
+// endpointRetryDelay is how long a failed endpoint is passed over - unless no other endpoint has the role
+const endpointRetryDelay = 30 * time.Second
+
+// AlgodEndpoint is one of the algod nodes of an EndpointPool
+type AlgodEndpoint struct {
+	// Name identifies the endpoint in logs - ie: its host, never including tokens
+	Name  string
+	Roles []string
+	Chain Chain
+}
+
+type endpointState struct {
+	AlgodEndpoint
+	// downUntil is when a failed endpoint is next tried - zero if healthy
+	downUntil time.Time
+}
+
+// EndpointPool is a Chain spread across algod endpoints by role.  Reads and submissions use the first healthy
+// endpoint with the role (in order), failing over to the next when an endpoint can't be reached, returns gateway
+// errors or (per Monitor) is catching up - so payouts and evictions keep running while the local node restarts.
+type EndpointPool struct {
+	log *slog.Logger
+
+	sync.Mutex
+	endpoints []*endpointState
+}
+
+// NewEndpointPool returns a Chain using the endpoints, in order of preference.  At most one endpoint may have the
+// admin role, and at least one must have the read and submit roles.
+func NewEndpointPool(log *slog.Logger, endpoints []AlgodEndpoint) (*EndpointPool, error) {
+	pool := &EndpointPool{log: log}
+	var numAdmin, numRead, numSubmit int
+	for _, endpoint := range endpoints {
+		for _, role := range endpoint.Roles {
+			switch role {
+			case RoleAdmin:
+				numAdmin++
+			case RoleRead:
+				numRead++
+			case RoleSubmit:
+				numSubmit++
+			default:
+				return nil, fmt.Errorf("unknown role:%s for algod endpoint %s, must be %s, %s or %s", role, endpoint.Name, RoleAdmin, RoleRead, RoleSubmit)
+			}
+		}
+		pool.endpoints = append(pool.endpoints, &endpointState{AlgodEndpoint: endpoint})
+	}
+	if numAdmin > 1 {
+		return nil, errors.New("only one algod endpoint can have the admin role")
+	}
+	if numRead == 0 || numSubmit == 0 {
+		return nil, errors.New("at least one algod endpoint must have the read role, and one the submit role")
+	}
+	return pool, nil
+}
+
+// candidates returns the endpoints with the role - healthy ones first (in order), then any passed over because
+// they've failed, as a last resort
+func (p *EndpointPool) candidates(role string) []*endpointState {
+	p.Lock()
+	defer p.Unlock()
+	var healthy, down []*endpointState
+	now := time.Now()
+	for _, endpoint := range p.endpoints {
+		if !slices.Contains(endpoint.Roles, role) {
+			continue
+		}
+		if endpoint.downUntil.After(now) {
+			down = append(down, endpoint)
+		} else {
+			healthy = append(healthy, endpoint)
+		}
+	}
+	return append(healthy, down...)
+}
+
+func (p *EndpointPool) markDown(endpoint *endpointState, err error) {
+	p.Lock()
+	defer p.Unlock()
+	if endpoint.downUntil.IsZero() {
+		misc.Warnf(p.log, "algod endpoint %s %v unavailable, failing over, err:%v", endpoint.Name, endpoint.Roles, err)
+	}
+	endpoint.downUntil = time.Now().Add(endpointRetryDelay)
+}
+
+func (p *EndpointPool) markUp(endpoint *endpointState) {
+	p.Lock()
+	defer p.Unlock()
+	if !endpoint.downUntil.IsZero() {
+		misc.Infof(p.log, "algod endpoint %s %v available again", endpoint.Name, endpoint.Roles)
+	}
+	endpoint.downUntil = time.Time{}
+}
+
+// Monitor checks the health of every endpoint each interval until ctx is done - so failed endpoints are used again
+// as soon as they're back, and endpoints catching up (ie: the local node after a restart) are passed over
+func (p *EndpointPool) Monitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		p.checkHealth(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *EndpointPool) checkHealth(ctx context.Context) {
+	for _, endpoint := range p.endpoints {
+		checkCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		status, err := endpoint.Chain.Status(checkCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		switch {
+		case err != nil:
+			p.markDown(endpoint, err)
+		case status.CatchupTime > 0:
+			p.markDown(endpoint, fmt.Errorf("catching up, at round %d", status.LastRound))
+		default:
+			p.markUp(endpoint)
+		}
+	}
+}
+
+// isEndpointFailure returns true if the error is the endpoint's rather than the request's - it couldn't be reached,
+// or a proxy in front of it couldn't reach it
+func isEndpointFailure(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+	for _, status := range []string{"HTTP 502", "HTTP 503", "HTTP 504"} {
+		if strings.HasPrefix(err.Error(), status) {
+			return true
+		}
+	}
+	return false
+}
+
+// withEndpoint performs op against the endpoints with the role, failing over to the next candidate on endpoint
+// failures
+func withEndpoint[T any](ctx context.Context, p *EndpointPool, role string, op func(chain Chain) (T, error)) (T, error) {
+	var result T
+	err := fmt.Errorf("%w with the %s role", ErrNoAlgodEndpoint, role)
+	for _, endpoint := range p.candidates(role) {
+		result, err = op(endpoint.Chain)
+		if err == nil || !isEndpointFailure(err) {
+			p.markUp(endpoint)
+			return result, err
+		}
+		if ctx.Err() != nil {
+			return result, err
+		}
+		p.markDown(endpoint, err)
+	}
+	return result, err
+}
+
+func (p *EndpointPool) Status(ctx context.Context) (models.NodeStatus, error) {
+	return withEndpoint(ctx, p, RoleRead, func(chain Chain) (models.NodeStatus, error) {
+		return chain.Status(ctx)
+	})
+}
+
+func (p *EndpointPool) StatusAfterBlock(ctx context.Context, round uint64) (models.NodeStatus, error) {
+	return withEndpoint(ctx, p, RoleRead, func(chain Chain) (models.NodeStatus, error) {
+		return chain.StatusAfterBlock(ctx, round)
+	})
+}
+
+func (p *EndpointPool) SuggestedParams(ctx context.Context) (types.SuggestedParams, error) {
+	return withEndpoint(ctx, p, RoleRead, func(chain Chain) (types.SuggestedParams, error) {
+		return chain.SuggestedParams(ctx)
+	})
+}
+
+// Versions returns the version of the admin (local) node - the node the pools participate with
+func (p *EndpointPool) Versions(ctx context.Context) (models.Version, error) {
+	return withEndpoint(ctx, p, RoleAdmin, func(chain Chain) (models.Version, error) {
+		return chain.Versions(ctx)
+	})
+}
+
+func (p *EndpointPool) Block(ctx context.Context, round uint64) (types.Block, error) {
+	return withEndpoint(ctx, p, RoleRead, func(chain Chain) (types.Block, error) {
+		return chain.Block(ctx, round)
+	})
+}
+
+func (p *EndpointPool) AccountInformation(ctx context.Context, address string, bare bool) (models.Account, error) {
+	return withEndpoint(ctx, p, RoleRead, func(chain Chain) (models.Account, error) {
+		return chain.AccountInformation(ctx, address, bare)
+	})
+}
+
+func (p *EndpointPool) AccountApplicationInformation(ctx context.Context, address string, appID uint64) (models.AccountApplicationResponse, error) {
+	return withEndpoint(ctx, p, RoleRead, func(chain Chain) (models.AccountApplicationResponse, error) {
+		return chain.AccountApplicationInformation(ctx, address, appID)
+	})
+}
+
+func (p *EndpointPool) ApplicationByID(ctx context.Context, appID uint64) (models.Application, error) {
+	return withEndpoint(ctx, p, RoleRead, func(chain Chain) (models.Application, error) {
+		return chain.ApplicationByID(ctx, appID)
+	})
+}
+
+func (p *EndpointPool) ApplicationBoxes(ctx context.Context, appID uint64) (models.BoxesResponse, error) {
+	return withEndpoint(ctx, p, RoleRead, func(chain Chain) (models.BoxesResponse, error) {
+		return chain.ApplicationBoxes(ctx, appID)
+	})
+}
+
+func (p *EndpointPool) ApplicationBoxByName(ctx context.Context, appID uint64, name []byte) (models.Box, error) {
+	return withEndpoint(ctx, p, RoleRead, func(chain Chain) (models.Box, error) {
+		return chain.ApplicationBoxByName(ctx, appID, name)
+	})
+}
+
+func (p *EndpointPool) TealCompile(ctx context.Context, source []byte) ([]byte, error) {
+	return withEndpoint(ctx, p, RoleRead, func(chain Chain) ([]byte, error) {
+		return chain.TealCompile(ctx, source)
+	})
+}
+
+func (p *EndpointPool) ParticipationKeys(ctx context.Context) ([]ParticipationKey, error) {
+	return withEndpoint(ctx, p, RoleAdmin, func(chain Chain) ([]ParticipationKey, error) {
+		return chain.ParticipationKeys(ctx)
+	})
+}
+
+func (p *EndpointPool) GenerateParticipationKey(ctx context.Context, account string, firstValid, lastValid, dilution uint64) error {
+	_, err := withEndpoint(ctx, p, RoleAdmin, func(chain Chain) (struct{}, error) {
+		return struct{}{}, chain.GenerateParticipationKey(ctx, account, firstValid, lastValid, dilution)
+	})
+	return err
+}
+
+func (p *EndpointPool) DeleteParticipationKey(ctx context.Context, partKeyID string) error {
+	_, err := withEndpoint(ctx, p, RoleAdmin, func(chain Chain) (struct{}, error) {
+		return struct{}{}, chain.DeleteParticipationKey(ctx, partKeyID)
+	})
+	return err
+}
+
+func (p *EndpointPool) SimulateATC(ctx context.Context, atc *transaction.AtomicTransactionComposer, request models.SimulateRequest) (transaction.SimulateResult, error) {
+	return withEndpoint(ctx, p, RoleRead, func(chain Chain) (transaction.SimulateResult, error) {
+		return chain.SimulateATC(ctx, atc, request)
+	})
+}
+
+// ExecuteATC sends the group via a submit endpoint - if that fails, the group is resent to the next.  When the first
+// send made it through the next algod reports the group as already in the ledger, which the algod client counts as
+// sent (see alreadySentTransport) so the group is waited for, and its results decoded, via the next endpoint.
+func (p *EndpointPool) ExecuteATC(ctx context.Context, atc *transaction.AtomicTransactionComposer, waitRounds uint64) (transaction.ExecuteResult, error) {
+	return withEndpoint(ctx, p, RoleSubmit, func(chain Chain) (transaction.ExecuteResult, error) {
+		return chain.ExecuteATC(ctx, atc, waitRounds)
+	})
+}
+
+func (p *EndpointPool) SendRawTransaction(ctx context.Context, txns []byte) (string, error) {
+	return withEndpoint(ctx, p, RoleSubmit, func(chain Chain) (string, error) {
+		return chain.SendRawTransaction(ctx, txns)
+	})
+}
+
+func (p *EndpointPool) WaitForConfirmation(ctx context.Context, txid string, waitRounds uint64) (models.PendingTransactionInfoResponse, error) {
+	return withEndpoint(ctx, p, RoleSubmit, func(chain Chain) (models.PendingTransactionInfoResponse, error) {
+		return chain.WaitForConfirmation(ctx, txid, waitRounds)
+	})
+}
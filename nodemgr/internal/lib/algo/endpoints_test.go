@@ -0,0 +1,163 @@
+package algo_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+
+	"github.com/algorandfoundation/reti/internal/lib/algo"
+	"github.com/algorandfoundation/reti/internal/lib/algo/fakechain"
+)
+
+// flakyChain is a fake chain which can be made unreachable, or catching up, like a restarting node
+type flakyChain struct {
+	*fakechain.Chain
+
+	sync.Mutex
+	down       bool
+	catchingUp bool
+	calls      int
+}
+
+func (f *flakyChain) set(down bool, catchingUp bool) {
+	f.Lock()
+	defer f.Unlock()
+	f.down, f.catchingUp = down, catchingUp
+}
+
+func (f *flakyChain) numCalls() int {
+	f.Lock()
+	defer f.Unlock()
+	return f.calls
+}
+
+func (f *flakyChain) Status(ctx context.Context) (models.NodeStatus, error) {
+	f.Lock()
+	f.calls++
+	down, catchingUp := f.down, f.catchingUp
+	f.Unlock()
+	if down {
+		return models.NodeStatus{}, &url.Error{Op: "Get", URL: "http://fake/v2/status", Err: errors.New("connection refused")}
+	}
+	status, err := f.Chain.Status(ctx)
+	if catchingUp {
+		status.CatchupTime = 1000
+	}
+	return status, err
+}
+
+func newTestPool(t *testing.T) (*algo.EndpointPool, *flakyChain, *flakyChain) {
+	t.Helper()
+	primary := &flakyChain{Chain: fakechain.New(100)}
+	backup := &flakyChain{Chain: fakechain.New(200)}
+	pool, err := algo.NewEndpointPool(slog.New(slog.NewTextHandler(io.Discard, nil)), []algo.AlgodEndpoint{
+		{Name: "primary", Roles: []string{algo.RoleAdmin, algo.RoleRead, algo.RoleSubmit}, Chain: primary},
+		{Name: "backup", Roles: []string{algo.RoleRead, algo.RoleSubmit}, Chain: backup},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pool, primary, backup
+}
+
+func lastRound(t *testing.T, pool *algo.EndpointPool) uint64 {
+	t.Helper()
+	status, err := pool.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	return status.LastRound
+}
+
+func TestNewEndpointPoolRoles(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	chain := fakechain.New(1)
+	tests := []struct {
+		name      string
+		endpoints []algo.AlgodEndpoint
+	}{
+		{"unknown role", []algo.AlgodEndpoint{{Name: "a", Roles: []string{algo.RoleRead, algo.RoleSubmit, "write"}, Chain: chain}}},
+		{"two admins", []algo.AlgodEndpoint{
+			{Name: "a", Roles: []string{algo.RoleAdmin, algo.RoleRead, algo.RoleSubmit}, Chain: chain},
+			{Name: "b", Roles: []string{algo.RoleAdmin}, Chain: chain},
+		}},
+		{"no submit", []algo.AlgodEndpoint{{Name: "a", Roles: []string{algo.RoleAdmin, algo.RoleRead}, Chain: chain}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := algo.NewEndpointPool(log, tt.endpoints); err == nil {
+				t.Fatal("expected endpoints to be refused")
+			}
+		})
+	}
+}
+
+func TestEndpointPoolFailover(t *testing.T) {
+	pool, primary, _ := newTestPool(t)
+
+	if round := lastRound(t, pool); round != 100 {
+		t.Fatalf("expected the primary endpoint to be used, got round %d", round)
+	}
+
+	primary.set(true, false)
+	if round := lastRound(t, pool); round != 200 {
+		t.Fatalf("expected failover to the backup endpoint, got round %d", round)
+	}
+	// the failed endpoint is passed over until it's known to be back, rather than tried (and timing out) every time
+	primaryCalls := primary.numCalls()
+	primary.set(false, false)
+	if round := lastRound(t, pool); round != 200 {
+		t.Fatalf("expected the backup endpoint to still be used, got round %d", round)
+	}
+	if primary.numCalls() != primaryCalls {
+		t.Fatal("failed endpoint was tried again before its retry delay")
+	}
+
+	// participation keys only ever use the admin endpoint - even when it's been marked down
+	if _, err := pool.ParticipationKeys(context.Background()); err != nil {
+		t.Fatalf("ParticipationKeys: %v", err)
+	}
+
+	// request errors (rather than endpoint failures) aren't failed over - the next endpoint would say the same
+	pool2, _, backup2 := newTestPool(t)
+	backup2.SetGlobalUint(1234, "numV", 1)
+	if _, err := pool2.ApplicationByID(context.Background(), 1234); err == nil {
+		t.Fatal("expected the primary endpoint's error, not a failover to the backup")
+	}
+}
+
+func TestEndpointPoolMonitor(t *testing.T) {
+	pool, primary, _ := newTestPool(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go pool.Monitor(ctx, 10*time.Millisecond)
+
+	waitForRound := func(expected uint64) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for lastRound(t, pool) != expected {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for the endpoint at round %d to be used", expected)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	// a node catching up (ie: after a restart) is passed over until it's caught up
+	primary.set(false, true)
+	waitForRound(200)
+	primary.set(false, false)
+	waitForRound(100)
+
+	primary.set(true, false)
+	waitForRound(200)
+	primary.set(false, false)
+	waitForRound(100)
+}
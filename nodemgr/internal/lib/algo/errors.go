@@ -4,4 +4,22 @@ import "errors"
 
 var (
 	ErrStateKeyNotFound = errors.New("key in global state not found")
+	// ErrTxnsExported is returned by an export chain (see NewExportChain) in place of executing a transaction group
+	ErrTxnsExported = errors.New("unsigned transactions exported")
+	// ErrNoSigningKeys is returned when none of the keys needed to sign for an account are available
+	ErrNoSigningKeys = errors.New("no local keys available to sign")
+	// ErrMultisigIncomplete is returned when fewer multisig subkeys than the threshold are available locally
+	ErrMultisigIncomplete = errors.New("not enough multisig subkeys available locally to meet threshold")
+	// ErrSignRejected is returned when a remote signing service's policy doesn't allow the transaction
+	ErrSignRejected = errors.New("signing rejected by policy")
+	// ErrNoAlgodEndpoint is returned by an EndpointPool with no endpoint having the role an operation needs
+	ErrNoAlgodEndpoint = errors.New("no algod endpoint available")
+	// ErrGenesisMismatch is returned when the algod node isn't on the selected network
+	ErrGenesisMismatch = errors.New("algod node is on a different network")
+	// ErrReadOnly is returned by a read-only chain or signer (see NewReadOnlyChain) in place of any write
+	ErrReadOnly = errors.New("not allowed in read-only mode")
+
+	ErrKeystoreLocked    = errors.New("keystore is locked")
+	ErrWrongPassphrase   = errors.New("wrong keystore passphrase")
+	ErrKeystoreNoAccount = errors.New("account not in keystore")
 )
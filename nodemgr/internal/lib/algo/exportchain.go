@@ -0,0 +1,66 @@
+package algo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// MaxTxnValidityRounds is the most rounds a transaction can be valid for (the protocol's max transaction lifetime)
+const MaxTxnValidityRounds = 1000
+
+// NewExportChain wraps a Chain so transaction groups that would be signed and sent via ExecuteATC are instead written,
+// unsigned, to filename in the specified format (TxnFormatJSON or TxnFormatMsgpack).  ExecuteATC then returns
+// ErrTxnsExported, so the caller stops after the first group - the file can be signed offline (see TxnFile.Sign)
+// and then submitted.  The exported transactions are valid for validRounds rounds from their first valid round (at
+// most MaxTxnValidityRounds), giving time to sign them.  All other calls go to the wrapped chain.
+func NewExportChain(chain Chain, filename string, format string, validRounds uint64) Chain {
+	return &exportChain{
+		Chain:       chain,
+		filename:    filename,
+		format:      format,
+		validRounds: min(validRounds, MaxTxnValidityRounds),
+	}
+}
+
+type exportChain struct {
+	Chain
+	filename    string
+	format      string
+	validRounds uint64
+}
+
+func (e *exportChain) ExecuteATC(ctx context.Context, atc *transaction.AtomicTransactionComposer, waitRounds uint64) (transaction.ExecuteResult, error) {
+	group, err := atc.BuildGroup()
+	if err != nil {
+		return transaction.ExecuteResult{}, err
+	}
+	txns := make([]types.Transaction, 0, len(group))
+	for _, txnWithSigner := range group {
+		txn := txnWithSigner.Txn
+		txn.LastValid = txn.FirstValid + types.Round(e.validRounds)
+		txn.Group = types.Digest{}
+		txns = append(txns, txn)
+	}
+	// the validity changing changes the transaction ids, so the group id has to be recomputed
+	if len(txns) > 1 {
+		gid, err := crypto.ComputeGroupID(txns)
+		if err != nil {
+			return transaction.ExecuteResult{}, fmt.Errorf("failed to compute group id: %w", err)
+		}
+		for i := range txns {
+			txns[i].Group = gid
+		}
+	}
+	txnFile, err := NewUnsignedTxnFile(txns, e.format)
+	if err != nil {
+		return transaction.ExecuteResult{}, err
+	}
+	if err := txnFile.Write(e.filename); err != nil {
+		return transaction.ExecuteResult{}, fmt.Errorf("unable to export transactions: %w", err)
+	}
+	return transaction.ExecuteResult{}, fmt.Errorf("%w: %d transaction(s) written to %s", ErrTxnsExported, len(txns), e.filename)
+}
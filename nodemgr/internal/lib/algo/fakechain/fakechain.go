@@ -0,0 +1,725 @@
+// Package fakechain provides an in-memory implementation of algo.Chain.  It keeps a tiny ledger of accounts,
+// application state, boxes and participation keys, advances rounds on demand and dispatches ABI method calls
+// to registered handlers - enough to drive the daemon's participation, epoch and eviction logic without a network.
+package fakechain
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/abi"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/algorandfoundation/reti/internal/lib/algo"
+)
+
+const (
+	GenesisID       = "fakenet-v1"
+	DefaultMinFee   = 1000
+	DefaultBlockDur = 2800 * time.Millisecond
+)
+
+var abiReturnPrefix = []byte{0x15, 0x1f, 0x7c, 0x75}
+
+// Account is the ledger state the fake tracks for an address
+type Account struct {
+	Amount            uint64
+	MinBalance        uint64
+	Online            bool
+	IncentiveEligible bool
+	Participation     models.AccountParticipation
+	Assets            []models.AssetHolding
+	CreatedAssets     []models.Asset
+}
+
+type application struct {
+	approvalProgram []byte
+	globalState     map[string]models.TealValue
+	boxes           map[string][]byte
+}
+
+// MethodCall is passed to a MethodHandler for every ABI method call in a simulated or executed group.
+type MethodCall struct {
+	AppID  uint64
+	Sender types.Address
+	Method abi.Method
+	// Args are the decoded (non-transaction) method arguments
+	Args []any
+	// Txn is the application call itself, Group is the full group it was sent in
+	Txn   types.Transaction
+	Group []types.Transaction
+	// Simulate is set when the group is only being simulated - handlers must not change state
+	Simulate bool
+}
+
+// MethodHandler implements a contract method.  The returned value is ABI encoded as the method's return
+// value (ignored for void methods) and a returned error fails the whole group.
+type MethodHandler func(c *Chain, call MethodCall) (any, error)
+
+type methodEntry struct {
+	method  abi.Method
+	handler MethodHandler
+}
+
+// Chain is an in-memory algo.Chain implementation.  The zero value isn't usable, use New.
+type Chain struct {
+	sync.Mutex
+
+	round       uint64
+	genesisTime time.Time
+	blockDur    time.Duration
+	version     models.Version
+
+	accounts  map[string]*Account
+	apps      map[uint64]*application
+	partKeys  map[string]algo.ParticipationKey
+	nextKeyID int
+	methods   map[string]methodEntry
+	confirmed map[string]models.PendingTransactionInfoResponse
+}
+
+// New returns a fake chain starting at the specified round
+func New(startRound uint64) *Chain {
+	return &Chain{
+		round:       startRound,
+		genesisTime: time.Now().Add(-time.Duration(startRound) * DefaultBlockDur),
+		blockDur:    DefaultBlockDur,
+		version: models.Version{
+			Build:     models.BuildVersion{Major: 4, Minor: 0, BuildNumber: 0, Branch: "fake", CommitHash: "0000000"},
+			GenesisID: GenesisID,
+		},
+		accounts:  map[string]*Account{},
+		apps:      map[uint64]*application{},
+		partKeys:  map[string]algo.ParticipationKey{},
+		methods:   map[string]methodEntry{},
+		confirmed: map[string]models.PendingTransactionInfoResponse{},
+	}
+}
+
+// -- ledger manipulation
+
+// Round returns the current (last committed) round
+func (c *Chain) Round() uint64 {
+	c.Lock()
+	defer c.Unlock()
+	return c.round
+}
+
+// AdvanceRounds moves the chain forward numRounds, expiring the participation of any online account whose
+// key is no longer valid.
+func (c *Chain) AdvanceRounds(numRounds uint64) uint64 {
+	c.Lock()
+	defer c.Unlock()
+	c.advanceTo(c.round + numRounds)
+	return c.round
+}
+
+func (c *Chain) advanceTo(round uint64) {
+	if round <= c.round {
+		return
+	}
+	c.round = round
+	for _, account := range c.accounts {
+		if account.Online && account.Participation.VoteLastValid < c.round {
+			account.Online = false
+			account.Participation = models.AccountParticipation{}
+		}
+	}
+}
+
+// SetBlockDuration changes the time between block timestamps returned by Block
+func (c *Chain) SetBlockDuration(dur time.Duration) {
+	c.Lock()
+	defer c.Unlock()
+	c.blockDur = dur
+}
+
+// SetAccount creates or replaces the ledger entry for address
+func (c *Chain) SetAccount(address string, account Account) {
+	c.Lock()
+	defer c.Unlock()
+	acct := account
+	c.accounts[address] = &acct
+}
+
+// GetAccount returns a copy of the ledger entry for address
+func (c *Chain) GetAccount(address string) Account {
+	c.Lock()
+	defer c.Unlock()
+	return *c.account(address)
+}
+
+// Fund adds microAlgos to address
+func (c *Chain) Fund(address string, microAlgos uint64) {
+	c.Lock()
+	defer c.Unlock()
+	c.account(address).Amount += microAlgos
+}
+
+// GoOnline marks address online against the specified participation key data
+func (c *Chain) GoOnline(address string, votePK, selectionPK, stateProofPK []byte, voteFirst, voteLast, voteKeyDilution uint64) {
+	c.Lock()
+	defer c.Unlock()
+	account := c.account(address)
+	account.Online = true
+	account.Participation = models.AccountParticipation{
+		SelectionParticipationKey: selectionPK,
+		StateProofKey:             stateProofPK,
+		VoteParticipationKey:      votePK,
+		VoteFirstValid:            voteFirst,
+		VoteLastValid:             voteLast,
+		VoteKeyDilution:           voteKeyDilution,
+	}
+}
+
+// GoOffline marks address offline
+func (c *Chain) GoOffline(address string) {
+	c.Lock()
+	defer c.Unlock()
+	account := c.account(address)
+	account.Online = false
+	account.Participation = models.AccountParticipation{}
+}
+
+func (c *Chain) account(address string) *Account {
+	account, found := c.accounts[address]
+	if !found {
+		account = &Account{MinBalance: 100_000}
+		c.accounts[address] = account
+	}
+	return account
+}
+
+func (c *Chain) app(appID uint64) *application {
+	app, found := c.apps[appID]
+	if !found {
+		app = &application{globalState: map[string]models.TealValue{}, boxes: map[string][]byte{}}
+		c.apps[appID] = app
+	}
+	return app
+}
+
+// SetGlobalUint sets a uint64 global state value for appID
+func (c *Chain) SetGlobalUint(appID uint64, key string, value uint64) {
+	c.Lock()
+	defer c.Unlock()
+	c.app(appID).globalState[key] = models.TealValue{Type: 2, Uint: value}
+}
+
+// SetGlobalBytes sets a byte-slice global state value for appID
+func (c *Chain) SetGlobalBytes(appID uint64, key string, value []byte) {
+	c.Lock()
+	defer c.Unlock()
+	c.app(appID).globalState[key] = models.TealValue{Type: 1, Bytes: base64.StdEncoding.EncodeToString(value)}
+}
+
+// SetBox sets the contents of a box for appID
+func (c *Chain) SetBox(appID uint64, name []byte, value []byte) {
+	c.Lock()
+	defer c.Unlock()
+	c.app(appID).boxes[string(name)] = bytes.Clone(value)
+}
+
+// DeleteApplication removes appID and all of its state, as if it had been deleted
+func (c *Chain) DeleteApplication(appID uint64) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.apps, appID)
+}
+
+// SetApprovalProgram sets the approval program returned for appID
+func (c *Chain) SetApprovalProgram(appID uint64, program []byte) {
+	c.Lock()
+	defer c.Unlock()
+	c.app(appID).approvalProgram = bytes.Clone(program)
+}
+
+// AddParticipationKey inserts an already generated participation key, returning its id
+func (c *Chain) AddParticipationKey(key algo.ParticipationKey) string {
+	c.Lock()
+	defer c.Unlock()
+	if key.Id == "" {
+		c.nextKeyID++
+		key.Id = fmt.Sprintf("FAKEKEY%06d", c.nextKeyID)
+	}
+	c.partKeys[key.Id] = key
+	return key.Id
+}
+
+// RegisterContract makes every method of contract known to the fake so calls to methods without a handler
+// are still treated as (no-op) ABI method calls.
+func (c *Chain) RegisterContract(contract *abi.Contract) {
+	c.Lock()
+	defer c.Unlock()
+	for _, method := range contract.Methods {
+		selector := string(method.GetSelector())
+		if _, found := c.methods[selector]; !found {
+			c.methods[selector] = methodEntry{method: method}
+		}
+	}
+}
+
+// HandleMethod registers the handler for a method (across all applications)
+func (c *Chain) HandleMethod(method abi.Method, handler MethodHandler) {
+	c.Lock()
+	defer c.Unlock()
+	c.methods[string(method.GetSelector())] = methodEntry{method: method, handler: handler}
+}
+
+// HandleKeyRegistration wires the staking pool goOnline/goOffline methods from contract so they change the online
+// status of the calling pool's application account - as the real contract's keyreg inner transactions would.
+func (c *Chain) HandleKeyRegistration(contract *abi.Contract) error {
+	goOnline, err := contract.GetMethodByName("goOnline")
+	if err != nil {
+		return err
+	}
+	goOffline, err := contract.GetMethodByName("goOffline")
+	if err != nil {
+		return err
+	}
+	c.HandleMethod(goOnline, func(c *Chain, call MethodCall) (any, error) {
+		if len(call.Args) != 6 {
+			return nil, fmt.Errorf("goOnline expects 6 non-txn args, got %d", len(call.Args))
+		}
+		if !call.Simulate {
+			c.GoOnline(crypto.GetApplicationAddress(call.AppID).String(),
+				call.Args[0].([]byte), call.Args[1].([]byte), call.Args[2].([]byte),
+				call.Args[3].(uint64), call.Args[4].(uint64), call.Args[5].(uint64))
+		}
+		return nil, nil
+	})
+	c.HandleMethod(goOffline, func(c *Chain, call MethodCall) (any, error) {
+		if !call.Simulate {
+			c.GoOffline(crypto.GetApplicationAddress(call.AppID).String())
+		}
+		return nil, nil
+	})
+	return nil
+}
+
+// -- algo.Chain implementation
+
+func (c *Chain) Status(_ context.Context) (models.NodeStatus, error) {
+	c.Lock()
+	defer c.Unlock()
+	return models.NodeStatus{LastRound: c.round, LastVersion: "future"}, nil
+}
+
+// StatusAfterBlock returns immediately - if the chain isn't past round yet, it's advanced to round+1 as if the
+// blocks had been produced while waiting.
+func (c *Chain) StatusAfterBlock(ctx context.Context, round uint64) (models.NodeStatus, error) {
+	if err := ctx.Err(); err != nil {
+		return models.NodeStatus{}, err
+	}
+	c.Lock()
+	c.advanceTo(round + 1)
+	c.Unlock()
+	return c.Status(ctx)
+}
+
+func (c *Chain) SuggestedParams(_ context.Context) (types.SuggestedParams, error) {
+	c.Lock()
+	defer c.Unlock()
+	return types.SuggestedParams{
+		Fee:             0,
+		GenesisID:       GenesisID,
+		GenesisHash:     make([]byte, 32),
+		FirstRoundValid: types.Round(c.round),
+		LastRoundValid:  types.Round(c.round + 1000),
+		MinFee:          DefaultMinFee,
+	}, nil
+}
+
+func (c *Chain) Versions(_ context.Context) (models.Version, error) {
+	c.Lock()
+	defer c.Unlock()
+	return c.version, nil
+}
+
+func (c *Chain) Block(_ context.Context, round uint64) (types.Block, error) {
+	c.Lock()
+	defer c.Unlock()
+	if round > c.round {
+		return types.Block{}, fmt.Errorf("failed to retrieve information from the ledger: round %d is not yet available", round)
+	}
+	var block types.Block
+	block.Round = types.Round(round)
+	block.TimeStamp = c.genesisTime.Add(time.Duration(round) * c.blockDur).Unix()
+	return block, nil
+}
+
+func (c *Chain) AccountInformation(_ context.Context, address string, bare bool) (models.Account, error) {
+	if _, err := types.DecodeAddress(address); err != nil {
+		return models.Account{}, err
+	}
+	c.Lock()
+	defer c.Unlock()
+	account := c.account(address)
+	info := models.Account{
+		Address:           address,
+		Amount:            account.Amount,
+		MinBalance:        account.MinBalance,
+		Status:            "Offline",
+		IncentiveEligible: account.IncentiveEligible,
+		Participation:     account.Participation,
+		Round:             c.round,
+	}
+	if account.Online {
+		info.Status = "Online"
+	}
+	if !bare {
+		info.Assets = append(info.Assets, account.Assets...)
+		info.CreatedAssets = append(info.CreatedAssets, account.CreatedAssets...)
+	}
+	return info, nil
+}
+
+func (c *Chain) AccountApplicationInformation(_ context.Context, address string, appID uint64) (models.AccountApplicationResponse, error) {
+	return models.AccountApplicationResponse{}, fmt.Errorf("account %s has not opted in to application %d", address, appID)
+}
+
+func (c *Chain) ApplicationByID(_ context.Context, appID uint64) (models.Application, error) {
+	c.Lock()
+	defer c.Unlock()
+	app, found := c.apps[appID]
+	if !found {
+		return models.Application{}, fmt.Errorf("application does not exist")
+	}
+	var keys []string
+	for key := range app.globalState {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var globalState []models.TealKeyValue
+	for _, key := range keys {
+		globalState = append(globalState, models.TealKeyValue{
+			Key:   base64.StdEncoding.EncodeToString([]byte(key)),
+			Value: app.globalState[key],
+		})
+	}
+	return models.Application{
+		Id: appID,
+		Params: models.ApplicationParams{
+			ApprovalProgram: bytes.Clone(app.approvalProgram),
+			GlobalState:     globalState,
+		},
+	}, nil
+}
+
+func (c *Chain) ApplicationBoxes(_ context.Context, appID uint64) (models.BoxesResponse, error) {
+	c.Lock()
+	defer c.Unlock()
+	var resp models.BoxesResponse
+	if app, found := c.apps[appID]; found {
+		for name := range app.boxes {
+			resp.Boxes = append(resp.Boxes, models.BoxDescriptor{Name: []byte(name)})
+		}
+	}
+	return resp, nil
+}
+
+func (c *Chain) ApplicationBoxByName(_ context.Context, appID uint64, name []byte) (models.Box, error) {
+	c.Lock()
+	defer c.Unlock()
+	if app, found := c.apps[appID]; found {
+		if value, found := app.boxes[string(name)]; found {
+			return models.Box{Name: name, Value: bytes.Clone(value), Round: c.round}, nil
+		}
+	}
+	return models.Box{}, errors.New("box not found")
+}
+
+// TealCompile doesn't assemble anything - the 'program' is just the source itself, so tests can set approval
+// programs via SetApprovalProgram using the (template substituted) TEAL source.
+func (c *Chain) TealCompile(_ context.Context, source []byte) ([]byte, error) {
+	return bytes.Clone(source), nil
+}
+
+func (c *Chain) ParticipationKeys(_ context.Context) ([]algo.ParticipationKey, error) {
+	c.Lock()
+	defer c.Unlock()
+	var keys []algo.ParticipationKey
+	for _, key := range c.partKeys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Id < keys[j].Id })
+	return keys, nil
+}
+
+// GenerateParticipationKey creates the key immediately (with random key material) rather than in the background
+func (c *Chain) GenerateParticipationKey(_ context.Context, account string, firstValid, lastValid, dilution uint64) error {
+	if _, err := types.DecodeAddress(account); err != nil {
+		return err
+	}
+	if lastValid <= firstValid {
+		return fmt.Errorf("invalid validity range: %d - %d", firstValid, lastValid)
+	}
+	var key algo.ParticipationKey
+	key.Address = account
+	key.EffectiveFirstValid = firstValid
+	key.EffectiveLastValid = lastValid
+	key.Key.VoteFirstValid = firstValid
+	key.Key.VoteLastValid = lastValid
+	key.Key.VoteKeyDilution = dilution
+	if dilution == 0 {
+		key.Key.VoteKeyDilution = 1 + uint64(lastValid-firstValid)/10_000
+	}
+	key.Key.SelectionParticipationKey = randomBytes(32)
+	key.Key.VoteParticipationKey = randomBytes(32)
+	key.Key.StateProofKey = randomBytes(64)
+	c.AddParticipationKey(key)
+	return nil
+}
+
+func (c *Chain) DeleteParticipationKey(_ context.Context, partKeyID string) error {
+	c.Lock()
+	defer c.Unlock()
+	if _, found := c.partKeys[partKeyID]; !found {
+		return fmt.Errorf("participation key %s not found", partKeyID)
+	}
+	delete(c.partKeys, partKeyID)
+	return nil
+}
+
+func (c *Chain) SimulateATC(_ context.Context, atc *transaction.AtomicTransactionComposer, _ models.SimulateRequest) (transaction.SimulateResult, error) {
+	group, err := signedGroup(atc)
+	if err != nil {
+		return transaction.SimulateResult{}, err
+	}
+	var result transaction.SimulateResult
+	groupResult := models.SimulateTransactionGroupResult{}
+	txnInfos, methodResults, err := c.processGroup(group, true)
+	if err != nil {
+		groupResult.FailureMessage = err.Error()
+	}
+	for _, info := range txnInfos {
+		groupResult.TxnResults = append(groupResult.TxnResults, models.SimulateTransactionResult{TxnResult: models.PendingTransactionResponse(info)})
+	}
+	result.SimulateResponse.TxnGroups = []models.SimulateTransactionGroupResult{groupResult}
+	result.SimulateResponse.LastRound = c.Round()
+	result.MethodResults = methodResults
+	return result, nil
+}
+
+func (c *Chain) ExecuteATC(_ context.Context, atc *transaction.AtomicTransactionComposer, _ uint64) (transaction.ExecuteResult, error) {
+	group, err := signedGroup(atc)
+	if err != nil {
+		return transaction.ExecuteResult{}, err
+	}
+	txnInfos, methodResults, err := c.processGroup(group, false)
+	if err != nil {
+		return transaction.ExecuteResult{}, fmt.Errorf("transaction rejected: %w", err)
+	}
+	result := transaction.ExecuteResult{MethodResults: methodResults}
+	for i, txn := range group {
+		result.TxIDs = append(result.TxIDs, crypto.GetTxID(txn))
+		result.ConfirmedRound = txnInfos[i].ConfirmedRound
+	}
+	return result, nil
+}
+
+func (c *Chain) SendRawTransaction(_ context.Context, txns []byte) (string, error) {
+	var group []types.Transaction
+	dec := msgpack.NewDecoder(bytes.NewReader(txns))
+	for {
+		var stxn types.SignedTxn
+		if err := dec.Decode(&stxn); err != nil {
+			break
+		}
+		group = append(group, stxn.Txn)
+	}
+	if len(group) == 0 {
+		return "", errors.New("no transactions in request")
+	}
+	if _, _, err := c.processGroup(group, false); err != nil {
+		return "", fmt.Errorf("transaction rejected: %w", err)
+	}
+	return crypto.GetTxID(group[0]), nil
+}
+
+func (c *Chain) WaitForConfirmation(_ context.Context, txid string, _ uint64) (models.PendingTransactionInfoResponse, error) {
+	c.Lock()
+	defer c.Unlock()
+	info, found := c.confirmed[txid]
+	if !found {
+		return models.PendingTransactionInfoResponse{}, fmt.Errorf("transaction %s not found", txid)
+	}
+	return info, nil
+}
+
+// processGroup runs every transaction in the group - applying payments and fees and dispatching ABI method
+// calls to their handlers.  When simulating, no ledger state is changed.  Each executed group is committed in
+// its own new round.
+func (c *Chain) processGroup(group []types.Transaction, simulate bool) ([]models.PendingTransactionInfoResponse, []transaction.ABIMethodResult, error) {
+	var (
+		infos         = make([]models.PendingTransactionInfoResponse, len(group))
+		methodResults []transaction.ABIMethodResult
+		debits        = map[string]uint64{}
+		credits       = map[string]uint64{}
+	)
+	for i, txn := range group {
+		sender := txn.Sender.String()
+		debits[sender] += uint64(txn.Fee)
+		if txn.Type == types.PaymentTx {
+			debits[sender] += uint64(txn.Amount)
+			credits[txn.Receiver.String()] += uint64(txn.Amount)
+		}
+		if txn.Type != types.ApplicationCallTx || len(txn.ApplicationArgs) == 0 {
+			continue
+		}
+		c.Lock()
+		entry, found := c.methods[string(txn.ApplicationArgs[0])]
+		c.Unlock()
+		if !found {
+			continue
+		}
+		call := MethodCall{
+			AppID:    uint64(txn.ApplicationID),
+			Sender:   txn.Sender,
+			Method:   entry.method,
+			Txn:      txn,
+			Group:    group,
+			Simulate: simulate,
+		}
+		args, err := decodeMethodArgs(entry.method, txn.ApplicationArgs[1:])
+		if err != nil {
+			return infos, nil, fmt.Errorf("txn %d: %w", i, err)
+		}
+		call.Args = args
+
+		var retVal any
+		if entry.handler != nil {
+			retVal, err = entry.handler(c, call)
+			if err != nil {
+				return infos, nil, fmt.Errorf("txn %d, method %s: %w", i, entry.method.Name, err)
+			}
+		}
+		result := transaction.ABIMethodResult{TxID: crypto.GetTxID(txn), Method: entry.method}
+		if !entry.method.Returns.IsVoid() {
+			returnType, err := entry.method.Returns.GetTypeObject()
+			if err != nil {
+				return infos, nil, err
+			}
+			if retVal == nil {
+				return infos, nil, fmt.Errorf("method %s has no handler to return a value", entry.method.Name)
+			}
+			raw, err := returnType.Encode(retVal)
+			if err != nil {
+				return infos, nil, fmt.Errorf("unable to encode return value of %s: %w", entry.method.Name, err)
+			}
+			infos[i].Logs = append(infos[i].Logs, append(bytes.Clone(abiReturnPrefix), raw...))
+			result.RawReturnValue = raw
+			result.ReturnValue, result.DecodeError = returnType.Decode(raw)
+		} else {
+			result.RawReturnValue = []byte{}
+		}
+		methodResults = append(methodResults, result)
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	for address, amount := range debits {
+		if c.account(address).Amount+credits[address] < amount {
+			return infos, nil, fmt.Errorf("overspend: account %s balance %d below %d", address, c.account(address).Amount, amount)
+		}
+	}
+	if simulate {
+		return infos, methodResults, nil
+	}
+	c.advanceTo(c.round + 1)
+	for address, amount := range credits {
+		c.account(address).Amount += amount
+	}
+	for address, amount := range debits {
+		c.account(address).Amount -= amount
+	}
+	for i, txn := range group {
+		infos[i].ConfirmedRound = c.round
+		infos[i].Transaction = types.SignedTxn{Txn: txn}
+		c.confirmed[crypto.GetTxID(txn)] = infos[i]
+	}
+	for i := range methodResults {
+		methodResults[i].TransactionInfo = c.confirmed[methodResults[i].TxID]
+	}
+	return infos, methodResults, nil
+}
+
+func decodeMethodArgs(method abi.Method, rawArgs [][]byte) ([]any, error) {
+	var (
+		args   []any
+		argIdx int
+	)
+	for _, arg := range method.Args {
+		if abi.IsTransactionType(arg.Type) {
+			continue
+		}
+		typeStr := arg.Type
+		if abi.IsReferenceType(typeStr) {
+			typeStr = "uint8"
+		}
+		if argIdx >= len(rawArgs) {
+			return nil, fmt.Errorf("method %s missing argument %d", method.Name, argIdx)
+		}
+		argType, err := abi.TypeOf(typeStr)
+		if err != nil {
+			return nil, err
+		}
+		value, err := argType.Decode(rawArgs[argIdx])
+		if err != nil {
+			return nil, fmt.Errorf("method %s, unable to decode argument %d: %w", method.Name, argIdx, err)
+		}
+		if values, ok := value.([]any); ok && strings.HasPrefix(typeStr, "byte[") {
+			// byte arrays decode as []any of each byte - handlers get them as []byte, like addresses
+			value = byteArray(values)
+		}
+		args = append(args, value)
+		argIdx++
+	}
+	return args, nil
+}
+
+// signedGroup gathers the signatures for the composed group (so signer failures surface just as against a real
+// node) and returns the final transactions.
+func signedGroup(atc *transaction.AtomicTransactionComposer) ([]types.Transaction, error) {
+	stxs, err := atc.GatherSignatures()
+	if err != nil {
+		return nil, err
+	}
+	var group []types.Transaction
+	for _, stx := range stxs {
+		var stxn types.SignedTxn
+		if err := msgpack.Decode(stx, &stxn); err != nil {
+			return nil, err
+		}
+		group = append(group, stxn.Txn)
+	}
+	return group, nil
+}
+
+func byteArray(values []any) []byte {
+	b := make([]byte, len(values))
+	for i, value := range values {
+		b[i] = value.(byte)
+	}
+	return b
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return b
+}
+
+var _ algo.Chain = (*Chain)(nil)
@@ -0,0 +1,249 @@
+package fakechain_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/algorand/go-algorand-sdk/v2/abi"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/algorandfoundation/reti/internal/lib/algo/fakechain"
+)
+
+func paymentATC(t *testing.T, chain *fakechain.Chain, from crypto.Account, to types.Address, amount uint64) *transaction.AtomicTransactionComposer {
+	t.Helper()
+	params, err := chain.SuggestedParams(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	params.FlatFee, params.Fee = true, fakechain.DefaultMinFee
+	txn, err := transaction.MakePaymentTxn(from.Address.String(), to.String(), amount, nil, "", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	atc := &transaction.AtomicTransactionComposer{}
+	if err := atc.AddTransaction(transaction.TransactionWithSigner{Txn: txn, Signer: transaction.BasicAccountTransactionSigner{Account: from}}); err != nil {
+		t.Fatal(err)
+	}
+	return atc
+}
+
+func methodATC(t *testing.T, chain *fakechain.Chain, from crypto.Account, appID uint64, method abi.Method, args ...any) *transaction.AtomicTransactionComposer {
+	t.Helper()
+	params, err := chain.SuggestedParams(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	params.FlatFee, params.Fee = true, fakechain.DefaultMinFee
+	atc := &transaction.AtomicTransactionComposer{}
+	err = atc.AddMethodCall(transaction.AddMethodCallParams{
+		AppID:           appID,
+		Method:          method,
+		MethodArgs:      args,
+		Sender:          from.Address,
+		SuggestedParams: params,
+		Signer:          transaction.BasicAccountTransactionSigner{Account: from},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return atc
+}
+
+func TestPayments(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		chain    = fakechain.New(100)
+		sender   = crypto.GenerateAccount()
+		receiver = crypto.GenerateAccount()
+	)
+	chain.Fund(sender.Address.String(), 10_000_000)
+
+	// simulating changes nothing
+	if _, err := chain.SimulateATC(ctx, paymentATC(t, chain, sender, receiver.Address, 1_000_000), models.SimulateRequest{}); err != nil {
+		t.Fatalf("SimulateATC: %v", err)
+	}
+	if chain.Round() != 100 || chain.GetAccount(receiver.Address.String()).Amount != 0 {
+		t.Fatal("simulate changed the ledger")
+	}
+
+	result, err := chain.ExecuteATC(ctx, paymentATC(t, chain, sender, receiver.Address, 1_000_000), 4)
+	if err != nil {
+		t.Fatalf("ExecuteATC: %v", err)
+	}
+	if result.ConfirmedRound != 101 || chain.Round() != 101 {
+		t.Fatalf("expected the group confirmed in round 101, got %d (chain at %d)", result.ConfirmedRound, chain.Round())
+	}
+	if balance := chain.GetAccount(sender.Address.String()).Amount; balance != 10_000_000-1_000_000-fakechain.DefaultMinFee {
+		t.Fatalf("sender balance %d after paying 1 ALGO plus fee", balance)
+	}
+	if balance := chain.GetAccount(receiver.Address.String()).Amount; balance != 1_000_000 {
+		t.Fatalf("receiver balance %d after being paid 1 ALGO", balance)
+	}
+	if info, err := chain.WaitForConfirmation(ctx, result.TxIDs[0], 4); err != nil || info.ConfirmedRound != 101 {
+		t.Fatalf("WaitForConfirmation returned round %d, err:%v", info.ConfirmedRound, err)
+	}
+
+	// an overspend is rejected without changing anything
+	if _, err := chain.ExecuteATC(ctx, paymentATC(t, chain, receiver, sender.Address, 5_000_000), 4); err == nil {
+		t.Fatal("overspend wasn't rejected")
+	}
+	if chain.Round() != 101 || chain.GetAccount(receiver.Address.String()).Amount != 1_000_000 {
+		t.Fatal("rejected group changed the ledger")
+	}
+}
+
+func TestMethodHandlers(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		chain  = fakechain.New(100)
+		sender = crypto.GenerateAccount()
+	)
+	chain.Fund(sender.Address.String(), 10_000_000)
+	add, err := abi.MethodFromSignature("add(uint64,uint64)uint64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	setKey, err := abi.MethodFromSignature("setKey(byte[32],address)void")
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain.HandleMethod(add, func(c *fakechain.Chain, call fakechain.MethodCall) (any, error) {
+		return call.Args[0].(uint64) + call.Args[1].(uint64), nil
+	})
+	var handledKey []byte
+	chain.HandleMethod(setKey, func(c *fakechain.Chain, call fakechain.MethodCall) (any, error) {
+		key, ok := call.Args[0].([]byte)
+		if !ok {
+			return nil, errors.New("byte[32] argument isn't a []byte")
+		}
+		if !call.Simulate {
+			handledKey = key
+		}
+		return nil, nil
+	})
+
+	result, err := chain.SimulateATC(ctx, methodATC(t, chain, sender, 1234, add, uint64(2), uint64(3)), models.SimulateRequest{})
+	if err != nil || result.SimulateResponse.TxnGroups[0].FailureMessage != "" {
+		t.Fatalf("SimulateATC: %v %s", err, result.SimulateResponse.TxnGroups[0].FailureMessage)
+	}
+	if sum := result.MethodResults[0].ReturnValue; sum != uint64(5) {
+		t.Fatalf("add returned %v", sum)
+	}
+
+	var key [32]byte
+	key[0], key[31] = 1, 2
+	if _, err := chain.ExecuteATC(ctx, methodATC(t, chain, sender, 1234, setKey, key, sender.Address), 4); err != nil {
+		t.Fatalf("ExecuteATC: %v", err)
+	}
+	if len(handledKey) != 32 || handledKey[0] != 1 || handledKey[31] != 2 {
+		t.Fatalf("handler got key %v", handledKey)
+	}
+
+	// a handler error fails the group
+	chain.HandleMethod(add, func(c *fakechain.Chain, call fakechain.MethodCall) (any, error) {
+		return nil, errors.New("overflow")
+	})
+	if _, err := chain.ExecuteATC(ctx, methodATC(t, chain, sender, 1234, add, uint64(2), uint64(3)), 4); err == nil {
+		t.Fatal("a failing handler didn't fail the group")
+	}
+}
+
+func TestApplicationState(t *testing.T) {
+	ctx := context.Background()
+	chain := fakechain.New(100)
+	chain.SetGlobalUint(1234, "count", 7)
+	chain.SetGlobalBytes(1234, "name", []byte("pool"))
+	chain.SetBox(1234, []byte("box"), []byte{1, 2, 3})
+
+	app, err := chain.ApplicationByID(ctx, 1234)
+	if err != nil {
+		t.Fatalf("ApplicationByID: %v", err)
+	}
+	state := map[string]any{}
+	for _, kv := range app.Params.GlobalState {
+		key, _ := base64.StdEncoding.DecodeString(kv.Key)
+		if kv.Value.Type == 1 {
+			value, _ := base64.StdEncoding.DecodeString(kv.Value.Bytes)
+			state[string(key)] = string(value)
+		} else {
+			state[string(key)] = kv.Value.Uint
+		}
+	}
+	if state["count"] != uint64(7) || state["name"] != "pool" {
+		t.Fatalf("unexpected global state %v", state)
+	}
+	box, err := chain.ApplicationBoxByName(ctx, 1234, []byte("box"))
+	if err != nil || string(box.Value) != string([]byte{1, 2, 3}) {
+		t.Fatalf("ApplicationBoxByName returned %v, err:%v", box.Value, err)
+	}
+	if _, err := chain.ApplicationBoxByName(ctx, 1234, []byte("missing")); err == nil {
+		t.Fatal("missing box was found")
+	}
+
+	chain.DeleteApplication(1234)
+	if _, err := chain.ApplicationByID(ctx, 1234); err == nil {
+		t.Fatal("deleted application was found")
+	}
+}
+
+func TestParticipation(t *testing.T) {
+	ctx := context.Background()
+	chain := fakechain.New(100)
+	account := crypto.GenerateAccount().Address.String()
+
+	if err := chain.GenerateParticipationKey(ctx, account, 200, 100, 0); err == nil {
+		t.Fatal("key with an invalid validity range was generated")
+	}
+	if err := chain.GenerateParticipationKey(ctx, account, 100, 150, 0); err != nil {
+		t.Fatalf("GenerateParticipationKey: %v", err)
+	}
+	keys, err := chain.ParticipationKeys(ctx)
+	if err != nil || len(keys) != 1 || keys[0].Address != account {
+		t.Fatalf("ParticipationKeys returned %+v, err:%v", keys, err)
+	}
+	key := keys[0].Key
+	chain.GoOnline(account, key.VoteParticipationKey, key.SelectionParticipationKey, key.StateProofKey, key.VoteFirstValid, key.VoteLastValid, key.VoteKeyDilution)
+	if info, _ := chain.AccountInformation(ctx, account, true); info.Status != "Online" {
+		t.Fatalf("account status %s after going online", info.Status)
+	}
+
+	// the account drops offline once its key expires
+	chain.AdvanceRounds(50)
+	if !chain.GetAccount(account).Online {
+		t.Fatal("account went offline while its key was still valid")
+	}
+	chain.AdvanceRounds(1)
+	if chain.GetAccount(account).Online {
+		t.Fatal("account stayed online past its key's last valid round")
+	}
+
+	if err := chain.DeleteParticipationKey(ctx, keys[0].Id); err != nil {
+		t.Fatalf("DeleteParticipationKey: %v", err)
+	}
+	if err := chain.DeleteParticipationKey(ctx, keys[0].Id); err == nil {
+		t.Fatal("deleting a missing key succeeded")
+	}
+}
+
+func TestStatusAfterBlock(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	chain := fakechain.New(100)
+	status, err := chain.StatusAfterBlock(ctx, 120)
+	if err != nil || status.LastRound != 121 {
+		t.Fatalf("StatusAfterBlock returned round %d, err:%v", status.LastRound, err)
+	}
+	// waiting for a round already past doesn't move the chain
+	if status, _ := chain.StatusAfterBlock(ctx, 50); status.LastRound != 121 {
+		t.Fatalf("chain moved to %d waiting for a past round", status.LastRound)
+	}
+	cancel()
+	if _, err := chain.StatusAfterBlock(ctx, 200); err == nil {
+		t.Fatal("StatusAfterBlock ignored the cancelled context")
+	}
+}
@@ -6,9 +6,6 @@ import (
 	"log/slog"
 	"time"
 
-	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
-	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
-
 	"github.com/algorandfoundation/reti/internal/lib/misc"
 )
 
@@ -31,10 +28,8 @@ type ParticipationKey struct {
 
 type PartKeysByAddress map[string][]ParticipationKey
 
-func GetParticipationKeys(ctx context.Context, algoClient *algod.Client) (PartKeysByAddress, error) {
-	var response []ParticipationKey
-
-	err := (*common.Client)(algoClient).Get(ctx, &response, fmt.Sprintf("/v2/participation"), nil, nil)
+func GetParticipationKeys(ctx context.Context, chain Chain) (PartKeysByAddress, error) {
+	response, err := chain.ParticipationKeys(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get participation keys")
 	}
@@ -48,7 +43,7 @@ func GetParticipationKeys(ctx context.Context, algoClient *algod.Client) (PartKe
 
 type GenerateParticipationKeysParams struct {
 	// Dilution Key dilution for two-level participation keys (defaults to sqrt of validity window).
-	//Dilution *uint64 `form:"dilution,omitempty" json:"dilution,omitempty"`
+	Dilution uint64 `form:"dilution,omitempty" url:"dilution,omitempty" json:"dilution,omitempty"`
 
 	// First First round for participation key.
 	First uint64 `form:"first" url:"first" json:"first"`
@@ -62,15 +57,10 @@ type GenerateParticipationKeysParams struct {
 // After the request is sent, it polls the node every 10 seconds to check if the key has been generated.
 // If the key is successfully generated, it returns the participation key.
 // If the key is not generated within 30 minutes, it returns an error.
-func GenerateParticipationKey(ctx context.Context, algoClient *algod.Client, logger *slog.Logger, account string, firstValid, lastValid uint64) (*ParticipationKey, error) {
-	var response struct{}
-	var params = GenerateParticipationKeysParams{
-		First: firstValid,
-		Last:  lastValid,
-	}
-
+// A dilution of 0 leaves it to algod (the square root of the validity window).
+func GenerateParticipationKey(ctx context.Context, chain Chain, logger *slog.Logger, account string, firstValid, lastValid, dilution uint64) (*ParticipationKey, error) {
 	misc.Infof(logger, "generating part key for account:%s, first/last valid of %d - %d", account, firstValid, lastValid)
-	err := (*common.Client)(algoClient).Post(ctx, &response, fmt.Sprintf("/v2/participation/generate/%s", account), params, nil, nil)
+	err := chain.GenerateParticipationKey(ctx, account, firstValid, lastValid, dilution)
 	if err != nil {
 		return nil, fmt.Errorf("error generating participation key for account:%s, err:%w", account, err)
 	}
@@ -81,7 +71,7 @@ func GenerateParticipationKey(ctx context.Context, algoClient *algod.Client, log
 			return nil, context.Canceled
 		case <-time.After(10 * time.Second):
 			// poll every 10 seconds checking to see if key has been generated
-			partKeys, err := GetParticipationKeys(ctx, algoClient)
+			partKeys, err := GetParticipationKeys(ctx, chain)
 			if err != nil {
 				return nil, fmt.Errorf("unable to get part keys as part of polling after key generation request, err:%w", err)
 			}
@@ -101,10 +91,9 @@ func GenerateParticipationKey(ctx context.Context, algoClient *algod.Client, log
 	}
 }
 
-func DeleteParticipationKey(ctx context.Context, algoClient *algod.Client, logger *slog.Logger, partKeyID string) error {
-	var response string
+func DeleteParticipationKey(ctx context.Context, chain Chain, logger *slog.Logger, partKeyID string) error {
 	misc.Infof(logger, "delete part key id:%s", partKeyID)
-	err := (*common.Client)(algoClient).Delete(ctx, &response, fmt.Sprintf("/v2/participation/%s", partKeyID), nil, nil)
+	err := chain.DeleteParticipationKey(ctx, partKeyID)
 	if err != nil {
 		return fmt.Errorf("error delete participation key for id:%s, err:%w", partKeyID, err)
 	}
@@ -0,0 +1,222 @@
+package algo
+
+import (
+	"crypto/cipher"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/mnemonic"
+
+	"github.com/algorandfoundation/reti/internal/lib/misc"
+)
+
+const (
+	keystoreVersion = 1
+	// keystoreCheck is encrypted into every keystore so the passphrase can be verified even if it has no accounts
+	keystoreCheck = "reti keystore"
+)
+
+// Keystore is an encrypted file of account keys.  A key is derived from the passphrase (scrypt) and used to encrypt
+// each account's private key (XChaCha20-Poly1305).  Account names and addresses are stored in the clear so accounts
+// can be listed without unlocking.
+type Keystore struct {
+	filename string
+	file     keystoreFile
+	// aead is set once unlocked
+	aead cipher.AEAD
+}
+
+type keystoreFile struct {
+	Version int         `json:"version"`
+	KDF     keystoreKDF `json:"kdf"`
+	// Check is keystoreCheck, encrypted
+	Check    []byte            `json:"check"`
+	Accounts []KeystoreAccount `json:"accounts"`
+}
+
+type keystoreKDF struct {
+	Name string `json:"name"`
+	misc.ScryptParams
+}
+
+// KeystoreAccount is a single account in a keystore - the private key encrypted with the address as additional data
+type KeystoreAccount struct {
+	Name         string `json:"name"`
+	Address      string `json:"address"`
+	EncryptedKey []byte `json:"encryptedKey"`
+}
+
+// CreateKeystore creates a new, empty, keystore file protected by the passphrase.  It fails if the file exists.
+func CreateKeystore(filename string, passphrase []byte) (*Keystore, error) {
+	if _, err := os.Stat(filename); err == nil {
+		return nil, fmt.Errorf("keystore %s already exists", filename)
+	}
+	params, err := misc.NewScryptParams()
+	if err != nil {
+		return nil, err
+	}
+	ks := &Keystore{
+		filename: filename,
+		file: keystoreFile{
+			Version: keystoreVersion,
+			KDF:     keystoreKDF{Name: "scrypt", ScryptParams: params},
+		},
+	}
+	if err := ks.deriveKey(passphrase); err != nil {
+		return nil, err
+	}
+	ks.file.Check = ks.seal([]byte(keystoreCheck), nil)
+	return ks, ks.Save()
+}
+
+// OpenKeystore reads a keystore file - it has to be unlocked before keys can be added or used
+func OpenKeystore(filename string) (*Keystore, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	ks := &Keystore{filename: filename}
+	if err := json.Unmarshal(data, &ks.file); err != nil {
+		return nil, fmt.Errorf("invalid keystore %s: %w", filename, err)
+	}
+	if ks.file.Version != keystoreVersion || ks.file.KDF.Name != "scrypt" {
+		return nil, fmt.Errorf("unsupported keystore %s, version:%d, kdf:%s", filename, ks.file.Version, ks.file.KDF.Name)
+	}
+	if err := ks.file.KDF.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid keystore %s: %w", filename, err)
+	}
+	return ks, nil
+}
+
+func (ks *Keystore) Filename() string {
+	return ks.filename
+}
+
+// Unlock derives the keystore key from the passphrase, verifying it's correct
+func (ks *Keystore) Unlock(passphrase []byte) error {
+	if err := ks.deriveKey(passphrase); err != nil {
+		return err
+	}
+	if _, err := ks.open(ks.file.Check, nil); err != nil {
+		ks.aead = nil
+		return ErrWrongPassphrase
+	}
+	return nil
+}
+
+func (ks *Keystore) deriveKey(passphrase []byte) error {
+	aead, err := ks.file.KDF.DeriveAEAD(passphrase)
+	if err != nil {
+		return fmt.Errorf("unable to derive keystore key: %w", err)
+	}
+	ks.aead = aead
+	return nil
+}
+
+// seal encrypts plaintext, returning the random nonce followed by the ciphertext
+func (ks *Keystore) seal(plaintext []byte, additionalData []byte) []byte {
+	sealed, err := misc.Seal(ks.aead, plaintext, additionalData)
+	if err != nil {
+		panic(err)
+	}
+	return sealed
+}
+
+func (ks *Keystore) open(sealed []byte, additionalData []byte) ([]byte, error) {
+	return misc.Open(ks.aead, sealed, additionalData)
+}
+
+// Accounts returns the accounts in the keystore - available without unlocking
+func (ks *Keystore) Accounts() []KeystoreAccount {
+	return ks.file.Accounts
+}
+
+// Keys decrypts and returns the private keys of every account, keyed by address
+func (ks *Keystore) Keys() (map[string]ed25519.PrivateKey, error) {
+	keys := map[string]ed25519.PrivateKey{}
+	for _, account := range ks.file.Accounts {
+		key, err := ks.key(account)
+		if err != nil {
+			return nil, err
+		}
+		keys[account.Address] = key
+	}
+	return keys, nil
+}
+
+func (ks *Keystore) key(account KeystoreAccount) (ed25519.PrivateKey, error) {
+	if ks.aead == nil {
+		return nil, ErrKeystoreLocked
+	}
+	key, err := ks.open(account.EncryptedKey, []byte(account.Address))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt key for account:%s: %w", account.Address, err)
+	}
+	return key, nil
+}
+
+// AddMnemonic adds (or replaces) the account for the mnemonic, returning its address.  Save has to be called to
+// persist the change.
+func (ks *Keystore) AddMnemonic(name string, mnemonicPhrase string) (string, error) {
+	if ks.aead == nil {
+		return "", ErrKeystoreLocked
+	}
+	key, err := mnemonic.ToPrivateKey(mnemonicPhrase)
+	if err != nil {
+		return "", fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	account, err := crypto.AccountFromPrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	address := account.Address.String()
+	ks.file.Accounts = slices.DeleteFunc(ks.file.Accounts, func(a KeystoreAccount) bool { return a.Address == address })
+	ks.file.Accounts = append(ks.file.Accounts, KeystoreAccount{
+		Name:         name,
+		Address:      address,
+		EncryptedKey: ks.seal(key, []byte(address)),
+	})
+	return address, nil
+}
+
+// Remove removes the account from the keystore.  Save has to be called to persist the change.
+func (ks *Keystore) Remove(address string) error {
+	numAccounts := len(ks.file.Accounts)
+	ks.file.Accounts = slices.DeleteFunc(ks.file.Accounts, func(a KeystoreAccount) bool { return a.Address == address })
+	if len(ks.file.Accounts) == numAccounts {
+		return fmt.Errorf("%w: %s", ErrKeystoreNoAccount, address)
+	}
+	return nil
+}
+
+// ExportMnemonic returns the mnemonic of the account
+func (ks *Keystore) ExportMnemonic(address string) (string, error) {
+	idx := slices.IndexFunc(ks.file.Accounts, func(a KeystoreAccount) bool { return a.Address == address })
+	if idx == -1 {
+		return "", fmt.Errorf("%w: %s", ErrKeystoreNoAccount, address)
+	}
+	key, err := ks.key(ks.file.Accounts[idx])
+	if err != nil {
+		return "", err
+	}
+	return mnemonic.FromPrivateKey(key)
+}
+
+// Save writes the keystore, readable only by the current user.  The file is replaced atomically so a failed write
+// can't lose the existing keys.
+func (ks *Keystore) Save() error {
+	data, err := json.MarshalIndent(ks.file, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmpName := ks.filename + ".tmp"
+	if err := os.WriteFile(tmpName, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, ks.filename)
+}
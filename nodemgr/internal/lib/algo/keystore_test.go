@@ -0,0 +1,133 @@
+package algo
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/mnemonic"
+)
+
+func TestKeystoreRoundTrip(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "keystore.json")
+	passphrase := []byte("correct horse battery staple")
+
+	ks, err := CreateKeystore(filename, passphrase)
+	if err != nil {
+		t.Fatalf("CreateKeystore: %v", err)
+	}
+	if _, err := CreateKeystore(filename, passphrase); err == nil {
+		t.Fatal("CreateKeystore over an existing keystore should fail")
+	}
+
+	account := crypto.GenerateAccount()
+	accountMnemonic, err := mnemonic.FromPrivateKey(account.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	address, err := ks.AddMnemonic("manager", accountMnemonic)
+	if err != nil {
+		t.Fatalf("AddMnemonic: %v", err)
+	}
+	if address != account.Address.String() {
+		t.Fatalf("AddMnemonic returned address %s, expected %s", address, account.Address)
+	}
+	if err := ks.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), accountMnemonic) {
+		t.Fatal("keystore file contains the mnemonic in the clear")
+	}
+
+	reopened, err := OpenKeystore(filename)
+	if err != nil {
+		t.Fatalf("OpenKeystore: %v", err)
+	}
+	if accounts := reopened.Accounts(); len(accounts) != 1 || accounts[0].Name != "manager" || accounts[0].Address != address {
+		t.Fatalf("unexpected accounts before unlocking: %+v", accounts)
+	}
+	if _, err := reopened.Keys(); !errors.Is(err, ErrKeystoreLocked) {
+		t.Fatalf("Keys on a locked keystore returned %v, expected ErrKeystoreLocked", err)
+	}
+	if err := reopened.Unlock([]byte("wrong passphrase")); !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("Unlock with the wrong passphrase returned %v, expected ErrWrongPassphrase", err)
+	}
+	if err := reopened.Unlock(passphrase); err != nil {
+		t.Fatalf("Unlock: %v", err)
+	}
+	keys, err := reopened.Keys()
+	if err != nil {
+		t.Fatalf("Keys: %v", err)
+	}
+	if !keys[address].Equal(account.PrivateKey) {
+		t.Fatal("decrypted key doesn't match the added account")
+	}
+	exported, err := reopened.ExportMnemonic(address)
+	if err != nil {
+		t.Fatalf("ExportMnemonic: %v", err)
+	}
+	if exported != accountMnemonic {
+		t.Fatal("exported mnemonic doesn't match the added account")
+	}
+
+	if err := reopened.Remove(address); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if err := reopened.Remove(address); !errors.Is(err, ErrKeystoreNoAccount) {
+		t.Fatalf("Remove of a missing account returned %v, expected ErrKeystoreNoAccount", err)
+	}
+}
+
+func TestOpenKeystoreRejectsInvalidKDF(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "keystore.json")
+	if _, err := CreateKeystore(filename, []byte("passphrase")); err != nil {
+		t.Fatalf("CreateKeystore: %v", err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		tamper func(kdf *keystoreKDF)
+	}{
+		{"weak N", func(kdf *keystoreKDF) { kdf.N = 2 }},
+		{"huge N", func(kdf *keystoreKDF) { kdf.N = 1 << 30 }},
+		{"N not a power of 2", func(kdf *keystoreKDF) { kdf.N = 1<<15 + 1 }},
+		{"zero r", func(kdf *keystoreKDF) { kdf.R = 0 }},
+		{"huge r", func(kdf *keystoreKDF) { kdf.R = 1 << 20 }},
+		{"zero p", func(kdf *keystoreKDF) { kdf.P = 0 }},
+		{"too much memory", func(kdf *keystoreKDF) { kdf.N, kdf.R = 1<<20, 16 }},
+		{"short salt", func(kdf *keystoreKDF) { kdf.Salt = kdf.Salt[:8] }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var file keystoreFile
+			if err := json.Unmarshal(data, &file); err != nil {
+				t.Fatal(err)
+			}
+			tt.tamper(&file.KDF)
+			tampered, err := json.Marshal(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tamperedName := filepath.Join(t.TempDir(), "keystore.json")
+			if err := os.WriteFile(tamperedName, tampered, 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := OpenKeystore(tamperedName); err == nil {
+				t.Fatal("OpenKeystore accepted tampered scrypt parameters")
+			}
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package algo
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -19,14 +23,50 @@ type NetworkConfig struct {
 	NodeHeaders map[string]string
 
 	RetiAppID uint64
+	// NFDRegistryID is the NFD registry contract - 0 if NFDs aren't deployed on the network
+	NFDRegistryID uint64
+
+	// GenesisID and GenesisHash (base64) identify the network - algod is refused if it doesn't match.  If unset
+	// (ie: localnets, which are recreated) algod is only refused if it's one of the other built-in networks.
+	GenesisID   string
+	GenesisHash string
+}
+
+// BuiltinNetworks are the networks with built-in configs
+var BuiltinNetworks = []string{"mainnet", "testnet", "betanet", "fnet", "sandbox"}
+
+// customNetworks are the networks added via AddNetwork - ie: network profiles from the config file
+var customNetworks = map[string]NetworkConfig{}
+
+// AddNetwork adds a custom network (ie: a private network or localnet) - its config being the defaults for the
+// network, just as for the built-in networks
+func AddNetwork(name string, cfg NetworkConfig) error {
+	if slices.Contains(BuiltinNetworks, name) {
+		return fmt.Errorf("network:%s is a built-in network and can't be redefined", name)
+	}
+	customNetworks[name] = cfg
+	return nil
+}
+
+// IsKnownNetwork returns true if network is a built-in network or one added via AddNetwork
+func IsKnownNetwork(network string) bool {
+	_, found := customNetworks[network]
+	return found || slices.Contains(BuiltinNetworks, network)
 }
 
+// String describes the config without any secret values - only the length of the token and the header names
 func (n NetworkConfig) String() string {
-	return fmt.Sprintf("NodeDataDir: %s, NFDAPIUrl: %s, NodeURL: %s, NodeToken: (length:%d), NodeHeaders: %v, RetiAppID: %d", n.NodeDataDir, n.NFDAPIUrl, n.NodeURL, len(n.NodeToken), n.NodeHeaders, n.RetiAppID)
+	var headerNames []string
+	for name := range n.NodeHeaders {
+		headerNames = append(headerNames, name)
+	}
+	slices.Sort(headerNames)
+	return fmt.Sprintf("NodeDataDir: %s, NFDAPIUrl: %s, NodeURL: %s, NodeToken: (length:%d), NodeHeaders: %v, RetiAppID: %d, NFDRegistryID: %d, GenesisID: %s, GenesisHash: %s",
+		n.NodeDataDir, n.NFDAPIUrl, n.NodeURL, len(n.NodeToken), headerNames, n.RetiAppID, n.NFDRegistryID, n.GenesisID, n.GenesisHash)
 }
 
 func GetNetworkConfig(network string) NetworkConfig {
-	cfg := getDefaults(network)
+	cfg := GetNetworkDefaults(network)
 
 	nodeDataDir := os.Getenv("ALGORAND_DATA")
 	if nodeDataDir != "" {
@@ -71,34 +111,83 @@ func GetNetworkConfig(network string) NetworkConfig {
 	return cfg
 }
 
-func getDefaults(network string) NetworkConfig {
+// GetNetworkDefaults returns the built-in (or AddNetwork) config of the network - without any env overrides
+func GetNetworkDefaults(network string) NetworkConfig {
+	if cfg, found := customNetworks[network]; found {
+		return cfg
+	}
 	cfg := NetworkConfig{}
 	switch network {
 	case "mainnet":
 		cfg.RetiAppID = 2714516089
+		cfg.NFDRegistryID = 760937186
 		cfg.NFDAPIUrl = "https://api.nf.domains"
 		cfg.NodeURL = "https://mainnet-api.4160.nodely.dev"
+		cfg.GenesisID = "mainnet-v1.0"
+		cfg.GenesisHash = "wGHE2Pwdvd7S12BL5FaOP20EGYesN73ktiC1qzkkit8="
 	case "testnet":
 		cfg.RetiAppID = 734834614 // 4.0 algod avm11 (deployed 02/28/2025)
+		cfg.NFDRegistryID = 84366825
 		cfg.NFDAPIUrl = "https://api.testnet.nf.domains"
 		cfg.NodeURL = "https://testnet-api.4160.nodely.dev"
+		cfg.GenesisID = "testnet-v1.0"
+		cfg.GenesisHash = "SGO1GKSzyE7IEPItTxCByw9x8FmnrCDexi9/cOUJOiI="
 	case "betanet":
 		cfg.RetiAppID = 2020356933 // 4.0 algod avm11
+		cfg.NFDRegistryID = 842656530
 		cfg.NFDAPIUrl = "https://api.betanet.nf.domains"
 		cfg.NodeURL = "https://betanet-api.4160.nodely.dev"
+		cfg.GenesisID = "betanet-v1.0"
+		cfg.GenesisHash = "mFgazF+2uRS1tMiL9dsj01hJGySEmPN28B/TjjvpVW0="
 	case "fnet":
 		cfg.RetiAppID = 639070 // 4.0 algod avm11
+		cfg.NFDRegistryID = 0  // nfds aren't deployed on fnet
 		cfg.NFDAPIUrl = "https://api.betanet.nf.domains"
 		cfg.NodeURL = "https://fnet-api.4160.nodely.dev"
+		// no genesis - fnet is periodically reset
 	case "sandbox":
 		cfg.RetiAppID = 0 // should come from .env.sandbox !!
 		cfg.NFDAPIUrl = "https://api.testnet.nf.domains"
 		cfg.NodeURL = "http://localhost:4001"
 		cfg.NodeToken = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+		// no nfd registry or genesis - localnets are recreated
 	}
 	return cfg
 }
 
+// VerifyGenesis refuses an algod node which isn't on the network - so a testnet config pointed at a mainnet node
+// (for example) can't sign and send transactions to the wrong network.
+func VerifyGenesis(ctx context.Context, chain Chain, network string, cfg NetworkConfig) error {
+	params, err := chain.SuggestedParams(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to fetch genesis of algod node: %w", err)
+	}
+	genesisHash := base64.StdEncoding.EncodeToString(params.GenesisHash)
+	if cfg.GenesisID == "" && cfg.GenesisHash == "" {
+		// nothing to match against - but it mustn't be one of the other (known) networks
+		for _, other := range BuiltinNetworks {
+			otherCfg := GetNetworkDefaults(other)
+			if other != network && otherCfg.GenesisHash != "" && otherCfg.GenesisHash == genesisHash {
+				return fmt.Errorf("%w: it is on %s, not %s", ErrGenesisMismatch, other, network)
+			}
+		}
+		return nil
+	}
+	if cfg.GenesisID != "" && cfg.GenesisID != params.GenesisID {
+		return fmt.Errorf("%w: its genesis id is %s, %s is %s", ErrGenesisMismatch, params.GenesisID, network, cfg.GenesisID)
+	}
+	if cfg.GenesisHash != "" {
+		expectedHash, err := base64.StdEncoding.DecodeString(cfg.GenesisHash)
+		if err != nil {
+			return fmt.Errorf("invalid genesis hash:%s for network:%s: %w", cfg.GenesisHash, network, err)
+		}
+		if !bytes.Equal(expectedHash, params.GenesisHash) {
+			return fmt.Errorf("%w: its genesis hash is %s, %s is %s", ErrGenesisMismatch, genesisHash, network, cfg.GenesisHash)
+		}
+	}
+	return nil
+}
+
 // GetNetAndTokenFromFiles reads the address and token from files in the local Algorand data directory.
 // It takes two parameters: netFile (file path of the address file) and tokenFile (file path of the token file).
 // It returns apiURL (the API URL), apiToken (the API token), and an error (if any).
@@ -0,0 +1,71 @@
+package algo_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/algorandfoundation/reti/internal/lib/algo"
+	"github.com/algorandfoundation/reti/internal/lib/algo/fakechain"
+)
+
+// genesisChain is a fake chain on the network of the genesis id and hash
+type genesisChain struct {
+	*fakechain.Chain
+	genesisID   string
+	genesisHash string
+	err         error
+}
+
+func (g *genesisChain) SuggestedParams(ctx context.Context) (types.SuggestedParams, error) {
+	if g.err != nil {
+		return types.SuggestedParams{}, g.err
+	}
+	params, err := g.Chain.SuggestedParams(ctx)
+	params.GenesisID = g.genesisID
+	params.GenesisHash, _ = base64.StdEncoding.DecodeString(g.genesisHash)
+	return params, err
+}
+
+func TestVerifyGenesis(t *testing.T) {
+	mainnet, testnet := algo.GetNetworkDefaults("mainnet"), algo.GetNetworkDefaults("testnet")
+	tests := []struct {
+		name     string
+		chain    *genesisChain
+		network  string
+		cfg      algo.NetworkConfig
+		mismatch bool
+	}{
+		{name: "same network", chain: &genesisChain{genesisID: mainnet.GenesisID, genesisHash: mainnet.GenesisHash}, network: "mainnet", cfg: mainnet},
+		{name: "other network", chain: &genesisChain{genesisID: mainnet.GenesisID, genesisHash: mainnet.GenesisHash}, network: "testnet", cfg: testnet, mismatch: true},
+		{name: "same id, other hash", chain: &genesisChain{genesisID: testnet.GenesisID, genesisHash: mainnet.GenesisHash}, network: "testnet", cfg: testnet, mismatch: true},
+		{name: "hash only", chain: &genesisChain{genesisID: "anything", genesisHash: testnet.GenesisHash}, network: "custom", cfg: algo.NetworkConfig{GenesisHash: testnet.GenesisHash}},
+		{name: "no genesis configured", chain: &genesisChain{genesisID: "localnet-v1", genesisHash: base64.StdEncoding.EncodeToString(make([]byte, 32))}, network: "sandbox", cfg: algo.GetNetworkDefaults("sandbox")},
+		{name: "no genesis configured, built-in network", chain: &genesisChain{genesisID: mainnet.GenesisID, genesisHash: mainnet.GenesisHash}, network: "sandbox", cfg: algo.GetNetworkDefaults("sandbox"), mismatch: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.chain.Chain = fakechain.New(100)
+			err := algo.VerifyGenesis(context.Background(), tt.chain, tt.network, tt.cfg)
+			if tt.mismatch != errors.Is(err, algo.ErrGenesisMismatch) || (!tt.mismatch && err != nil) {
+				t.Fatalf("VerifyGenesis returned %v, expected mismatch:%v", err, tt.mismatch)
+			}
+		})
+	}
+
+	t.Run("unreachable", func(t *testing.T) {
+		chain := &genesisChain{Chain: fakechain.New(100), err: errors.New("connection refused")}
+		if err := algo.VerifyGenesis(context.Background(), chain, "mainnet", mainnet); err == nil || errors.Is(err, algo.ErrGenesisMismatch) {
+			t.Fatalf("unreachable node returned %v", err)
+		}
+	})
+	t.Run("invalid configured hash", func(t *testing.T) {
+		chain := &genesisChain{Chain: fakechain.New(100), genesisHash: mainnet.GenesisHash}
+		if err := algo.VerifyGenesis(context.Background(), chain, "custom", algo.NetworkConfig{GenesisHash: "not base64!"}); err == nil || errors.Is(err, algo.ErrGenesisMismatch) {
+			t.Fatalf("invalid genesis hash returned %v", err)
+		}
+	})
+}
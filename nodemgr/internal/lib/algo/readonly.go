@@ -0,0 +1,57 @@
+package algo
+
+import (
+	"context"
+
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// NewReadOnlyChain wraps a Chain so every call that would change the chain or the node - sending transactions and
+// generating or deleting participation keys - returns ErrReadOnly.  Reads (and simulation) go to the wrapped chain.
+func NewReadOnlyChain(chain Chain) Chain {
+	return &readOnlyChain{Chain: chain}
+}
+
+type readOnlyChain struct {
+	Chain
+}
+
+func (r *readOnlyChain) GenerateParticipationKey(ctx context.Context, account string, firstValid, lastValid, dilution uint64) error {
+	return ErrReadOnly
+}
+
+func (r *readOnlyChain) DeleteParticipationKey(ctx context.Context, partKeyID string) error {
+	return ErrReadOnly
+}
+
+func (r *readOnlyChain) ExecuteATC(ctx context.Context, atc *transaction.AtomicTransactionComposer, waitRounds uint64) (transaction.ExecuteResult, error) {
+	return transaction.ExecuteResult{}, ErrReadOnly
+}
+
+func (r *readOnlyChain) SendRawTransaction(ctx context.Context, txns []byte) (string, error) {
+	return "", ErrReadOnly
+}
+
+// NewReadOnlySigner returns a signer without any keys, which refuses to sign with ErrReadOnly
+func NewReadOnlySigner() MultipleWalletSigner {
+	return readOnlySigner{}
+}
+
+type readOnlySigner struct{}
+
+func (readOnlySigner) HasAccount(publicAddress string) bool {
+	return false
+}
+
+func (readOnlySigner) FindFirstSigner(addresses []string) (string, error) {
+	return "", ErrReadOnly
+}
+
+func (readOnlySigner) SignWithAccount(ctx context.Context, tx types.Transaction, publicAddress string) (string, []byte, error) {
+	return "", nil, ErrReadOnly
+}
+
+func (readOnlySigner) SignPartial(ctx context.Context, tx types.Transaction, publicAddress string, partial []byte) ([]byte, bool, error) {
+	return nil, false, ErrReadOnly
+}
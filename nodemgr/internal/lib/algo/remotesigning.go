@@ -0,0 +1,276 @@
+package algo
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/algorandfoundation/reti/internal/lib/misc"
+)
+
+// The remote signing protocol is json POSTed over http (or http over a unix socket) to a signing service holding the
+// keys - see NewSignerHandler.  Every endpoint mirrors a MultipleWalletSigner method.  Requests carry a bearer token
+// if the service requires one (it must when listening on tcp).
+const (
+	signerHasAccountPath  = "/v1/hasaccount"
+	signerFindSignerPath  = "/v1/findsigner"
+	signerSignPath        = "/v1/sign"
+	signerUnixSocketHost  = "signer"
+	signerRequestTimeout  = 30 * time.Second
+	signerMaxRequestBytes = 1 << 20
+)
+
+type signerAccountsRequest struct {
+	Addresses []string `json:"addresses"`
+}
+
+type signerAccountsResponse struct {
+	Found   bool   `json:"found"`
+	Address string `json:"address,omitempty"`
+}
+
+type signerSignRequest struct {
+	Address string `json:"address"`
+	// Txn is the msgpack encoded transaction to sign
+	Txn []byte `json:"txn"`
+	// Partial requests SignPartial semantics - adding to the (possibly empty) partially signed PartialTxn
+	Partial    bool   `json:"partial,omitempty"`
+	PartialTxn []byte `json:"partialTxn,omitempty"`
+}
+
+type signerSignResponse struct {
+	Signed   []byte `json:"signed"`
+	Complete bool   `json:"complete"`
+}
+
+type signerErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// RemoteSigner is a MultipleWalletSigner which has a separate signing service (see NewSignerHandler) sign the
+// transactions - so the keys don't have to be present in this process.
+type RemoteSigner struct {
+	log     *slog.Logger
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewRemoteSigner returns a signer for the service at url - either http(s)://host:port or unix:///path/to/socket -
+// authenticating with the bearer token, if not empty
+func NewRemoteSigner(log *slog.Logger, url string, token string) (*RemoteSigner, error) {
+	signer := &RemoteSigner{
+		log:    log,
+		token:  token,
+		client: &http.Client{Timeout: signerRequestTimeout},
+	}
+	switch {
+	case strings.HasPrefix(url, "unix://"):
+		socketPath := strings.TrimPrefix(url, "unix://")
+		signer.baseURL = "http://" + signerUnixSocketHost
+		signer.client.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socketPath)
+			},
+		}
+	case strings.HasPrefix(url, "http://"), strings.HasPrefix(url, "https://"):
+		signer.baseURL = strings.TrimSuffix(url, "/")
+	default:
+		return nil, fmt.Errorf("invalid remote signer url:%s, must be http(s)://host:port or unix:///path", url)
+	}
+	misc.Infof(log, "using remote signer at %s", url)
+	return signer, nil
+}
+
+func (rs *RemoteSigner) call(ctx context.Context, path string, request any, response any) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rs.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if rs.token != "" {
+		req.Header.Set("Authorization", "Bearer "+rs.token)
+	}
+	resp, err := rs.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("remote signer request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var errResp signerErrorResponse
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return fmt.Errorf("remote signer rejected the request: %s", errResp.Error)
+		case http.StatusForbidden:
+			return fmt.Errorf("%w: %s", ErrSignRejected, errResp.Error)
+		case http.StatusNotFound:
+			return ErrNoSigningKeys
+		}
+		return fmt.Errorf("remote signer returned status %d: %s", resp.StatusCode, errResp.Error)
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
+
+func (rs *RemoteSigner) HasAccount(publicAddress string) bool {
+	var resp signerAccountsResponse
+	if err := rs.call(context.Background(), signerHasAccountPath, signerAccountsRequest{Addresses: []string{publicAddress}}, &resp); err != nil {
+		misc.Warnf(rs.log, "unable to check remote signer for account:%s, err:%v", publicAddress, err)
+		return false
+	}
+	return resp.Found
+}
+
+func (rs *RemoteSigner) FindFirstSigner(addresses []string) (string, error) {
+	var resp signerAccountsResponse
+	if err := rs.call(context.Background(), signerFindSignerPath, signerAccountsRequest{Addresses: addresses}, &resp); err != nil {
+		return "", err
+	}
+	if !resp.Found {
+		return "", fmt.Errorf("no signer found for any of the addresses")
+	}
+	return resp.Address, nil
+}
+
+func (rs *RemoteSigner) SignWithAccount(ctx context.Context, tx types.Transaction, publicAddress string) (string, []byte, error) {
+	var resp signerSignResponse
+	err := rs.call(ctx, signerSignPath, signerSignRequest{Address: publicAddress, Txn: msgpack.Encode(tx)}, &resp)
+	if err != nil {
+		return "", nil, err
+	}
+	return crypto.GetTxID(tx), resp.Signed, nil
+}
+
+func (rs *RemoteSigner) SignPartial(ctx context.Context, tx types.Transaction, publicAddress string, partial []byte) ([]byte, bool, error) {
+	var resp signerSignResponse
+	err := rs.call(ctx, signerSignPath, signerSignRequest{Address: publicAddress, Txn: msgpack.Encode(tx), Partial: true, PartialTxn: partial}, &resp)
+	if err != nil {
+		return nil, false, err
+	}
+	return resp.Signed, resp.Complete, nil
+}
+
+// NewSignerHandler returns the http handler for a signing service signing with signer - but only the transactions
+// allowed by policy, and only for requests with the bearer token if token isn't empty.  Every request is logged,
+// along with why any were rejected.
+func NewSignerHandler(log *slog.Logger, signer MultipleWalletSigner, policy *SignPolicy, token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+signerHasAccountPath, func(w http.ResponseWriter, r *http.Request) {
+		var req signerAccountsRequest
+		if !decodeSignerRequest(w, r, &req) {
+			return
+		}
+		var resp signerAccountsResponse
+		if len(req.Addresses) == 1 {
+			resp.Found = signer.HasAccount(req.Addresses[0])
+		}
+		writeSignerResponse(w, http.StatusOK, resp)
+	})
+	mux.HandleFunc("POST "+signerFindSignerPath, func(w http.ResponseWriter, r *http.Request) {
+		var req signerAccountsRequest
+		if !decodeSignerRequest(w, r, &req) {
+			return
+		}
+		var resp signerAccountsResponse
+		if address, err := signer.FindFirstSigner(req.Addresses); err == nil {
+			resp.Found, resp.Address = true, address
+		}
+		writeSignerResponse(w, http.StatusOK, resp)
+	})
+	mux.HandleFunc("POST "+signerSignPath, func(w http.ResponseWriter, r *http.Request) {
+		var (
+			req signerSignRequest
+			tx  types.Transaction
+		)
+		if !decodeSignerRequest(w, r, &req) {
+			return
+		}
+		if err := msgpack.Decode(req.Txn, &tx); err != nil {
+			writeSignerResponse(w, http.StatusBadRequest, signerErrorResponse{Error: fmt.Sprintf("invalid transaction: %v", err)})
+			return
+		}
+		txID := crypto.GetTxID(tx)
+		if err := policy.Check(tx); err != nil {
+			misc.Warnf(log, "rejected txid:%s, sender:%s, type:%s - %v", txID, tx.Sender, tx.Type, err)
+			writeSignerResponse(w, http.StatusForbidden, signerErrorResponse{Error: err.Error()})
+			return
+		}
+		if req.Partial && req.PartialTxn != nil {
+			// the partially signed transaction is what gets signed, so it has to be the transaction the policy allowed
+			var partial types.SignedTxn
+			if err := msgpack.Decode(req.PartialTxn, &partial); err != nil {
+				writeSignerResponse(w, http.StatusBadRequest, signerErrorResponse{Error: fmt.Sprintf("invalid partially signed transaction: %v", err)})
+				return
+			}
+			if partialID := crypto.GetTxID(partial.Txn); partialID != txID {
+				misc.Warnf(log, "rejected txid:%s, sender:%s - partially signed transaction is txid:%s", txID, tx.Sender, partialID)
+				writeSignerResponse(w, http.StatusForbidden, signerErrorResponse{Error: "partially signed transaction doesn't match the transaction"})
+				return
+			}
+		}
+		var (
+			resp signerSignResponse
+			err  error
+		)
+		if req.Partial {
+			resp.Signed, resp.Complete, err = signer.SignPartial(r.Context(), tx, req.Address, req.PartialTxn)
+		} else {
+			_, resp.Signed, err = signer.SignWithAccount(r.Context(), tx, req.Address)
+			resp.Complete = err == nil
+		}
+		switch {
+		case errors.Is(err, ErrNoSigningKeys):
+			writeSignerResponse(w, http.StatusNotFound, signerErrorResponse{Error: err.Error()})
+			return
+		case err != nil:
+			misc.Errorf(log, "failed signing txid:%s for %s, err:%v", txID, req.Address, err)
+			writeSignerResponse(w, http.StatusInternalServerError, signerErrorResponse{Error: err.Error()})
+			return
+		}
+		misc.Infof(log, "signed txid:%s, sender:%s, type:%s, app id:%d, fee:%s", txID, tx.Sender, tx.Type, tx.ApplicationID, FormattedAlgoAmount(uint64(tx.Fee)))
+		writeSignerResponse(w, http.StatusOK, resp)
+	})
+	if token == "" {
+		return mux
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bearer, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			misc.Warnf(log, "rejected %s request from %s without a valid token", r.URL.Path, r.RemoteAddr)
+			writeSignerResponse(w, http.StatusUnauthorized, signerErrorResponse{Error: "missing or invalid token"})
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func decodeSignerRequest(w http.ResponseWriter, r *http.Request, request any) bool {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, signerMaxRequestBytes)).Decode(request); err != nil {
+		writeSignerResponse(w, http.StatusBadRequest, signerErrorResponse{Error: fmt.Sprintf("invalid request: %v", err)})
+		return false
+	}
+	return true
+}
+
+func writeSignerResponse(w http.ResponseWriter, status int, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,172 @@
+package algo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func testKeyStore(accounts ...crypto.Account) *localKeyStore {
+	lk := &localKeyStore{
+		log:       testLogger(),
+		keys:      map[string]ed25519.PrivateKey{},
+		multisigs: map[string]crypto.MultisigAccount{},
+	}
+	for _, account := range accounts {
+		lk.keys[account.Address.String()] = account.PrivateKey
+	}
+	return lk
+}
+
+func testRemoteSigner(t *testing.T, signer MultipleWalletSigner) *RemoteSigner {
+	server := httptest.NewServer(NewSignerHandler(testLogger(), signer, testPolicy(), ""))
+	t.Cleanup(server.Close)
+	remote, err := NewRemoteSigner(testLogger(), server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return remote
+}
+
+func TestRemoteSignerPolicy(t *testing.T) {
+	account := crypto.GenerateAccount()
+	address := account.Address.String()
+	remote := testRemoteSigner(t, testKeyStore(account))
+
+	if !remote.HasAccount(address) {
+		t.Fatal("remote signer should have the account")
+	}
+	if signer, err := remote.FindFirstSigner([]string{crypto.GenerateAccount().Address.String(), address}); err != nil || signer != address {
+		t.Fatalf("FindFirstSigner returned %s, %v", signer, err)
+	}
+
+	tx := testAppCall(account.Address, testPolicyAppID, testPolicySelector)
+	txID, signed, err := remote.SignWithAccount(context.Background(), tx, address)
+	if err != nil {
+		t.Fatalf("allowed transaction wasn't signed: %v", err)
+	}
+	var stxn types.SignedTxn
+	if err := msgpack.Decode(signed, &stxn); err != nil {
+		t.Fatal(err)
+	}
+	if crypto.GetTxID(stxn.Txn) != txID || stxn.Sig == (types.Signature{}) {
+		t.Fatal("signed transaction doesn't match the transaction")
+	}
+
+	_, _, err = remote.SignWithAccount(context.Background(), testAppCall(account.Address, testPolicyAppID+1, testPolicySelector), address)
+	if !errors.Is(err, ErrSignRejected) {
+		t.Fatalf("transaction not allowed by the policy returned %v, expected ErrSignRejected", err)
+	}
+
+	_, _, err = remote.SignWithAccount(context.Background(), testAppCall(crypto.GenerateAccount().Address, testPolicyAppID, testPolicySelector), crypto.GenerateAccount().Address.String())
+	if err == nil {
+		t.Fatal("signing for an unknown account should fail")
+	}
+}
+
+func TestRemoteSignerToken(t *testing.T) {
+	account := crypto.GenerateAccount()
+	server := httptest.NewServer(NewSignerHandler(testLogger(), testKeyStore(account), testPolicy(), "secret-token"))
+	t.Cleanup(server.Close)
+	tx := testAppCall(account.Address, testPolicyAppID, testPolicySelector)
+
+	for _, token := range []string{"", "wrong-token"} {
+		remote, err := NewRemoteSigner(testLogger(), server.URL, token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if remote.HasAccount(account.Address.String()) {
+			t.Fatalf("request with token %q was answered", token)
+		}
+		if _, _, err := remote.SignWithAccount(context.Background(), tx, account.Address.String()); err == nil {
+			t.Fatalf("request with token %q was signed", token)
+		}
+	}
+	remote, err := NewRemoteSigner(testLogger(), server.URL, "secret-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := remote.SignWithAccount(context.Background(), tx, account.Address.String()); err != nil {
+		t.Fatalf("request with the token wasn't signed: %v", err)
+	}
+}
+
+func TestRemoteSignerPartialMustMatch(t *testing.T) {
+	account := crypto.GenerateAccount()
+	address := account.Address.String()
+	remote := testRemoteSigner(t, testKeyStore(account))
+
+	allowed := testAppCall(account.Address, testPolicyAppID, testPolicySelector)
+	// a transaction the policy would never allow, passed off as the partially signed version of an allowed one
+	forbidden := testPayment(account.Address, crypto.GenerateAccount().Address, 100_000_000)
+	_, _, err := remote.SignPartial(context.Background(), allowed, address, msgpack.Encode(types.SignedTxn{Txn: forbidden}))
+	if !errors.Is(err, ErrSignRejected) {
+		t.Fatalf("mismatched partially signed transaction returned %v, expected ErrSignRejected", err)
+	}
+
+	signed, complete, err := remote.SignPartial(context.Background(), allowed, address, msgpack.Encode(types.SignedTxn{Txn: allowed}))
+	if err != nil || !complete || signed == nil {
+		t.Fatalf("matching partially signed transaction wasn't signed, complete:%v, err:%v", complete, err)
+	}
+}
+
+func TestSignMultisigPartial(t *testing.T) {
+	local, other := crypto.GenerateAccount(), crypto.GenerateAccount()
+	msig, err := crypto.MultisigAccountWithParams(1, 2, []types.Address{local.Address, other.Address})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msigAddr, err := msig.Address()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lk := testKeyStore(local)
+	lk.multisigs[msigAddr.String()] = msig
+
+	if lk.HasAccount(msigAddr.String()) {
+		t.Fatal("multisig account shouldn't be fully signable with only one of two subkeys")
+	}
+	tx := testAppCall(msigAddr, testPolicyAppID, testPolicySelector)
+	if _, _, err := lk.SignWithAccount(context.Background(), tx, msigAddr.String()); !errors.Is(err, ErrMultisigIncomplete) {
+		t.Fatalf("SignWithAccount returned %v, expected ErrMultisigIncomplete", err)
+	}
+
+	// the other subkey holder signs first, then we add ours
+	_, partial, err := crypto.SignMultisigTransaction(other.PrivateKey, msig, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, complete, err := lk.SignPartial(context.Background(), tx, msigAddr.String(), partial)
+	if err != nil {
+		t.Fatalf("SignPartial: %v", err)
+	}
+	if !complete {
+		t.Fatal("both subkeys signed but the transaction isn't complete")
+	}
+	var stxn types.SignedTxn
+	if err := msgpack.Decode(signed, &stxn); err != nil {
+		t.Fatal(err)
+	}
+	if crypto.GetTxID(stxn.Txn) != crypto.GetTxID(tx) {
+		t.Fatal("signed transaction doesn't match the transaction")
+	}
+
+	// signatures are never added to a different transaction than the one asked for
+	otherTx := testAppCall(msigAddr, testPolicyAppID+1, testPolicySelector)
+	if _, _, err := lk.SignPartial(context.Background(), otherTx, msigAddr.String(), partial); err == nil {
+		t.Fatal("SignPartial added signatures to a partially signed transaction that doesn't match")
+	}
+}
@@ -9,10 +9,8 @@ import (
 	"log"
 	"log/slog"
 
-	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
 	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
 	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
-	"github.com/algorand/go-algorand-sdk/v2/transaction"
 	"github.com/algorand/go-algorand-sdk/v2/types"
 )
 
@@ -70,13 +68,13 @@ func decodeAndSignTransaction(signer MultipleWalletSigner, txnType string, msgPa
 	return txnid, bytes, nil
 }
 
-func sendAndWaitTxns(ctx context.Context, log *slog.Logger, algoClient *algod.Client, txnBytes []byte) (models.PendingTransactionInfoResponse, error) {
-	txid, err := algoClient.SendRawTransaction(txnBytes).Do(ctx)
+func sendAndWaitTxns(ctx context.Context, log *slog.Logger, chain Chain, txnBytes []byte) (models.PendingTransactionInfoResponse, error) {
+	txid, err := chain.SendRawTransaction(ctx, txnBytes)
 	if err != nil {
 		return models.PendingTransactionInfoResponse{}, fmt.Errorf("sendAndWaitTxns failed to send txns: %w", err)
 	}
 	log.Info("sendAndWaitTxns", "txid", txid)
-	resp, err := transaction.WaitForConfirmation(algoClient, txid, 100, ctx)
+	resp, err := chain.WaitForConfirmation(ctx, txid, 100)
 	if err != nil {
 		return models.PendingTransactionInfoResponse{}, fmt.Errorf("sendAndWaitTxns failure in confirmation wait: %w", err)
 	}
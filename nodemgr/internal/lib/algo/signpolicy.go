@@ -0,0 +1,62 @@
+package algo
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// SignPolicy restricts the transactions a signing service (see NewSignerHandler) will sign.  Anything not explicitly
+// allowed is rejected.
+type SignPolicy struct {
+	// AppIDs are the only applications which may be called - and the only accounts which may be paid
+	AppIDs []uint64
+	// Selectors are the ABI method selectors which may be called
+	Selectors [][]byte
+	// MaxFee is the highest fee (in microAlgo) of any single transaction
+	MaxFee uint64
+	// MaxPayment is the largest payment (in microAlgo) to one of the AppIDs accounts
+	MaxPayment uint64
+}
+
+// Check returns an error describing why the transaction isn't allowed by the policy, or nil if it is
+func (p *SignPolicy) Check(tx types.Transaction) error {
+	if uint64(tx.Fee) > p.MaxFee {
+		return fmt.Errorf("fee of %s exceeds maximum of %s", FormattedAlgoAmount(uint64(tx.Fee)), FormattedAlgoAmount(p.MaxFee))
+	}
+	if !tx.RekeyTo.IsZero() {
+		return fmt.Errorf("rekeying isn't allowed")
+	}
+	switch tx.Type {
+	case types.ApplicationCallTx:
+		if !slices.Contains(p.AppIDs, uint64(tx.ApplicationID)) {
+			return fmt.Errorf("app id:%d isn't allowed", tx.ApplicationID)
+		}
+		if tx.OnCompletion != types.NoOpOC {
+			return fmt.Errorf("on completion:%d isn't allowed", tx.OnCompletion)
+		}
+		if len(tx.ApplicationArgs) == 0 || !slices.ContainsFunc(p.Selectors, func(selector []byte) bool {
+			return bytes.Equal(selector, tx.ApplicationArgs[0])
+		}) {
+			return fmt.Errorf("method isn't allowed for app id:%d", tx.ApplicationID)
+		}
+	case types.PaymentTx:
+		if !tx.CloseRemainderTo.IsZero() {
+			return fmt.Errorf("closing the account isn't allowed")
+		}
+		if !slices.ContainsFunc(p.AppIDs, func(appID uint64) bool {
+			return crypto.GetApplicationAddress(appID) == tx.Receiver
+		}) {
+			return fmt.Errorf("payment to %s isn't allowed", tx.Receiver)
+		}
+		if uint64(tx.Amount) > p.MaxPayment {
+			return fmt.Errorf("payment of %s exceeds maximum of %s", FormattedAlgoAmount(uint64(tx.Amount)), FormattedAlgoAmount(p.MaxPayment))
+		}
+	default:
+		return fmt.Errorf("transaction type:%s isn't allowed", tx.Type)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package algo
+
+import (
+	"testing"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+const testPolicyAppID = 1234
+
+var testPolicySelector = []byte{1, 2, 3, 4}
+
+func testPolicy() *SignPolicy {
+	return &SignPolicy{
+		AppIDs:     []uint64{testPolicyAppID},
+		Selectors:  [][]byte{testPolicySelector},
+		MaxFee:     10_000,
+		MaxPayment: 2_000_000,
+	}
+}
+
+func testAppCall(sender types.Address, appID uint64, args ...[]byte) types.Transaction {
+	return types.Transaction{
+		Type:   types.ApplicationCallTx,
+		Header: types.Header{Sender: sender, Fee: 1000, FirstValid: 1, LastValid: 1000},
+		ApplicationFields: types.ApplicationFields{ApplicationCallTxnFields: types.ApplicationCallTxnFields{
+			ApplicationID:   types.AppIndex(appID),
+			OnCompletion:    types.NoOpOC,
+			ApplicationArgs: args,
+		}},
+	}
+}
+
+func testPayment(sender types.Address, receiver types.Address, amount uint64) types.Transaction {
+	return types.Transaction{
+		Type:             types.PaymentTx,
+		Header:           types.Header{Sender: sender, Fee: 1000, FirstValid: 1, LastValid: 1000},
+		PaymentTxnFields: types.PaymentTxnFields{Receiver: receiver, Amount: types.MicroAlgos(amount)},
+	}
+}
+
+func TestSignPolicyCheck(t *testing.T) {
+	sender := crypto.GenerateAccount().Address
+	other := crypto.GenerateAccount().Address
+	appAddress := crypto.GetApplicationAddress(testPolicyAppID)
+
+	tests := []struct {
+		name    string
+		tx      func() types.Transaction
+		allowed bool
+	}{
+		{"allowed method", func() types.Transaction {
+			return testAppCall(sender, testPolicyAppID, testPolicySelector)
+		}, true},
+		{"allowed payment", func() types.Transaction {
+			return testPayment(sender, appAddress, 2_000_000)
+		}, true},
+		{"other app", func() types.Transaction {
+			return testAppCall(sender, testPolicyAppID+1, testPolicySelector)
+		}, false},
+		{"other method", func() types.Transaction {
+			return testAppCall(sender, testPolicyAppID, []byte{9, 9, 9, 9})
+		}, false},
+		{"bare call", func() types.Transaction {
+			return testAppCall(sender, testPolicyAppID)
+		}, false},
+		{"delete", func() types.Transaction {
+			tx := testAppCall(sender, testPolicyAppID, testPolicySelector)
+			tx.OnCompletion = types.DeleteApplicationOC
+			return tx
+		}, false},
+		{"fee too high", func() types.Transaction {
+			tx := testAppCall(sender, testPolicyAppID, testPolicySelector)
+			tx.Fee = 10_001
+			return tx
+		}, false},
+		{"rekey", func() types.Transaction {
+			tx := testAppCall(sender, testPolicyAppID, testPolicySelector)
+			tx.RekeyTo = other
+			return tx
+		}, false},
+		{"payment elsewhere", func() types.Transaction {
+			return testPayment(sender, other, 1)
+		}, false},
+		{"payment too large", func() types.Transaction {
+			return testPayment(sender, appAddress, 2_000_001)
+		}, false},
+		{"close account", func() types.Transaction {
+			tx := testPayment(sender, appAddress, 1)
+			tx.CloseRemainderTo = other
+			return tx
+		}, false},
+		{"asset transfer", func() types.Transaction {
+			return types.Transaction{Type: types.AssetTransferTx, Header: types.Header{Sender: sender, Fee: 1000}}
+		}, false},
+	}
+	policy := testPolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check(tt.tx())
+			if tt.allowed && err != nil {
+				t.Fatalf("expected transaction to be allowed, got: %v", err)
+			}
+			if !tt.allowed && err == nil {
+				t.Fatal("expected transaction to be rejected")
+			}
+		})
+	}
+}
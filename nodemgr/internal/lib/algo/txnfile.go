@@ -0,0 +1,291 @@
+package algo
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// Transaction file formats used for offline signing
+const (
+	// TxnFormatJSON is the json array of [signType, base64 msgpack] pairs produced by SignGroupTransactionsForFrontend.
+	// Partially signed multisig transactions use a signType of "p".
+	TxnFormatJSON = "json"
+	// TxnFormatMsgpack is concatenated msgpack encoded SignedTxn objects - the format 'goal clerk' reads and writes
+	TxnFormatMsgpack = "msgpack"
+)
+
+// FileTxn is a single transaction of a group read from (or written to) a transaction file
+type FileTxn struct {
+	Txn types.Transaction
+	// Signed is the encoded signed transaction - nil if the transaction hasn't been signed yet
+	Signed []byte
+	// Partial is set if Signed is a multisig transaction not yet signed by enough subkeys to meet the threshold
+	Partial bool
+}
+
+// TxnFile is a transaction group along with the format it was read in - so it can be written back the same way
+type TxnFile struct {
+	Format string
+	Txns   []FileTxn
+}
+
+// NewUnsignedTxnFile returns a TxnFile containing the (already grouped) transactions, all unsigned
+func NewUnsignedTxnFile(txns []types.Transaction, format string) (*TxnFile, error) {
+	if format != TxnFormatJSON && format != TxnFormatMsgpack {
+		return nil, fmt.Errorf("unknown transaction file format:%s, must be %s or %s", format, TxnFormatJSON, TxnFormatMsgpack)
+	}
+	txnFile := &TxnFile{Format: format}
+	for _, txn := range txns {
+		txnFile.Txns = append(txnFile.Txns, FileTxn{Txn: txn})
+	}
+	return txnFile, nil
+}
+
+// ReadTxnFile reads a transaction file in either format, determining which from its contents
+func ReadTxnFile(filename string) (*TxnFile, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	txnFile, err := DecodeTxnFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode transactions in %s: %w", filename, err)
+	}
+	return txnFile, nil
+}
+
+// DecodeTxnFile decodes the contents of a transaction file in either format
+func DecodeTxnFile(data []byte) (*TxnFile, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		return decodeJSONTxnFile(trimmed)
+	}
+	return decodeMsgpackTxnFile(data)
+}
+
+func decodeJSONTxnFile(data []byte) (*TxnFile, error) {
+	var pairs [][2]string
+	if err := json.Unmarshal(data, &pairs); err != nil {
+		return nil, err
+	}
+	txnFile := &TxnFile{Format: TxnFormatJSON}
+	for i, pair := range pairs {
+		rawBytes, err := base64.StdEncoding.DecodeString(pair[1])
+		if err != nil {
+			return nil, fmt.Errorf("error decoding txn %d: %w", i, err)
+		}
+		var fileTxn FileTxn
+		switch pair[0] {
+		case "u":
+			err = msgpack.Decode(rawBytes, &fileTxn.Txn)
+		case "s", "p":
+			var stxn types.SignedTxn
+			err = msgpack.Decode(rawBytes, &stxn)
+			fileTxn.Txn, fileTxn.Signed, fileTxn.Partial = stxn.Txn, rawBytes, !isFullySigned(stxn)
+		default:
+			err = fmt.Errorf("unknown sign type:%s", pair[0])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error decoding txn %d: %w", i, err)
+		}
+		txnFile.Txns = append(txnFile.Txns, fileTxn)
+	}
+	return txnFile, nil
+}
+
+func decodeMsgpackTxnFile(data []byte) (*TxnFile, error) {
+	txnFile := &TxnFile{Format: TxnFormatMsgpack}
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	for {
+		var stxn types.SignedTxn
+		err := dec.Decode(&stxn)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error decoding txn %d: %w", len(txnFile.Txns), err)
+		}
+		fileTxn := FileTxn{Txn: stxn.Txn}
+		if stxn.Sig != (types.Signature{}) || len(stxn.Msig.Subsigs) > 0 || len(stxn.Lsig.Logic) > 0 {
+			fileTxn.Signed, fileTxn.Partial = msgpack.Encode(stxn), !isFullySigned(stxn)
+		}
+		txnFile.Txns = append(txnFile.Txns, fileTxn)
+	}
+	return txnFile, nil
+}
+
+// isFullySigned returns whether the transaction has a signature, logic sig, or a multisig signature meeting its
+// threshold
+func isFullySigned(stxn types.SignedTxn) bool {
+	if stxn.Sig != (types.Signature{}) || len(stxn.Lsig.Logic) > 0 {
+		return true
+	}
+	var numSigs int
+	for _, subsig := range stxn.Msig.Subsigs {
+		if subsig.Sig != (types.Signature{}) {
+			numSigs++
+		}
+	}
+	return stxn.Msig.Threshold > 0 && numSigs >= int(stxn.Msig.Threshold)
+}
+
+// Encode returns the transaction file contents in its format
+func (tf *TxnFile) Encode() ([]byte, error) {
+	switch tf.Format {
+	case TxnFormatJSON:
+		var pairs [][]string
+		for _, fileTxn := range tf.Txns {
+			if fileTxn.Partial {
+				pairs = append(pairs, []string{"p", base64.StdEncoding.EncodeToString(fileTxn.Signed)})
+			} else if fileTxn.Signed != nil {
+				pairs = append(pairs, []string{"s", base64.StdEncoding.EncodeToString(fileTxn.Signed)})
+			} else {
+				pairs = append(pairs, []string{"u", base64.StdEncoding.EncodeToString(msgpack.Encode(fileTxn.Txn))})
+			}
+		}
+		return json.Marshal(pairs)
+	case TxnFormatMsgpack:
+		var data []byte
+		for _, fileTxn := range tf.Txns {
+			if fileTxn.Signed != nil {
+				data = append(data, fileTxn.Signed...)
+			} else {
+				data = append(data, msgpack.Encode(types.SignedTxn{Txn: fileTxn.Txn})...)
+			}
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("unknown transaction file format:%s", tf.Format)
+}
+
+// Write writes the transaction file - readable only by the current user as it may contain signed transactions
+func (tf *TxnFile) Write(filename string) error {
+	data, err := tf.Encode()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0o600)
+}
+
+// Sign adds every signature the signer can to the transactions not yet fully signed - returning the number of
+// transactions signed (multisig transactions may still only be partially signed)
+func (tf *TxnFile) Sign(ctx context.Context, signer MultipleWalletSigner) (int, error) {
+	var numSigned int
+	for i, fileTxn := range tf.Txns {
+		if fileTxn.Signed != nil && !fileTxn.Partial {
+			continue
+		}
+		sender := fileTxn.Txn.Sender.String()
+		signed, complete, err := signer.SignPartial(ctx, fileTxn.Txn, sender, fileTxn.Signed)
+		if errors.Is(err, ErrNoSigningKeys) {
+			continue
+		}
+		if err != nil {
+			return numSigned, fmt.Errorf("error signing txn %d for sender:%s: %w", i, sender, err)
+		}
+		tf.Txns[i].Signed, tf.Txns[i].Partial = signed, !complete
+		numSigned++
+	}
+	return numSigned, nil
+}
+
+// Merge adds the signatures from other - the same transactions, signed elsewhere - combining the subkey signatures
+// of partially signed multisig transactions
+func (tf *TxnFile) Merge(other *TxnFile) error {
+	if len(other.Txns) != len(tf.Txns) {
+		return fmt.Errorf("can't merge %d transactions with %d transactions", len(other.Txns), len(tf.Txns))
+	}
+	for i, otherTxn := range other.Txns {
+		fileTxn := tf.Txns[i]
+		if crypto.GetTxID(otherTxn.Txn) != crypto.GetTxID(fileTxn.Txn) {
+			return fmt.Errorf("txn %d isn't the same transaction in both files", i)
+		}
+		switch {
+		case otherTxn.Signed == nil || (fileTxn.Signed != nil && !fileTxn.Partial):
+			continue
+		case fileTxn.Signed == nil || !otherTxn.Partial:
+			tf.Txns[i] = otherTxn
+		default:
+			_, merged, err := crypto.MergeMultisigTransactions(fileTxn.Signed, otherTxn.Signed)
+			if err != nil {
+				return fmt.Errorf("unable to merge multisig signatures of txn %d: %w", i, err)
+			}
+			var stxn types.SignedTxn
+			if err := msgpack.Decode(merged, &stxn); err != nil {
+				return err
+			}
+			tf.Txns[i].Signed, tf.Txns[i].Partial = merged, !isFullySigned(stxn)
+		}
+	}
+	return nil
+}
+
+// Unsigned returns the indexes of the transactions still needing a signature (or more multisig signatures)
+func (tf *TxnFile) Unsigned() []int {
+	var unsigned []int
+	for i, fileTxn := range tf.Txns {
+		if fileTxn.Signed == nil || fileTxn.Partial {
+			unsigned = append(unsigned, i)
+		}
+	}
+	return unsigned
+}
+
+// Verify checks that the transactions form a single, fully signed group, which is still valid as of round
+func (tf *TxnFile) Verify(round uint64) error {
+	if len(tf.Txns) == 0 {
+		return errors.New("no transactions in file")
+	}
+	var txns []types.Transaction
+	for i, fileTxn := range tf.Txns {
+		if fileTxn.Signed == nil {
+			return fmt.Errorf("txn %d (sender:%s) isn't signed", i, fileTxn.Txn.Sender)
+		}
+		if fileTxn.Partial {
+			return fmt.Errorf("txn %d (sender:%s) doesn't have enough multisig signatures", i, fileTxn.Txn.Sender)
+		}
+		if uint64(fileTxn.Txn.LastValid) < round {
+			return fmt.Errorf("txn %d expired at round %d, current round is %d", i, fileTxn.Txn.LastValid, round)
+		}
+		if uint64(fileTxn.Txn.FirstValid) > round {
+			return fmt.Errorf("txn %d isn't valid until round %d, current round is %d", i, fileTxn.Txn.FirstValid, round)
+		}
+		txn := fileTxn.Txn
+		txn.Group = types.Digest{}
+		txns = append(txns, txn)
+	}
+	if len(txns) == 1 {
+		if tf.Txns[0].Txn.Group != (types.Digest{}) {
+			return errors.New("single transaction is part of a group whose other transactions are missing")
+		}
+		return nil
+	}
+	gid, err := crypto.ComputeGroupID(txns)
+	if err != nil {
+		return fmt.Errorf("failed to compute group id: %w", err)
+	}
+	for i, fileTxn := range tf.Txns {
+		if fileTxn.Txn.Group != gid {
+			return fmt.Errorf("txn %d isn't part of the group formed by the transactions in the file", i)
+		}
+	}
+	return nil
+}
+
+// SignedGroup returns the concatenated signed transactions, ready to send
+func (tf *TxnFile) SignedGroup() []byte {
+	var signed []byte
+	for _, fileTxn := range tf.Txns {
+		signed = append(signed, fileTxn.Signed...)
+	}
+	return signed
+}
@@ -0,0 +1,149 @@
+package algo
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func testGroup(t *testing.T, txns ...types.Transaction) []types.Transaction {
+	t.Helper()
+	gid, err := crypto.ComputeGroupID(txns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range txns {
+		txns[i].Group = gid
+	}
+	return txns
+}
+
+func TestTxnFileSignAndVerify(t *testing.T) {
+	owner, manager := crypto.GenerateAccount(), crypto.GenerateAccount()
+	for _, format := range []string{TxnFormatJSON, TxnFormatMsgpack} {
+		t.Run(format, func(t *testing.T) {
+			txns := testGroup(t, testPayment(owner.Address, manager.Address, 1_000_000), testAppCall(manager.Address, testPolicyAppID, testPolicySelector))
+			txnFile, err := NewUnsignedTxnFile(txns, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := txnFile.Verify(500); err == nil || !strings.Contains(err.Error(), "isn't signed") {
+				t.Fatalf("unsigned file verified, err:%v", err)
+			}
+
+			// each sender signs on their own machine, the file being passed along
+			numSigned, err := txnFile.Sign(context.Background(), testKeyStore(owner))
+			if err != nil || numSigned != 1 {
+				t.Fatalf("owner signed %d transactions, err:%v", numSigned, err)
+			}
+			encoded, err := txnFile.Encode()
+			if err != nil {
+				t.Fatal(err)
+			}
+			txnFile, err = DecodeTxnFile(encoded)
+			if err != nil {
+				t.Fatalf("DecodeTxnFile: %v", err)
+			}
+			if txnFile.Format != format || !slices.Equal(txnFile.Unsigned(), []int{1}) {
+				t.Fatalf("decoded %s file with unsigned txns %v", txnFile.Format, txnFile.Unsigned())
+			}
+			numSigned, err = txnFile.Sign(context.Background(), testKeyStore(manager))
+			if err != nil || numSigned != 1 {
+				t.Fatalf("manager signed %d transactions, err:%v", numSigned, err)
+			}
+			if len(txnFile.Unsigned()) != 0 {
+				t.Fatalf("txns %v still unsigned", txnFile.Unsigned())
+			}
+
+			if err := txnFile.Verify(500); err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if err := txnFile.Verify(1001); err == nil || !strings.Contains(err.Error(), "expired") {
+				t.Fatalf("expired group verified, err:%v", err)
+			}
+			if err := txnFile.Verify(0); err == nil || !strings.Contains(err.Error(), "isn't valid until") {
+				t.Fatalf("group not yet valid verified, err:%v", err)
+			}
+			partial := &TxnFile{Format: format, Txns: txnFile.Txns[:1]}
+			if err := partial.Verify(500); err == nil || !strings.Contains(err.Error(), "other transactions are missing") {
+				t.Fatalf("incomplete group verified, err:%v", err)
+			}
+		})
+	}
+}
+
+func TestTxnFileVerifyGroup(t *testing.T) {
+	owner := crypto.GenerateAccount()
+	grouped := testGroup(t, testPayment(owner.Address, owner.Address, 1), testPayment(owner.Address, owner.Address, 2))
+	// a transaction swapped in from another group
+	other := testGroup(t, testPayment(owner.Address, owner.Address, 3), testPayment(owner.Address, owner.Address, 4))
+	txnFile, err := NewUnsignedTxnFile([]types.Transaction{grouped[0], other[1]}, TxnFormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := txnFile.Sign(context.Background(), testKeyStore(owner)); err != nil {
+		t.Fatal(err)
+	}
+	if err := txnFile.Verify(500); err == nil || !strings.Contains(err.Error(), "isn't part of the group") {
+		t.Fatalf("mismatched group verified, err:%v", err)
+	}
+}
+
+func TestTxnFileMerge(t *testing.T) {
+	first, second := crypto.GenerateAccount(), crypto.GenerateAccount()
+	msig, err := crypto.MultisigAccountWithParams(1, 2, []types.Address{first.Address, second.Address})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msigAddr, err := msig.Address()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := testPayment(msigAddr, first.Address, 1_000_000)
+
+	// each subkey holder signs their own copy of the file
+	var files []*TxnFile
+	for _, account := range []crypto.Account{first, second} {
+		lk := testKeyStore(account)
+		lk.multisigs[msigAddr.String()] = msig
+		txnFile, err := NewUnsignedTxnFile([]types.Transaction{tx}, TxnFormatMsgpack)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if numSigned, err := txnFile.Sign(context.Background(), lk); err != nil || numSigned != 1 {
+			t.Fatalf("signed %d transactions, err:%v", numSigned, err)
+		}
+		if !slices.Equal(txnFile.Unsigned(), []int{0}) {
+			t.Fatal("multisig transaction signed by one of two subkeys isn't partially signed")
+		}
+		if err := txnFile.Verify(500); err == nil || !strings.Contains(err.Error(), "multisig signatures") {
+			t.Fatalf("partially signed file verified, err:%v", err)
+		}
+		files = append(files, txnFile)
+	}
+
+	if err := files[0].Merge(files[1]); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	if len(files[0].Unsigned()) != 0 {
+		t.Fatal("merged multisig transaction isn't fully signed")
+	}
+	if err := files[0].Verify(500); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	other, err := NewUnsignedTxnFile([]types.Transaction{testPayment(msigAddr, first.Address, 2_000_000)}, TxnFormatMsgpack)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := files[1].Merge(other); err == nil {
+		t.Fatal("merged a file of different transactions")
+	}
+	if err := files[1].Merge(&TxnFile{Format: TxnFormatMsgpack}); err == nil {
+		t.Fatal("merged a file with a different number of transactions")
+	}
+}
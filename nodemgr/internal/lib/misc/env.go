@@ -4,10 +4,19 @@ import (
 	"errors"
 	"log/slog"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/joho/godotenv"
 )
 
+var (
+	envSourcesMu sync.Mutex
+	// envSources records where each env var not present in the process environment came from - the env file (or
+	// config file) which set it
+	envSources = map[string]string{}
+)
+
 func LoadEnvSettings(log *slog.Logger) {
 	loadEnvFile(log, ".env.local")
 	loadEnvFile(log, ".env")
@@ -18,8 +27,46 @@ func LoadEnvForNetwork(log *slog.Logger, network string) {
 }
 
 func loadEnvFile(log *slog.Logger, filename string) {
-	err := godotenv.Load(filename)
+	err := LoadNamedEnvFile(filename)
 	if err != nil && !errors.Is(err, os.ErrNotExist) {
 		Warnf(log, "error loading %s, err: %v", filename, err)
 	}
 }
+
+// LoadNamedEnvFile loads the env file - never overriding vars already set - recording it as the source of the vars
+// it sets
+func LoadNamedEnvFile(filename string) error {
+	before := map[string]bool{}
+	for _, envVal := range os.Environ() {
+		before[envVal[0:strings.IndexByte(envVal, '=')]] = true
+	}
+	if err := godotenv.Load(filename); err != nil {
+		return err
+	}
+	envSourcesMu.Lock()
+	defer envSourcesMu.Unlock()
+	for _, envVal := range os.Environ() {
+		if key := envVal[0:strings.IndexByte(envVal, '=')]; !before[key] {
+			envSources[key] = filename
+		}
+	}
+	return nil
+}
+
+// SetEnvDefault sets the env var to value if it isn't already set, recording source as where it came from
+func SetEnvDefault(key string, value string, source string) {
+	if value == "" || os.Getenv(key) != "" {
+		return
+	}
+	os.Setenv(key, value)
+	envSourcesMu.Lock()
+	defer envSourcesMu.Unlock()
+	envSources[key] = source
+}
+
+// EnvSource returns where the env var was set - the env or config file, or "" if from the process environment
+func EnvSource(key string) string {
+	envSourcesMu.Lock()
+	defer envSourcesMu.Unlock()
+	return envSources[key]
+}
@@ -0,0 +1,87 @@
+package misc
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Limits on the scrypt parameters of files being opened - so a tampered (or corrupt) file can neither weaken the key
+// derivation nor make it use unbounded memory or cpu.  Files are created with N:2^15, r:8, p:1 and 32 bytes of salt.
+const (
+	scryptMinN       = 1 << 14
+	scryptMaxN       = 1 << 20
+	scryptMaxR       = 16
+	scryptMaxP       = 16
+	scryptMinSaltLen = 16
+	// scryptMaxMemory bounds the memory scrypt needs (128 * N * r bytes)
+	scryptMaxMemory = 1 << 30
+)
+
+// ScryptParams are the scrypt parameters (stored alongside the encrypted data) deriving the XChaCha20-Poly1305 key
+// of a passphrase protected file - ie: the keystore or an encrypted secrets file
+type ScryptParams struct {
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+	Salt []byte `json:"salt"`
+}
+
+// NewScryptParams returns the parameters new files are created with, with a random salt
+func NewScryptParams() (ScryptParams, error) {
+	params := ScryptParams{N: 1 << 15, R: 8, P: 1, Salt: make([]byte, 32)}
+	if _, err := rand.Read(params.Salt); err != nil {
+		return ScryptParams{}, err
+	}
+	return params, nil
+}
+
+// Validate checks the parameters are within limits, before any key is derived with them
+func (s ScryptParams) Validate() error {
+	switch {
+	case s.N < scryptMinN || s.N > scryptMaxN || s.N&(s.N-1) != 0:
+		return fmt.Errorf("scrypt N:%d must be a power of 2 from %d to %d", s.N, scryptMinN, scryptMaxN)
+	case s.R < 1 || s.R > scryptMaxR:
+		return fmt.Errorf("scrypt r:%d must be from 1 to %d", s.R, scryptMaxR)
+	case s.P < 1 || s.P > scryptMaxP:
+		return fmt.Errorf("scrypt p:%d must be from 1 to %d", s.P, scryptMaxP)
+	case 128*uint64(s.N)*uint64(s.R) > scryptMaxMemory:
+		return fmt.Errorf("scrypt N:%d, r:%d would need more than %d MiB", s.N, s.R, scryptMaxMemory>>20)
+	case len(s.Salt) < scryptMinSaltLen:
+		return fmt.Errorf("scrypt salt of %d bytes, at least %d needed", len(s.Salt), scryptMinSaltLen)
+	}
+	return nil
+}
+
+// DeriveAEAD validates the parameters and derives the XChaCha20-Poly1305 cipher of the passphrase with them
+func (s ScryptParams) DeriveAEAD(passphrase []byte) (cipher.AEAD, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	key, err := scrypt.Key(passphrase, s.Salt, s.N, s.R, s.P, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("unable to derive key: %w", err)
+	}
+	return chacha20poly1305.NewX(key)
+}
+
+// Seal encrypts plaintext, returning the random nonce followed by the ciphertext
+func Seal(aead cipher.AEAD, plaintext []byte, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// Open decrypts the output of Seal
+func Open(aead cipher.AEAD, sealed []byte, additionalData []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("encrypted value too short")
+	}
+	return aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], additionalData)
+}
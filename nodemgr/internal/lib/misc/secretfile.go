@@ -0,0 +1,83 @@
+package misc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const encryptedSecretsVersion = 1
+
+// encryptedSecretsFile is the on-disk format of an encrypted secrets file.  The json encoded map of secrets is
+// encrypted (XChaCha20-Poly1305) as a whole using a key derived (scrypt) from the passphrase.
+type encryptedSecretsFile struct {
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"`
+	ScryptParams
+	// Secrets is the nonce followed by the encrypted secrets
+	Secrets []byte `json:"secrets"`
+}
+
+// ReadEncryptedSecrets decrypts the secrets in an encrypted secrets file
+func ReadEncryptedSecrets(filename string, passphrase []byte) (map[string]string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var file encryptedSecretsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid secrets file %s: %w", filename, err)
+	}
+	if file.Version != encryptedSecretsVersion || file.KDF != "scrypt" {
+		return nil, fmt.Errorf("unsupported secrets file %s, version:%d, kdf:%s", filename, file.Version, file.KDF)
+	}
+	if len(file.Secrets) < chacha20poly1305.NonceSizeX {
+		return nil, fmt.Errorf("invalid secrets file %s: encrypted secrets too short", filename)
+	}
+	aead, err := file.DeriveAEAD(passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secrets file %s: %w", filename, err)
+	}
+	plaintext, err := Open(aead, file.Secrets, nil)
+	if err != nil {
+		return nil, errors.New("wrong secrets file passphrase")
+	}
+	secrets := map[string]string{}
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("invalid secrets file %s: %w", filename, err)
+	}
+	return secrets, nil
+}
+
+// WriteEncryptedSecrets (re-)encrypts the secrets with the passphrase, replacing the file atomically so a failed
+// write can't lose the existing secrets.  The file is only readable by the current user.
+func WriteEncryptedSecrets(filename string, passphrase []byte, secrets map[string]string) error {
+	params, err := NewScryptParams()
+	if err != nil {
+		return err
+	}
+	file := encryptedSecretsFile{Version: encryptedSecretsVersion, KDF: "scrypt", ScryptParams: params}
+	aead, err := params.DeriveAEAD(passphrase)
+	if err != nil {
+		return err
+	}
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+	if file.Secrets, err = Seal(aead, plaintext, nil); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmpName := filename + ".tmp"
+	if err := os.WriteFile(tmpName, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, filename)
+}
@@ -0,0 +1,94 @@
+package misc
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEncryptedSecretsRoundTrip(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "secrets.json")
+	passphrase := []byte("correct horse battery staple")
+	secrets := map[string]string{"ALGOD_TOKEN": "token", "MANAGER_MNEMONIC": "abandon abandon"}
+
+	if err := WriteEncryptedSecrets(filename, passphrase, secrets); err != nil {
+		t.Fatalf("WriteEncryptedSecrets: %v", err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "abandon") {
+		t.Fatal("secrets file contains a secret in the clear")
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{"version", "kdf", "n", "r", "p", "salt", "secrets"} {
+		if _, found := raw[field]; !found {
+			t.Fatalf("secrets file is missing the %s field", field)
+		}
+	}
+
+	read, err := ReadEncryptedSecrets(filename, passphrase)
+	if err != nil {
+		t.Fatalf("ReadEncryptedSecrets: %v", err)
+	}
+	if len(read) != len(secrets) || read["ALGOD_TOKEN"] != "token" || read["MANAGER_MNEMONIC"] != "abandon abandon" {
+		t.Fatalf("read secrets %v don't match those written", read)
+	}
+	if _, err := ReadEncryptedSecrets(filename, []byte("wrong passphrase")); err == nil || !strings.Contains(err.Error(), "wrong secrets file passphrase") {
+		t.Fatalf("wrong passphrase returned %v", err)
+	}
+}
+
+func TestReadEncryptedSecretsRejectsTampering(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "secrets.json")
+	if err := WriteEncryptedSecrets(filename, []byte("passphrase"), map[string]string{"KEY": "value"}); err != nil {
+		t.Fatalf("WriteEncryptedSecrets: %v", err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		tamper func(file *encryptedSecretsFile)
+	}{
+		{"weak N", func(file *encryptedSecretsFile) { file.N = 2 }},
+		{"huge N", func(file *encryptedSecretsFile) { file.N = 1 << 30 }},
+		{"N not a power of 2", func(file *encryptedSecretsFile) { file.N = 1<<15 + 1 }},
+		{"zero r", func(file *encryptedSecretsFile) { file.R = 0 }},
+		{"huge r", func(file *encryptedSecretsFile) { file.R = 1 << 20 }},
+		{"zero p", func(file *encryptedSecretsFile) { file.P = 0 }},
+		{"too much memory", func(file *encryptedSecretsFile) { file.N, file.R = scryptMaxN, scryptMaxR }},
+		{"short salt", func(file *encryptedSecretsFile) { file.Salt = file.Salt[:8] }},
+		{"unknown kdf", func(file *encryptedSecretsFile) { file.KDF = "pbkdf2" }},
+		{"truncated secrets", func(file *encryptedSecretsFile) { file.Secrets = file.Secrets[:10] }},
+		{"modified secrets", func(file *encryptedSecretsFile) { file.Secrets[len(file.Secrets)-1] ^= 1 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var file encryptedSecretsFile
+			if err := json.Unmarshal(data, &file); err != nil {
+				t.Fatal(err)
+			}
+			tt.tamper(&file)
+			tampered, err := json.Marshal(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tamperedName := filepath.Join(t.TempDir(), "secrets.json")
+			if err := os.WriteFile(tamperedName, tampered, 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := ReadEncryptedSecrets(tamperedName, []byte("passphrase")); err == nil {
+				t.Fatal("ReadEncryptedSecrets accepted a tampered file")
+			}
+		})
+	}
+}
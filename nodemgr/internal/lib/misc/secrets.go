@@ -1,19 +1,103 @@
 package misc
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 )
 
-var secretsMap = map[string]string{}
+// SecretProvider is a source of secrets other than plain environment variables
+type SecretProvider interface {
+	Name() string
+	// GetSecret returns the value of the secret - or "" if the provider doesn't have it
+	GetSecret(key string) (string, error)
+	// SecretKeys returns the keys of every secret the provider has
+	SecretKeys() ([]string, error)
+}
+
+var (
+	providersMu     sync.RWMutex
+	secretProviders []SecretProvider
+	secretsLog      = slog.Default()
+)
+
+// Names of the secret providers which can be listed in RETI_SECRETS_PROVIDERS
+const (
+	FileSecretsProvider          = "files"
+	EncryptedFileSecretsProvider = "encfile"
+	HTTPSecretsProvider          = "http"
+)
+
+// ConfigureSecretProviders sets the secret providers (consulted in order, after the environment) from the
+// RETI_SECRETS_PROVIDERS comma separated list - defaulting to just files.  Provider settings:
+//
+//	files:   *_FILE env vars naming a file containing the secret, and files named after the secret in
+//	         RETI_SECRETS_DIR (default /run/secrets - as used by Docker and Kubernetes)
+//	encfile: the encrypted secrets file RETI_SECRETS_ENCFILE, unlocked with the passphrase in RETI_SECRETS_ENCFILE_PASSFILE
+//	http:    RETI_SECRETS_HTTP_URL (GET {url}/{key}) with RETI_SECRETS_HTTP_TOKEN as the bearer token
+func ConfigureSecretProviders(log *slog.Logger) error {
+	names := os.Getenv("RETI_SECRETS_PROVIDERS")
+	if names == "" {
+		names = FileSecretsProvider
+	}
+	providersMu.Lock()
+	secretsLog = log
+	secretProviders = nil
+	providersMu.Unlock()
+
+	for _, name := range strings.Split(names, ",") {
+		var (
+			provider SecretProvider
+			err      error
+		)
+		switch name = strings.TrimSpace(name); name {
+		case FileSecretsProvider:
+			dir := os.Getenv("RETI_SECRETS_DIR")
+			if dir == "" {
+				dir = "/run/secrets"
+			}
+			provider = &fileSecrets{dir: dir}
+		case EncryptedFileSecretsProvider:
+			provider, err = newEncryptedFileSecrets(os.Getenv("RETI_SECRETS_ENCFILE"), os.Getenv("RETI_SECRETS_ENCFILE_PASSFILE"))
+		case HTTPSecretsProvider:
+			// the token can itself come from the providers configured before this one (ie: a docker secret)
+			provider, err = newHTTPSecrets(os.Getenv("RETI_SECRETS_HTTP_URL"), GetSecret("RETI_SECRETS_HTTP_TOKEN"))
+		default:
+			err = fmt.Errorf("unknown secrets provider:%s", name)
+		}
+		if err != nil {
+			return fmt.Errorf("unable to configure %s secrets provider: %w", name, err)
+		}
+		providersMu.Lock()
+		secretProviders = append(secretProviders, provider)
+		providersMu.Unlock()
+		Infof(log, "using %s secrets provider", provider.Name())
+	}
+	return nil
+}
 
+func providers() []SecretProvider {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	return secretProviders
+}
+
+// SecretKeys returns the keys of every environment variable and every secret the providers have
 func SecretKeys() []string {
 	var uniqKeys = map[string]bool{}
 	for _, envVal := range os.Environ() {
 		key := envVal[0:strings.IndexByte(envVal, '=')]
 		uniqKeys[key] = true
 	}
-	for k, _ := range secretsMap {
+	for _, k := range ProviderSecretKeys() {
 		uniqKeys[k] = true
 	}
 	var retStrings []string
@@ -23,14 +107,207 @@ func SecretKeys() []string {
 	return retStrings
 }
 
+// ProviderSecretKeys returns the keys of every secret the providers have - not including plain env vars
+func ProviderSecretKeys() []string {
+	var keys []string
+	for _, provider := range providers() {
+		providerKeys, err := provider.SecretKeys()
+		if err != nil {
+			Warnf(secretsLog, "unable to list secrets from %s provider, err:%v", provider.Name(), err)
+			continue
+		}
+		keys = append(keys, providerKeys...)
+	}
+	return keys
+}
+
 // GetSecret retrieves the value of a secret identified by the given key.
 // If the secret is found in an environment variable, it returns the value.
-// Otherwise, it return the value secretsMap if found.
-// This abstraction is just an env abstraction at the moment but could easily
-// be changed to fetch secrets other ways if necessary.
+// Otherwise, each configured secret provider (see ConfigureSecretProviders) is tried in turn.
 func GetSecret(key string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
-	return secretsMap[key]
+	return GetProviderSecret(key)
+}
+
+// GetProviderSecret retrieves the value of a secret from the secret providers only - ignoring plain env vars
+func GetProviderSecret(key string) string {
+	for _, provider := range providers() {
+		value, err := provider.GetSecret(key)
+		if err != nil {
+			// never log the value - just where it couldn't be fetched from
+			Warnf(secretsLog, "unable to fetch secret:%s from %s provider, err:%v", key, provider.Name(), err)
+			continue
+		}
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// fileSecrets reads secrets from files - either named by a {key}_FILE env var or named {key} in dir
+type fileSecrets struct {
+	dir string
+}
+
+func (f *fileSecrets) Name() string {
+	return fmt.Sprintf("%s (%s and *_FILE)", FileSecretsProvider, f.dir)
+}
+
+func (f *fileSecrets) GetSecret(key string) (string, error) {
+	if filename := os.Getenv(key + "_FILE"); filename != "" {
+		return readSecretFile(filename)
+	}
+	if strings.ContainsAny(key, `/\`) {
+		return "", nil
+	}
+	value, err := readSecretFile(filepath.Join(f.dir, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	return value, err
+}
+
+func (f *fileSecrets) SecretKeys() ([]string, error) {
+	var keys []string
+	for _, envVal := range os.Environ() {
+		key := envVal[0:strings.IndexByte(envVal, '=')]
+		if strings.HasSuffix(key, "_FILE") {
+			keys = append(keys, strings.TrimSuffix(key, "_FILE"))
+		}
+	}
+	entries, err := os.ReadDir(f.dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return keys, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			keys = append(keys, entry.Name())
+		}
+	}
+	return keys, nil
+}
+
+// readSecretFile reads a secret file - trailing newlines are ignored
+func readSecretFile(filename string) (string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// encryptedFileSecrets are the secrets of an encrypted secrets file (see WriteEncryptedSecrets), decrypted once
+type encryptedFileSecrets struct {
+	filename string
+	secrets  map[string]string
+}
+
+func newEncryptedFileSecrets(filename string, passfile string) (*encryptedFileSecrets, error) {
+	if filename == "" || passfile == "" {
+		return nil, errors.New("RETI_SECRETS_ENCFILE and RETI_SECRETS_ENCFILE_PASSFILE must be set")
+	}
+	passphrase, err := readSecretFile(passfile)
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := ReadEncryptedSecrets(filename, []byte(passphrase))
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedFileSecrets{filename: filename, secrets: secrets}, nil
+}
+
+func (e *encryptedFileSecrets) Name() string {
+	return fmt.Sprintf("%s (%s)", EncryptedFileSecretsProvider, e.filename)
+}
+
+func (e *encryptedFileSecrets) GetSecret(key string) (string, error) {
+	return e.secrets[key], nil
+}
+
+func (e *encryptedFileSecrets) SecretKeys() ([]string, error) {
+	var keys []string
+	for key := range e.secrets {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// httpSecrets fetches secrets from a secret store - GET {url}/{key} returning {"value":"..."} (404 if not present)
+// and GET {url} returning {"keys":[...]}.  Values are cached once fetched.
+type httpSecrets struct {
+	baseURL string
+	token   string
+	client  *http.Client
+
+	sync.Mutex
+	cache map[string]string
+}
+
+func newHTTPSecrets(baseURL string, token string) (*httpSecrets, error) {
+	if baseURL == "" || token == "" {
+		return nil, errors.New("RETI_SECRETS_HTTP_URL and RETI_SECRETS_HTTP_TOKEN must be set")
+	}
+	return &httpSecrets{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		cache:   map[string]string{},
+	}, nil
+}
+
+func (h *httpSecrets) Name() string {
+	return fmt.Sprintf("%s (%s)", HTTPSecretsProvider, h.baseURL)
+}
+
+func (h *httpSecrets) get(path string, response any) (bool, error) {
+	req, err := http.NewRequest(http.MethodGet, h.baseURL+path, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+h.token)
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, json.NewDecoder(resp.Body).Decode(response)
+	case http.StatusNotFound:
+		return false, nil
+	}
+	return false, fmt.Errorf("secret store returned status %d", resp.StatusCode)
+}
+
+func (h *httpSecrets) GetSecret(key string) (string, error) {
+	h.Lock()
+	defer h.Unlock()
+	if value, found := h.cache[key]; found {
+		return value, nil
+	}
+	var resp struct {
+		Value string `json:"value"`
+	}
+	found, err := h.get("/"+url.PathEscape(key), &resp)
+	if err != nil {
+		return "", err
+	}
+	if found {
+		h.cache[key] = resp.Value
+	}
+	return resp.Value, nil
+}
+
+func (h *httpSecrets) SecretKeys() ([]string, error) {
+	var resp struct {
+		Keys []string `json:"keys"`
+	}
+	if _, err := h.get("", &resp); err != nil {
+		return nil, err
+	}
+	return resp.Keys, nil
 }
@@ -0,0 +1,316 @@
+package misc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ErrCircuitOpen is returned (without sending the request) while an endpoint's circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker open - endpoint failing")
+
+// TransportOptions controls the throttling, retries and circuit breaking of a ResilientTransport
+type TransportOptions struct {
+	// RateLimit is the requests per second allowed to the endpoint (0 for no limit), with bursts of up to Burst
+	RateLimit float64 `yaml:"rateLimit"`
+	Burst     int     `yaml:"burst"`
+	// Timeout is the maximum time for each request attempt (0 for none)
+	Timeout time.Duration `yaml:"timeout"`
+	// MaxRetries is how many times a request is retried after throttling, gateway errors or connection failures
+	MaxRetries int `yaml:"maxRetries"`
+	// BreakerThreshold consecutive failures open the circuit breaker - failing all requests for BreakerCooldown,
+	// after which a single request is let through to test the endpoint (0 to disable)
+	BreakerThreshold int           `yaml:"breakerThreshold"`
+	BreakerCooldown  time.Duration `yaml:"breakerCooldown"`
+}
+
+var (
+	transportDefaultsMu sync.Mutex
+	transportDefaults   = TransportOptions{
+		RateLimit:        50,
+		Burst:            100,
+		Timeout:          30 * time.Second,
+		MaxRetries:       3,
+		BreakerThreshold: 10,
+		BreakerCooldown:  30 * time.Second,
+	}
+)
+
+// DefaultTransportOptions returns the options transports are created with unless specified otherwise
+func DefaultTransportOptions() TransportOptions {
+	transportDefaultsMu.Lock()
+	defer transportDefaultsMu.Unlock()
+	return transportDefaults
+}
+
+// SetDefaultTransportOptions changes the options of transports created from now on - ie: from the config file
+func SetDefaultTransportOptions(options TransportOptions) {
+	transportDefaultsMu.Lock()
+	defer transportDefaultsMu.Unlock()
+	transportDefaults = options
+}
+
+var (
+	promHTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: "reti",
+		Name:      "http_requests_total",
+		Help:      "HTTP request attempts per endpoint, by status code (or 'error')",
+	}, []string{"endpoint", "code"})
+	promHTTPRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: "reti",
+		Name:      "http_retries_total",
+		Help:      "HTTP requests retried per endpoint",
+	}, []string{"endpoint"})
+	promHTTPDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: "reti",
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request attempt durations per endpoint",
+	}, []string{"endpoint"})
+	promHTTPCircuitOpen = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: "reti",
+		Name:      "http_circuit_open",
+		Help:      "1 while the endpoint's circuit breaker is open",
+	}, []string{"endpoint"})
+)
+
+// ResilientTransport is an http.RoundTripper for a single endpoint (ie: an algod node or the NFD API) which
+// throttles requests with a token bucket, retries throttled/failed requests with jittered exponential backoff,
+// stops sending requests to an endpoint which keeps failing (circuit breaker) and records per endpoint metrics.
+type ResilientTransport struct {
+	log      *slog.Logger
+	endpoint string
+	base     http.RoundTripper
+	options  TransportOptions
+
+	sync.Mutex
+	// token bucket
+	tokens     float64
+	lastRefill time.Time
+	// circuit breaker
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+// NewResilientTransport wraps base (http.DefaultTransport if nil) for requests to the endpoint - which is only used
+// to identify it in logs and metrics, so mustn't contain secrets.
+func NewResilientTransport(log *slog.Logger, endpoint string, base http.RoundTripper, options TransportOptions) *ResilientTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &ResilientTransport{
+		log:        log,
+		endpoint:   endpoint,
+		base:       base,
+		options:    options,
+		tokens:     float64(options.Burst),
+		lastRefill: time.Now(),
+	}
+}
+
+func (t *ResilientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := t.wait(req.Context()); err != nil {
+			return nil, err
+		}
+		if err := t.allow(); err != nil {
+			return nil, err
+		}
+		resp, err := t.attempt(req)
+		retryable, healthy := t.classify(req, resp, err)
+		t.record(healthy)
+		if !retryable || attempt >= t.options.MaxRetries {
+			return resp, err
+		}
+		delay := backoff(attempt)
+		if retryAfter := retryAfterDelay(resp); retryAfter > delay {
+			delay = retryAfter
+		}
+		if resp != nil {
+			// drain so the connection can be reused
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+			resp.Body.Close()
+		}
+		Debugf(t.log, "retrying %s %s on %s in %v (attempt %d), status:%s err:%v", req.Method, req.URL.Path, t.endpoint, delay, attempt+1, statusOf(resp), err)
+		promHTTPRetries.WithLabelValues(t.endpoint).Inc()
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+		if req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// attempt sends the request once, with the per-attempt timeout
+func (t *ResilientTransport) attempt(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	defer func() {
+		promHTTPDuration.WithLabelValues(t.endpoint).Observe(time.Since(start).Seconds())
+	}()
+	if t.options.Timeout == 0 {
+		resp, err := t.base.RoundTrip(req)
+		promHTTPRequests.WithLabelValues(t.endpoint, statusOf(resp)).Inc()
+		return resp, err
+	}
+	timeout := t.options.Timeout
+	if strings.Contains(req.URL.Path, "/wait-for-block-after/") {
+		// algod holds these open for up to a minute waiting for the round
+		timeout += time.Minute
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	promHTTPRequests.WithLabelValues(t.endpoint, statusOf(resp)).Inc()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// the timeout covers reading the body too - so it can only be released once the body's closed
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// classify returns whether the attempt can be retried, and whether the endpoint was healthy (for the breaker).
+// Requests which may have changed state (ie: POSTs) are only retried if the endpoint refused them outright.
+func (t *ResilientTransport) classify(req *http.Request, resp *http.Response, err error) (retryable bool, healthy bool) {
+	if req.Context().Err() != nil {
+		return false, true
+	}
+	replayable := req.Body == nil || req.GetBody != nil
+	idempotent := replayable && (req.Method == http.MethodGet || req.Method == http.MethodHead || req.Method == http.MethodDelete)
+	if err != nil {
+		return idempotent, false
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return replayable, true
+	case http.StatusServiceUnavailable:
+		return replayable, false
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent, false
+	}
+	return false, true
+}
+
+// wait blocks until the token bucket allows another request
+func (t *ResilientTransport) wait(ctx context.Context) error {
+	if t.options.RateLimit <= 0 {
+		return nil
+	}
+	for {
+		t.Lock()
+		now := time.Now()
+		t.tokens = min(float64(max(t.options.Burst, 1)), t.tokens+now.Sub(t.lastRefill).Seconds()*t.options.RateLimit)
+		t.lastRefill = now
+		if t.tokens >= 1 {
+			t.tokens--
+			t.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - t.tokens) / t.options.RateLimit * float64(time.Second))
+		t.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// allow returns ErrCircuitOpen if the breaker is open - letting one request through to probe the endpoint once the
+// cooldown has passed
+func (t *ResilientTransport) allow() error {
+	if t.options.BreakerThreshold <= 0 {
+		return nil
+	}
+	t.Lock()
+	defer t.Unlock()
+	if t.failures < t.options.BreakerThreshold {
+		return nil
+	}
+	if time.Now().Before(t.openUntil) || t.probing {
+		return fmt.Errorf("%w: %s", ErrCircuitOpen, t.endpoint)
+	}
+	t.probing = true
+	return nil
+}
+
+// record updates the circuit breaker with the outcome of an attempt
+func (t *ResilientTransport) record(healthy bool) {
+	if t.options.BreakerThreshold <= 0 {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	wasOpen := t.failures >= t.options.BreakerThreshold
+	t.probing = false
+	if healthy {
+		if wasOpen {
+			Infof(t.log, "%s is responding again, circuit breaker closed", t.endpoint)
+			promHTTPCircuitOpen.WithLabelValues(t.endpoint).Set(0)
+		}
+		t.failures = 0
+		return
+	}
+	t.failures++
+	if t.failures >= t.options.BreakerThreshold {
+		if !wasOpen {
+			Warnf(t.log, "%s failed %d times in a row, circuit breaker open for %v", t.endpoint, t.failures, t.options.BreakerCooldown)
+			promHTTPCircuitOpen.WithLabelValues(t.endpoint).Set(1)
+		}
+		t.openUntil = time.Now().Add(t.options.BreakerCooldown)
+	}
+}
+
+// backoff is exponential from 250ms (capped at 10s), with full jitter
+func backoff(attempt int) time.Duration {
+	ceiling := min(250*time.Millisecond<<attempt, 10*time.Second)
+	return time.Duration(rand.Int64N(int64(ceiling))) + 50*time.Millisecond
+}
+
+// retryAfterDelay returns the (seconds) Retry-After delay of the response if any, capped at a minute
+func retryAfterDelay(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	secs, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || secs <= 0 {
+		return 0
+	}
+	return min(time.Duration(secs)*time.Second, time.Minute)
+}
+
+func statusOf(resp *http.Response) string {
+	if resp == nil {
+		return "error"
+	}
+	return strconv.Itoa(resp.StatusCode)
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
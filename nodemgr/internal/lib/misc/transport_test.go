@@ -0,0 +1,142 @@
+package misc
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testTransportClient(t *testing.T, options TransportOptions, handler http.HandlerFunc) (*http.Client, string) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	transport := NewResilientTransport(slog.New(slog.NewTextHandler(io.Discard, nil)), t.Name(), nil, options)
+	return &http.Client{Transport: transport}, server.URL
+}
+
+// failingHandler fails the first numFailures requests with status, then succeeds - counting every request
+func failingHandler(numFailures int32, status int, requests *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= numFailures {
+			w.WriteHeader(status)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Write(append([]byte("ok"), body...))
+	}
+}
+
+func TestResilientTransportRetries(t *testing.T) {
+	options := TransportOptions{MaxRetries: 3, Timeout: 5 * time.Second}
+	tests := []struct {
+		name        string
+		method      string
+		status      int
+		numFailures int32
+		// expectedRequests is how many requests reach the server
+		expectedRequests int32
+		expectedStatus   int
+	}{
+		{"get throttled", http.MethodGet, http.StatusTooManyRequests, 2, 3, http.StatusOK},
+		{"get unavailable", http.MethodGet, http.StatusServiceUnavailable, 1, 2, http.StatusOK},
+		{"get bad gateway", http.MethodGet, http.StatusBadGateway, 1, 2, http.StatusOK},
+		{"get gives up", http.MethodGet, http.StatusServiceUnavailable, 10, 4, http.StatusServiceUnavailable},
+		{"get not found", http.MethodGet, http.StatusNotFound, 1, 1, http.StatusNotFound},
+		{"post throttled", http.MethodPost, http.StatusTooManyRequests, 1, 2, http.StatusOK},
+		// the request may have been processed - so is never retried
+		{"post bad gateway", http.MethodPost, http.StatusBadGateway, 1, 1, http.StatusBadGateway},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			client, url := testTransportClient(t, options, failingHandler(tt.numFailures, tt.status, &requests))
+			req, err := http.NewRequest(tt.method, url, strings.NewReader("body"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if resp.StatusCode != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if resp.StatusCode == http.StatusOK && string(body) != "okbody" {
+				t.Fatalf("retried request body wasn't resent, got response:%q", body)
+			}
+			if requests.Load() != tt.expectedRequests {
+				t.Fatalf("expected %d requests, got %d", tt.expectedRequests, requests.Load())
+			}
+		})
+	}
+}
+
+func TestResilientTransportCircuitBreaker(t *testing.T) {
+	var (
+		requests atomic.Int32
+		healthy  atomic.Bool
+	)
+	options := TransportOptions{BreakerThreshold: 2, BreakerCooldown: 100 * time.Millisecond}
+	client, url := testTransportClient(t, options, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+	get := func() error {
+		resp, err := client.Get(url)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	for range 2 {
+		if err := get(); err != nil {
+			t.Fatalf("request before the breaker opened failed: %v", err)
+		}
+	}
+	if err := get(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen once open, got %v", err)
+	}
+	if requests.Load() != 2 {
+		t.Fatalf("requests were sent while the breaker was open - %d requests", requests.Load())
+	}
+
+	// after the cooldown a probe is let through, which closes the breaker if the endpoint has recovered
+	healthy.Store(true)
+	time.Sleep(options.BreakerCooldown)
+	for range 2 {
+		if err := get(); err != nil {
+			t.Fatalf("request after the cooldown failed: %v", err)
+		}
+	}
+	if requests.Load() != 4 {
+		t.Fatalf("expected 4 requests, got %d", requests.Load())
+	}
+}
+
+func TestResilientTransportRateLimit(t *testing.T) {
+	var requests atomic.Int32
+	client, url := testTransportClient(t, TransportOptions{RateLimit: 20, Burst: 1}, failingHandler(0, 0, &requests))
+	start := time.Now()
+	for range 3 {
+		resp, err := client.Get(url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	// a burst of one, then one request every 50ms
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("3 requests at 20/s with a burst of 1 took only %v", elapsed)
+	}
+}
@@ -29,7 +29,7 @@ func (n *NfdApi) getApplicationBoxes(ctx context.Context, appID uint64) (map[str
 	)
 
 	// First fetch the list of boxes
-	boxes, err := n.algoClient.GetApplicationBoxes(appID).Do(ctx)
+	boxes, err := n.chain.ApplicationBoxes(ctx, appID)
 	if err != nil {
 		return nil, fmt.Errorf("unable to retrieve boxes list: %w", err)
 	}
@@ -38,7 +38,7 @@ func (n *NfdApi) getApplicationBoxes(ctx context.Context, appID uint64) (map[str
 	for _, box := range boxes.Boxes {
 		wg.Run(func(val interface{}) error {
 			boxName := val.([]byte)
-			boxValue, err := n.algoClient.GetApplicationBoxByName(appID, boxName).Do(ctx)
+			boxValue, err := n.chain.ApplicationBoxByName(ctx, appID, boxName)
 			if err != nil {
 				return fmt.Errorf("unable to fetch box:%s, error:%w", string(boxName), err)
 			}
@@ -11,7 +11,7 @@ import (
 
 func (n *NfdApi) FindByName(ctx context.Context, nfdName string) (uint64, error) {
 	// First try to resolve via V2
-	boxValue, err := n.algoClient.GetApplicationBoxByName(n.registryAppID, getRegistryBoxNameForNFD(nfdName)).Do(ctx)
+	boxValue, err := n.chain.ApplicationBoxByName(ctx, n.registryAppID, getRegistryBoxNameForNFD(nfdName))
 	if err == nil {
 		// The box data is stored as
 		// {ASA ID}{APP ID} - packed 64-bit ints
@@ -28,7 +28,7 @@ func (n *NfdApi) FindByName(ctx context.Context, nfdName string) (uint64, error)
 	}
 	// Read the local state for our registry SC from this specific account
 	address, _ := nameLSIG.Address()
-	account, err := n.algoClient.AccountApplicationInformation(address.String(), n.registryAppID).Do(ctx)
+	account, err := n.chain.AccountApplicationInformation(ctx, address.String(), n.registryAppID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get account data for account:%s : %w", address, err)
 	}
@@ -51,7 +51,7 @@ func (n *NfdApi) FindByAddress(ctx context.Context, lookupAddress string) ([]uin
 	}
 
 	// First try to resolve via V2
-	boxValue, err := n.algoClient.GetApplicationBoxByName(n.registryAppID, getRegistryBoxNameForAddress(algoAddress)).Do(ctx)
+	boxValue, err := n.chain.ApplicationBoxByName(ctx, n.registryAppID, getRegistryBoxNameForAddress(algoAddress))
 	if err == nil {
 		// Get the set of nfd app ids referenced by this address - we just grab the first for now
 		nfdAppIDs, err = fetchUInt64sFromPackedValue(boxValue.Value)
@@ -67,7 +67,7 @@ func (n *NfdApi) FindByAddress(ctx context.Context, lookupAddress string) ([]uin
 		}
 		// Read the local state for our registry SC from this specific account
 		address, _ := revAddressLSIG.Address()
-		account, err := n.algoClient.AccountApplicationInformation(address.String(), n.registryAppID).Do(ctx)
+		account, err := n.chain.AccountApplicationInformation(ctx, address.String(), n.registryAppID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get account data for account:%s : %w", address, err)
 		}
@@ -2,32 +2,19 @@ package nfdonchain
 
 import (
 	"context"
-	"fmt"
 
-	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorandfoundation/reti/internal/lib/algo"
 )
 
 type NfdApi struct {
-	algoClient    *algod.Client
+	chain         algo.Chain
 	registryAppID uint64
 }
 
-func NewNfdApi(algoClient *algod.Client, network string) (*NfdApi, error) {
-	var appId uint64
-
-	switch network {
-	case "fnet":
-		appId = 0 // nfds aren't deployed on fnet
-	case "betanet":
-		appId = 842656530
-	case "testnet":
-		appId = 84366825
-	case "mainnet":
-		appId = 760937186
-	default:
-		return nil, fmt.Errorf("invalid network:%s for nfd api", network)
-	}
-	return &NfdApi{algoClient: algoClient, registryAppID: appId}, nil
+// NewNfdApi returns an api for reading NFDs directly from the chain, using the NFD registry contract registryAppID
+// (see algo.NetworkConfig.NFDRegistryID) - 0 if NFDs aren't deployed on the network.
+func NewNfdApi(chain algo.Chain, registryAppID uint64) *NfdApi {
+	return &NfdApi{chain: chain, registryAppID: registryAppID}
 }
 
 type NFDProperties struct {
@@ -41,7 +28,7 @@ type NFDProperties struct {
 // are fetched.
 func (n *NfdApi) GetNFD(ctx context.Context, appID uint64, fullFetch bool) (NFDProperties, error) {
 	// Load the global state of this NFD
-	appData, err := n.algoClient.GetApplicationByID(appID).Do(ctx)
+	appData, err := n.chain.ApplicationByID(ctx, appID)
 	if err != nil {
 		return NFDProperties{}, err
 	}
@@ -0,0 +1,15 @@
+// Code generated by contracthashes. DO NOT EDIT.
+
+package reti
+
+// knownContractHashes maps the approval program hash of each released contract, on each network, to its release
+var knownContractHashes = map[string]string{
+	"OYKFSPRTY357DNJL4K2JN3DUGF36RUBDHKKVSZS3D6HTSTF2H4MARTLP7A": "1.5.1", // StakingPool betanet
+	"AVAPXTUEVG3T6SGR5VXJ4TMAP2D4SS74LKQMC4YVXCRSQ35QWQQNKTPIYU": "1.5.1", // StakingPool fnet
+	"R7UAT2FYSH6QQT6AM6HZQFS77E2WW2MJG2BTKYFDEHIP2UMTD4WPNVJBJE": "1.5.1", // StakingPool mainnet
+	"IUFHX7YL274LNOI6MUKCMASE5NVRI2CKCZ5XD7TMDJKEH6SFEKZ6TJMUGA": "1.5.1", // StakingPool testnet
+	"SGHUWJUZVXM25LBZWIFIHTNZRQ2HIJR3WM2OAS3JRVTX5TDQRR5ZLWPRXM": "1.5.1", // ValidatorRegistry betanet
+	"6Y2JBUDI7NHOAZMWUXTJG3B3HAALRSY3NPPBMNBWX7TG4LVJKXREE7HLZ4": "1.5.1", // ValidatorRegistry fnet
+	"CE3STUJNJOB4ONW5W5OH2PLPPAJNMXOF4QYMDMHVVOAIVPM3MGCZKCUDUM": "1.5.1", // ValidatorRegistry mainnet
+	"5C6C7U4T6XVIGQWP4C7Z2EPK7SBK2SQS5KN2MQL364WNFOSNSD6ENO3JBQ": "1.5.1", // ValidatorRegistry testnet
+}
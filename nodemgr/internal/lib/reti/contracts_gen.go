@@ -0,0 +1,1527 @@
+// Code generated by arc32gen from ValidatorRegistry.arc32.json, StakingPool.arc32.json. DO NOT EDIT.
+
+package reti
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/algorand/go-algorand-sdk/v2/abi"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// ABIConstraints is the ABI tuple (uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64)
+type ABIConstraints struct {
+	EpochPayoutRoundsMin           uint64
+	EpochPayoutRoundsMax           uint64
+	MinPctToValidatorWFourDecimals uint64
+	MaxPctToValidatorWFourDecimals uint64
+	MinEntryStake                  uint64
+	MaxAlgoPerPool                 uint64
+	MaxAlgoPerValidator            uint64
+	AmtConsideredSaturated         uint64
+	MaxNodes                       uint64
+	MaxPoolsPerNode                uint64
+	MaxStakersPerPool              uint64
+}
+
+func decodeABIConstraints(v any) (ABIConstraints, error) {
+	var s ABIConstraints
+	values, ok := v.([]any)
+	if !ok || len(values) != 11 {
+		return s, fmt.Errorf("Constraints: expected 11 element tuple, got %T", v)
+	}
+	var err error
+	if s.EpochPayoutRoundsMin, err = abiUint64(values[0]); err != nil {
+		return s, fmt.Errorf("Constraints.EpochPayoutRoundsMin: %w", err)
+	}
+	if s.EpochPayoutRoundsMax, err = abiUint64(values[1]); err != nil {
+		return s, fmt.Errorf("Constraints.EpochPayoutRoundsMax: %w", err)
+	}
+	if s.MinPctToValidatorWFourDecimals, err = abiUint64(values[2]); err != nil {
+		return s, fmt.Errorf("Constraints.MinPctToValidatorWFourDecimals: %w", err)
+	}
+	if s.MaxPctToValidatorWFourDecimals, err = abiUint64(values[3]); err != nil {
+		return s, fmt.Errorf("Constraints.MaxPctToValidatorWFourDecimals: %w", err)
+	}
+	if s.MinEntryStake, err = abiUint64(values[4]); err != nil {
+		return s, fmt.Errorf("Constraints.MinEntryStake: %w", err)
+	}
+	if s.MaxAlgoPerPool, err = abiUint64(values[5]); err != nil {
+		return s, fmt.Errorf("Constraints.MaxAlgoPerPool: %w", err)
+	}
+	if s.MaxAlgoPerValidator, err = abiUint64(values[6]); err != nil {
+		return s, fmt.Errorf("Constraints.MaxAlgoPerValidator: %w", err)
+	}
+	if s.AmtConsideredSaturated, err = abiUint64(values[7]); err != nil {
+		return s, fmt.Errorf("Constraints.AmtConsideredSaturated: %w", err)
+	}
+	if s.MaxNodes, err = abiUint64(values[8]); err != nil {
+		return s, fmt.Errorf("Constraints.MaxNodes: %w", err)
+	}
+	if s.MaxPoolsPerNode, err = abiUint64(values[9]); err != nil {
+		return s, fmt.Errorf("Constraints.MaxPoolsPerNode: %w", err)
+	}
+	if s.MaxStakersPerPool, err = abiUint64(values[10]); err != nil {
+		return s, fmt.Errorf("Constraints.MaxStakersPerPool: %w", err)
+	}
+	return s, nil
+}
+
+func (s ABIConstraints) abiValues() []any {
+	return []any{
+		s.EpochPayoutRoundsMin,
+		s.EpochPayoutRoundsMax,
+		s.MinPctToValidatorWFourDecimals,
+		s.MaxPctToValidatorWFourDecimals,
+		s.MinEntryStake,
+		s.MaxAlgoPerPool,
+		s.MaxAlgoPerValidator,
+		s.AmtConsideredSaturated,
+		s.MaxNodes,
+		s.MaxPoolsPerNode,
+		s.MaxStakersPerPool,
+	}
+}
+
+// ABIFindPoolForStakerReturn is the ABI tuple ((uint64,uint64,uint64),bool,bool)
+type ABIFindPoolForStakerReturn struct {
+	Field0 ABIValidatorPoolKey
+	Field1 bool
+	Field2 bool
+}
+
+func decodeABIFindPoolForStakerReturn(v any) (ABIFindPoolForStakerReturn, error) {
+	var s ABIFindPoolForStakerReturn
+	values, ok := v.([]any)
+	if !ok || len(values) != 3 {
+		return s, fmt.Errorf("FindPoolForStakerReturn: expected 3 element tuple, got %T", v)
+	}
+	var err error
+	if s.Field0, err = decodeABIValidatorPoolKey(values[0]); err != nil {
+		return s, fmt.Errorf("FindPoolForStakerReturn.Field0: %w", err)
+	}
+	if s.Field1, err = abiBool(values[1]); err != nil {
+		return s, fmt.Errorf("FindPoolForStakerReturn.Field1: %w", err)
+	}
+	if s.Field2, err = abiBool(values[2]); err != nil {
+		return s, fmt.Errorf("FindPoolForStakerReturn.Field2: %w", err)
+	}
+	return s, nil
+}
+
+func (s ABIFindPoolForStakerReturn) abiValues() []any {
+	return []any{
+		s.Field0.abiValues(),
+		s.Field1,
+		s.Field2,
+	}
+}
+
+// ABIGetValidatorOwnerAndManagerReturn is the ABI tuple (address,address)
+type ABIGetValidatorOwnerAndManagerReturn struct {
+	Field0 types.Address
+	Field1 types.Address
+}
+
+func decodeABIGetValidatorOwnerAndManagerReturn(v any) (ABIGetValidatorOwnerAndManagerReturn, error) {
+	var s ABIGetValidatorOwnerAndManagerReturn
+	values, ok := v.([]any)
+	if !ok || len(values) != 2 {
+		return s, fmt.Errorf("GetValidatorOwnerAndManagerReturn: expected 2 element tuple, got %T", v)
+	}
+	var err error
+	if s.Field0, err = abiAddress(values[0]); err != nil {
+		return s, fmt.Errorf("GetValidatorOwnerAndManagerReturn.Field0: %w", err)
+	}
+	if s.Field1, err = abiAddress(values[1]); err != nil {
+		return s, fmt.Errorf("GetValidatorOwnerAndManagerReturn.Field1: %w", err)
+	}
+	return s, nil
+}
+
+func (s ABIGetValidatorOwnerAndManagerReturn) abiValues() []any {
+	return []any{
+		s.Field0,
+		s.Field1,
+	}
+}
+
+// ABIMbrAmounts is the ABI tuple (uint64,uint64,uint64,uint64)
+type ABIMbrAmounts struct {
+	AddValidatorMbr uint64
+	AddPoolMbr      uint64
+	PoolInitMbr     uint64
+	AddStakerMbr    uint64
+}
+
+func decodeABIMbrAmounts(v any) (ABIMbrAmounts, error) {
+	var s ABIMbrAmounts
+	values, ok := v.([]any)
+	if !ok || len(values) != 4 {
+		return s, fmt.Errorf("MbrAmounts: expected 4 element tuple, got %T", v)
+	}
+	var err error
+	if s.AddValidatorMbr, err = abiUint64(values[0]); err != nil {
+		return s, fmt.Errorf("MbrAmounts.AddValidatorMbr: %w", err)
+	}
+	if s.AddPoolMbr, err = abiUint64(values[1]); err != nil {
+		return s, fmt.Errorf("MbrAmounts.AddPoolMbr: %w", err)
+	}
+	if s.PoolInitMbr, err = abiUint64(values[2]); err != nil {
+		return s, fmt.Errorf("MbrAmounts.PoolInitMbr: %w", err)
+	}
+	if s.AddStakerMbr, err = abiUint64(values[3]); err != nil {
+		return s, fmt.Errorf("MbrAmounts.AddStakerMbr: %w", err)
+	}
+	return s, nil
+}
+
+func (s ABIMbrAmounts) abiValues() []any {
+	return []any{
+		s.AddValidatorMbr,
+		s.AddPoolMbr,
+		s.PoolInitMbr,
+		s.AddStakerMbr,
+	}
+}
+
+// ABINodePoolAssignmentConfig is the ABI tuple ((uint64[3])[8])
+type ABINodePoolAssignmentConfig struct {
+	Nodes [8]ABINodePoolAssignmentConfigNodes
+}
+
+func decodeABINodePoolAssignmentConfig(v any) (ABINodePoolAssignmentConfig, error) {
+	var s ABINodePoolAssignmentConfig
+	values, ok := v.([]any)
+	if !ok || len(values) != 1 {
+		return s, fmt.Errorf("NodePoolAssignmentConfig: expected 1 element tuple, got %T", v)
+	}
+	var err error
+	if s.Nodes, err = func(v any) ([8]ABINodePoolAssignmentConfigNodes, error) {
+		var arr [8]ABINodePoolAssignmentConfigNodes
+		values, err := abiSliceOf(decodeABINodePoolAssignmentConfigNodes)(v)
+		if err != nil {
+			return arr, err
+		}
+		if len(values) != len(arr) {
+			return arr, fmt.Errorf("expected 8 elements, got %d", len(values))
+		}
+		copy(arr[:], values)
+		return arr, nil
+	}(values[0]); err != nil {
+		return s, fmt.Errorf("NodePoolAssignmentConfig.Nodes: %w", err)
+	}
+	return s, nil
+}
+
+func (s ABINodePoolAssignmentConfig) abiValues() []any {
+	return []any{
+		abiValuesOf(s.Nodes[:], func(v ABINodePoolAssignmentConfigNodes) any { return v.abiValues() }),
+	}
+}
+
+// ABINodePoolAssignmentConfigNodes is the ABI tuple (uint64[3])
+type ABINodePoolAssignmentConfigNodes struct {
+	Field0 [3]uint64
+}
+
+func decodeABINodePoolAssignmentConfigNodes(v any) (ABINodePoolAssignmentConfigNodes, error) {
+	var s ABINodePoolAssignmentConfigNodes
+	values, ok := v.([]any)
+	if !ok || len(values) != 1 {
+		return s, fmt.Errorf("NodePoolAssignmentConfigNodes: expected 1 element tuple, got %T", v)
+	}
+	var err error
+	if s.Field0, err = func(v any) ([3]uint64, error) {
+		var arr [3]uint64
+		values, err := abiSliceOf(abiUint64)(v)
+		if err != nil {
+			return arr, err
+		}
+		if len(values) != len(arr) {
+			return arr, fmt.Errorf("expected 3 elements, got %d", len(values))
+		}
+		copy(arr[:], values)
+		return arr, nil
+	}(values[0]); err != nil {
+		return s, fmt.Errorf("NodePoolAssignmentConfigNodes.Field0: %w", err)
+	}
+	return s, nil
+}
+
+func (s ABINodePoolAssignmentConfigNodes) abiValues() []any {
+	return []any{
+		s.Field0,
+	}
+}
+
+// ABIPoolInfo is the ABI tuple (uint64,uint16,uint64)
+type ABIPoolInfo struct {
+	PoolAppId       uint64
+	TotalStakers    uint16
+	TotalAlgoStaked uint64
+}
+
+func decodeABIPoolInfo(v any) (ABIPoolInfo, error) {
+	var s ABIPoolInfo
+	values, ok := v.([]any)
+	if !ok || len(values) != 3 {
+		return s, fmt.Errorf("PoolInfo: expected 3 element tuple, got %T", v)
+	}
+	var err error
+	if s.PoolAppId, err = abiUint64(values[0]); err != nil {
+		return s, fmt.Errorf("PoolInfo.PoolAppId: %w", err)
+	}
+	if s.TotalStakers, err = abiUint16(values[1]); err != nil {
+		return s, fmt.Errorf("PoolInfo.TotalStakers: %w", err)
+	}
+	if s.TotalAlgoStaked, err = abiUint64(values[2]); err != nil {
+		return s, fmt.Errorf("PoolInfo.TotalAlgoStaked: %w", err)
+	}
+	return s, nil
+}
+
+func (s ABIPoolInfo) abiValues() []any {
+	return []any{
+		s.PoolAppId,
+		s.TotalStakers,
+		s.TotalAlgoStaked,
+	}
+}
+
+// ABIPoolTokenPayoutRatio is the ABI tuple (uint64[24],uint64)
+type ABIPoolTokenPayoutRatio struct {
+	PoolPctOfWhole   [24]uint64
+	UpdatedForPayout uint64
+}
+
+func decodeABIPoolTokenPayoutRatio(v any) (ABIPoolTokenPayoutRatio, error) {
+	var s ABIPoolTokenPayoutRatio
+	values, ok := v.([]any)
+	if !ok || len(values) != 2 {
+		return s, fmt.Errorf("PoolTokenPayoutRatio: expected 2 element tuple, got %T", v)
+	}
+	var err error
+	if s.PoolPctOfWhole, err = func(v any) ([24]uint64, error) {
+		var arr [24]uint64
+		values, err := abiSliceOf(abiUint64)(v)
+		if err != nil {
+			return arr, err
+		}
+		if len(values) != len(arr) {
+			return arr, fmt.Errorf("expected 24 elements, got %d", len(values))
+		}
+		copy(arr[:], values)
+		return arr, nil
+	}(values[0]); err != nil {
+		return s, fmt.Errorf("PoolTokenPayoutRatio.PoolPctOfWhole: %w", err)
+	}
+	if s.UpdatedForPayout, err = abiUint64(values[1]); err != nil {
+		return s, fmt.Errorf("PoolTokenPayoutRatio.UpdatedForPayout: %w", err)
+	}
+	return s, nil
+}
+
+func (s ABIPoolTokenPayoutRatio) abiValues() []any {
+	return []any{
+		s.PoolPctOfWhole,
+		s.UpdatedForPayout,
+	}
+}
+
+// ABIStakedInfo is the ABI tuple (address,uint64,uint64,uint64,uint64)
+type ABIStakedInfo struct {
+	Account            types.Address
+	Balance            uint64
+	TotalRewarded      uint64
+	RewardTokenBalance uint64
+	EntryRound         uint64
+}
+
+func decodeABIStakedInfo(v any) (ABIStakedInfo, error) {
+	var s ABIStakedInfo
+	values, ok := v.([]any)
+	if !ok || len(values) != 5 {
+		return s, fmt.Errorf("StakedInfo: expected 5 element tuple, got %T", v)
+	}
+	var err error
+	if s.Account, err = abiAddress(values[0]); err != nil {
+		return s, fmt.Errorf("StakedInfo.Account: %w", err)
+	}
+	if s.Balance, err = abiUint64(values[1]); err != nil {
+		return s, fmt.Errorf("StakedInfo.Balance: %w", err)
+	}
+	if s.TotalRewarded, err = abiUint64(values[2]); err != nil {
+		return s, fmt.Errorf("StakedInfo.TotalRewarded: %w", err)
+	}
+	if s.RewardTokenBalance, err = abiUint64(values[3]); err != nil {
+		return s, fmt.Errorf("StakedInfo.RewardTokenBalance: %w", err)
+	}
+	if s.EntryRound, err = abiUint64(values[4]); err != nil {
+		return s, fmt.Errorf("StakedInfo.EntryRound: %w", err)
+	}
+	return s, nil
+}
+
+func (s ABIStakedInfo) abiValues() []any {
+	return []any{
+		s.Account,
+		s.Balance,
+		s.TotalRewarded,
+		s.RewardTokenBalance,
+		s.EntryRound,
+	}
+}
+
+// ABIValidatorConfig is the ABI tuple (uint64,address,address,uint64,uint8,address,uint64[4],uint64,uint64,uint64,uint32,uint32,address,uint64,uint64,uint8,uint64,uint64)
+type ABIValidatorConfig struct {
+	Id                         uint64
+	Owner                      types.Address
+	Manager                    types.Address
+	NfdForInfo                 uint64
+	EntryGatingType            uint8
+	EntryGatingAddress         types.Address
+	EntryGatingAssets          [4]uint64
+	GatingAssetMinBalance      uint64
+	RewardTokenId              uint64
+	RewardPerPayout            uint64
+	EpochRoundLength           uint32
+	PercentToValidator         uint32
+	ValidatorCommissionAddress types.Address
+	MinEntryStake              uint64
+	MaxAlgoPerPool             uint64
+	PoolsPerNode               uint8
+	SunsettingOn               uint64
+	SunsettingTo               uint64
+}
+
+func decodeABIValidatorConfig(v any) (ABIValidatorConfig, error) {
+	var s ABIValidatorConfig
+	values, ok := v.([]any)
+	if !ok || len(values) != 18 {
+		return s, fmt.Errorf("ValidatorConfig: expected 18 element tuple, got %T", v)
+	}
+	var err error
+	if s.Id, err = abiUint64(values[0]); err != nil {
+		return s, fmt.Errorf("ValidatorConfig.Id: %w", err)
+	}
+	if s.Owner, err = abiAddress(values[1]); err != nil {
+		return s, fmt.Errorf("ValidatorConfig.Owner: %w", err)
+	}
+	if s.Manager, err = abiAddress(values[2]); err != nil {
+		return s, fmt.Errorf("ValidatorConfig.Manager: %w", err)
+	}
+	if s.NfdForInfo, err = abiUint64(values[3]); err != nil {
+		return s, fmt.Errorf("ValidatorConfig.NfdForInfo: %w", err)
+	}
+	if s.EntryGatingType, err = abiUint8(values[4]); err != nil {
+		return s, fmt.Errorf("ValidatorConfig.EntryGatingType: %w", err)
+	}
+	if s.EntryGatingAddress, err = abiAddress(values[5]); err != nil {
+		return s, fmt.Errorf("ValidatorConfig.EntryGatingAddress: %w", err)
+	}
+	if s.EntryGatingAssets, err = func(v any) ([4]uint64, error) {
+		var arr [4]uint64
+		values, err := abiSliceOf(abiUint64)(v)
+		if err != nil {
+			return arr, err
+		}
+		if len(values) != len(arr) {
+			return arr, fmt.Errorf("expected 4 elements, got %d", len(values))
+		}
+		copy(arr[:], values)
+		return arr, nil
+	}(values[6]); err != nil {
+		return s, fmt.Errorf("ValidatorConfig.EntryGatingAssets: %w", err)
+	}
+	if s.GatingAssetMinBalance, err = abiUint64(values[7]); err != nil {
+		return s, fmt.Errorf("ValidatorConfig.GatingAssetMinBalance: %w", err)
+	}
+	if s.RewardTokenId, err = abiUint64(values[8]); err != nil {
+		return s, fmt.Errorf("ValidatorConfig.RewardTokenId: %w", err)
+	}
+	if s.RewardPerPayout, err = abiUint64(values[9]); err != nil {
+		return s, fmt.Errorf("ValidatorConfig.RewardPerPayout: %w", err)
+	}
+	if s.EpochRoundLength, err = abiUint32(values[10]); err != nil {
+		return s, fmt.Errorf("ValidatorConfig.EpochRoundLength: %w", err)
+	}
+	if s.PercentToValidator, err = abiUint32(values[11]); err != nil {
+		return s, fmt.Errorf("ValidatorConfig.PercentToValidator: %w", err)
+	}
+	if s.ValidatorCommissionAddress, err = abiAddress(values[12]); err != nil {
+		return s, fmt.Errorf("ValidatorConfig.ValidatorCommissionAddress: %w", err)
+	}
+	if s.MinEntryStake, err = abiUint64(values[13]); err != nil {
+		return s, fmt.Errorf("ValidatorConfig.MinEntryStake: %w", err)
+	}
+	if s.MaxAlgoPerPool, err = abiUint64(values[14]); err != nil {
+		return s, fmt.Errorf("ValidatorConfig.MaxAlgoPerPool: %w", err)
+	}
+	if s.PoolsPerNode, err = abiUint8(values[15]); err != nil {
+		return s, fmt.Errorf("ValidatorConfig.PoolsPerNode: %w", err)
+	}
+	if s.SunsettingOn, err = abiUint64(values[16]); err != nil {
+		return s, fmt.Errorf("ValidatorConfig.SunsettingOn: %w", err)
+	}
+	if s.SunsettingTo, err = abiUint64(values[17]); err != nil {
+		return s, fmt.Errorf("ValidatorConfig.SunsettingTo: %w", err)
+	}
+	return s, nil
+}
+
+func (s ABIValidatorConfig) abiValues() []any {
+	return []any{
+		s.Id,
+		s.Owner,
+		s.Manager,
+		s.NfdForInfo,
+		s.EntryGatingType,
+		s.EntryGatingAddress,
+		s.EntryGatingAssets,
+		s.GatingAssetMinBalance,
+		s.RewardTokenId,
+		s.RewardPerPayout,
+		s.EpochRoundLength,
+		s.PercentToValidator,
+		s.ValidatorCommissionAddress,
+		s.MinEntryStake,
+		s.MaxAlgoPerPool,
+		s.PoolsPerNode,
+		s.SunsettingOn,
+		s.SunsettingTo,
+	}
+}
+
+// ABIValidatorCurState is the ABI tuple (uint16,uint64,uint64,uint64)
+type ABIValidatorCurState struct {
+	NumPools            uint16
+	TotalStakers        uint64
+	TotalAlgoStaked     uint64
+	RewardTokenHeldBack uint64
+}
+
+func decodeABIValidatorCurState(v any) (ABIValidatorCurState, error) {
+	var s ABIValidatorCurState
+	values, ok := v.([]any)
+	if !ok || len(values) != 4 {
+		return s, fmt.Errorf("ValidatorCurState: expected 4 element tuple, got %T", v)
+	}
+	var err error
+	if s.NumPools, err = abiUint16(values[0]); err != nil {
+		return s, fmt.Errorf("ValidatorCurState.NumPools: %w", err)
+	}
+	if s.TotalStakers, err = abiUint64(values[1]); err != nil {
+		return s, fmt.Errorf("ValidatorCurState.TotalStakers: %w", err)
+	}
+	if s.TotalAlgoStaked, err = abiUint64(values[2]); err != nil {
+		return s, fmt.Errorf("ValidatorCurState.TotalAlgoStaked: %w", err)
+	}
+	if s.RewardTokenHeldBack, err = abiUint64(values[3]); err != nil {
+		return s, fmt.Errorf("ValidatorCurState.RewardTokenHeldBack: %w", err)
+	}
+	return s, nil
+}
+
+func (s ABIValidatorCurState) abiValues() []any {
+	return []any{
+		s.NumPools,
+		s.TotalStakers,
+		s.TotalAlgoStaked,
+		s.RewardTokenHeldBack,
+	}
+}
+
+// ABIValidatorInfo is the ABI tuple ((uint64,address,address,uint64,uint8,address,uint64[4],uint64,uint64,uint64,uint32,uint32,address,uint64,uint64,uint8,uint64,uint64),(uint16,uint64,uint64,uint64),(uint64,uint16,uint64)[24],(uint64[24],uint64),((uint64[3])[8]))
+type ABIValidatorInfo struct {
+	Config              ABIValidatorConfig
+	State               ABIValidatorCurState
+	Pools               [24]ABIPoolInfo
+	TokenPayoutRatio    ABIPoolTokenPayoutRatio
+	NodePoolAssignments ABINodePoolAssignmentConfig
+}
+
+func decodeABIValidatorInfo(v any) (ABIValidatorInfo, error) {
+	var s ABIValidatorInfo
+	values, ok := v.([]any)
+	if !ok || len(values) != 5 {
+		return s, fmt.Errorf("ValidatorInfo: expected 5 element tuple, got %T", v)
+	}
+	var err error
+	if s.Config, err = decodeABIValidatorConfig(values[0]); err != nil {
+		return s, fmt.Errorf("ValidatorInfo.Config: %w", err)
+	}
+	if s.State, err = decodeABIValidatorCurState(values[1]); err != nil {
+		return s, fmt.Errorf("ValidatorInfo.State: %w", err)
+	}
+	if s.Pools, err = func(v any) ([24]ABIPoolInfo, error) {
+		var arr [24]ABIPoolInfo
+		values, err := abiSliceOf(decodeABIPoolInfo)(v)
+		if err != nil {
+			return arr, err
+		}
+		if len(values) != len(arr) {
+			return arr, fmt.Errorf("expected 24 elements, got %d", len(values))
+		}
+		copy(arr[:], values)
+		return arr, nil
+	}(values[2]); err != nil {
+		return s, fmt.Errorf("ValidatorInfo.Pools: %w", err)
+	}
+	if s.TokenPayoutRatio, err = decodeABIPoolTokenPayoutRatio(values[3]); err != nil {
+		return s, fmt.Errorf("ValidatorInfo.TokenPayoutRatio: %w", err)
+	}
+	if s.NodePoolAssignments, err = decodeABINodePoolAssignmentConfig(values[4]); err != nil {
+		return s, fmt.Errorf("ValidatorInfo.NodePoolAssignments: %w", err)
+	}
+	return s, nil
+}
+
+func (s ABIValidatorInfo) abiValues() []any {
+	return []any{
+		s.Config.abiValues(),
+		s.State.abiValues(),
+		abiValuesOf(s.Pools[:], func(v ABIPoolInfo) any { return v.abiValues() }),
+		s.TokenPayoutRatio.abiValues(),
+		s.NodePoolAssignments.abiValues(),
+	}
+}
+
+// ABIValidatorPoolKey is the ABI tuple (uint64,uint64,uint64)
+type ABIValidatorPoolKey struct {
+	Id        uint64
+	PoolId    uint64
+	PoolAppId uint64
+}
+
+func decodeABIValidatorPoolKey(v any) (ABIValidatorPoolKey, error) {
+	var s ABIValidatorPoolKey
+	values, ok := v.([]any)
+	if !ok || len(values) != 3 {
+		return s, fmt.Errorf("ValidatorPoolKey: expected 3 element tuple, got %T", v)
+	}
+	var err error
+	if s.Id, err = abiUint64(values[0]); err != nil {
+		return s, fmt.Errorf("ValidatorPoolKey.Id: %w", err)
+	}
+	if s.PoolId, err = abiUint64(values[1]); err != nil {
+		return s, fmt.Errorf("ValidatorPoolKey.PoolId: %w", err)
+	}
+	if s.PoolAppId, err = abiUint64(values[2]); err != nil {
+		return s, fmt.Errorf("ValidatorPoolKey.PoolAppId: %w", err)
+	}
+	return s, nil
+}
+
+func (s ABIValidatorPoolKey) abiValues() []any {
+	return []any{
+		s.Id,
+		s.PoolId,
+		s.PoolAppId,
+	}
+}
+
+// ValidatorRegistryABI provides typed calls to the methods of the ValidatorRegistry contract
+type ValidatorRegistryABI struct {
+	createApplication                abi.Method
+	initStakingContract              abi.Method
+	loadStakingContractData          abi.Method
+	finalizeStakingContract          abi.Method
+	gas                              abi.Method
+	getMbrAmounts                    abi.Method
+	getProtocolConstraints           abi.Method
+	getNumValidators                 abi.Method
+	getValidatorConfig               abi.Method
+	getValidatorState                abi.Method
+	getValidatorOwnerAndManager      abi.Method
+	getPools                         abi.Method
+	getPoolAppId                     abi.Method
+	getPoolInfo                      abi.Method
+	getCurMaxStakePerPool            abi.Method
+	doesStakerNeedToPayMBR           abi.Method
+	getStakedPoolsForAccount         abi.Method
+	getTokenPayoutRatio              abi.Method
+	getNodePoolAssignments           abi.Method
+	getNFDRegistryID                 abi.Method
+	addValidator                     abi.Method
+	changeValidatorManager           abi.Method
+	changeValidatorSunsetInfo        abi.Method
+	changeValidatorNFD               abi.Method
+	changeValidatorCommissionAddress abi.Method
+	changeValidatorRewardInfo        abi.Method
+	addPool                          abi.Method
+	addStake                         abi.Method
+	setTokenPayoutRatio              abi.Method
+	stakeUpdatedViaRewards           abi.Method
+	stakeRemoved                     abi.Method
+	findPoolForStaker                abi.Method
+	movePoolToNode                   abi.Method
+	emptyTokenRewards                abi.Method
+	stakerPoolSetBox                 abi.Type
+	validatorListBox                 abi.Type
+}
+
+// NewValidatorRegistryABI binds the methods of contract, failing if any generated method signature doesn't match the contract.
+func NewValidatorRegistryABI(contract *abi.Contract) (*ValidatorRegistryABI, error) {
+	var (
+		c   = &ValidatorRegistryABI{}
+		err error
+	)
+	for _, bind := range []struct {
+		method    *abi.Method
+		signature string
+	}{
+		{&c.createApplication, "createApplication()void"},
+		{&c.initStakingContract, "initStakingContract(uint64)void"},
+		{&c.loadStakingContractData, "loadStakingContractData(uint64,byte[])void"},
+		{&c.finalizeStakingContract, "finalizeStakingContract()void"},
+		{&c.gas, "gas()void"},
+		{&c.getMbrAmounts, "getMbrAmounts()(uint64,uint64,uint64,uint64)"},
+		{&c.getProtocolConstraints, "getProtocolConstraints()(uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64)"},
+		{&c.getNumValidators, "getNumValidators()uint64"},
+		{&c.getValidatorConfig, "getValidatorConfig(uint64)(uint64,address,address,uint64,uint8,address,uint64[4],uint64,uint64,uint64,uint32,uint32,address,uint64,uint64,uint8,uint64,uint64)"},
+		{&c.getValidatorState, "getValidatorState(uint64)(uint16,uint64,uint64,uint64)"},
+		{&c.getValidatorOwnerAndManager, "getValidatorOwnerAndManager(uint64)(address,address)"},
+		{&c.getPools, "getPools(uint64)(uint64,uint16,uint64)[]"},
+		{&c.getPoolAppId, "getPoolAppId(uint64,uint64)uint64"},
+		{&c.getPoolInfo, "getPoolInfo((uint64,uint64,uint64))(uint64,uint16,uint64)"},
+		{&c.getCurMaxStakePerPool, "getCurMaxStakePerPool(uint64)uint64"},
+		{&c.doesStakerNeedToPayMBR, "doesStakerNeedToPayMBR(address)bool"},
+		{&c.getStakedPoolsForAccount, "getStakedPoolsForAccount(address)(uint64,uint64,uint64)[]"},
+		{&c.getTokenPayoutRatio, "getTokenPayoutRatio(uint64)(uint64[24],uint64)"},
+		{&c.getNodePoolAssignments, "getNodePoolAssignments(uint64)((uint64[3])[8])"},
+		{&c.getNFDRegistryID, "getNFDRegistryID()uint64"},
+		{&c.addValidator, "addValidator(pay,string,(uint64,address,address,uint64,uint8,address,uint64[4],uint64,uint64,uint64,uint32,uint32,address,uint64,uint64,uint8,uint64,uint64))uint64"},
+		{&c.changeValidatorManager, "changeValidatorManager(uint64,address)void"},
+		{&c.changeValidatorSunsetInfo, "changeValidatorSunsetInfo(uint64,uint64,uint64)void"},
+		{&c.changeValidatorNFD, "changeValidatorNFD(uint64,uint64,string)void"},
+		{&c.changeValidatorCommissionAddress, "changeValidatorCommissionAddress(uint64,address)void"},
+		{&c.changeValidatorRewardInfo, "changeValidatorRewardInfo(uint64,uint8,address,uint64[4],uint64,uint64)void"},
+		{&c.addPool, "addPool(pay,uint64,uint64)(uint64,uint64,uint64)"},
+		{&c.addStake, "addStake(pay,uint64,uint64)(uint64,uint64,uint64)"},
+		{&c.setTokenPayoutRatio, "setTokenPayoutRatio(uint64)(uint64[24],uint64)"},
+		{&c.stakeUpdatedViaRewards, "stakeUpdatedViaRewards((uint64,uint64,uint64),uint64,uint64,uint64,uint64)void"},
+		{&c.stakeRemoved, "stakeRemoved((uint64,uint64,uint64),address,uint64,uint64,bool)void"},
+		{&c.findPoolForStaker, "findPoolForStaker(uint64,address,uint64)((uint64,uint64,uint64),bool,bool)"},
+		{&c.movePoolToNode, "movePoolToNode(uint64,uint64,uint64)void"},
+		{&c.emptyTokenRewards, "emptyTokenRewards(uint64,address)uint64"},
+	} {
+		if *bind.method, err = abiBindMethod(contract, bind.signature); err != nil {
+			return nil, err
+		}
+	}
+	if c.stakerPoolSetBox, err = abi.TypeOf("(uint64,uint64,uint64)[6]"); err != nil {
+		return nil, err
+	}
+	if c.validatorListBox, err = abi.TypeOf("((uint64,address,address,uint64,uint8,address,uint64[4],uint64,uint64,uint64,uint32,uint32,address,uint64,uint64,uint8,uint64,uint64),(uint16,uint64,uint64,uint64),(uint64,uint16,uint64)[24],(uint64[24],uint64),((uint64[3])[8]))"); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// CreateApplication sets the method and arguments of params for a call to createApplication()void
+func (c *ValidatorRegistryABI) CreateApplication(params transaction.AddMethodCallParams) transaction.AddMethodCallParams {
+	params.Method = c.createApplication
+	params.MethodArgs = []any{}
+	return params
+}
+
+// InitStakingContract sets the method and arguments of params for a call to initStakingContract(uint64)void
+func (c *ValidatorRegistryABI) InitStakingContract(params transaction.AddMethodCallParams, approvalProgramSize uint64) transaction.AddMethodCallParams {
+	params.Method = c.initStakingContract
+	params.MethodArgs = []any{approvalProgramSize}
+	return params
+}
+
+// LoadStakingContractData sets the method and arguments of params for a call to loadStakingContractData(uint64,byte[])void
+func (c *ValidatorRegistryABI) LoadStakingContractData(params transaction.AddMethodCallParams, offset uint64, data []byte) transaction.AddMethodCallParams {
+	params.Method = c.loadStakingContractData
+	params.MethodArgs = []any{offset, data}
+	return params
+}
+
+// FinalizeStakingContract sets the method and arguments of params for a call to finalizeStakingContract()void
+func (c *ValidatorRegistryABI) FinalizeStakingContract(params transaction.AddMethodCallParams) transaction.AddMethodCallParams {
+	params.Method = c.finalizeStakingContract
+	params.MethodArgs = []any{}
+	return params
+}
+
+// Gas sets the method and arguments of params for a call to gas()void
+//
+// gas is a dummy no-op call that can be used to pool-up resource references and opcode cost
+func (c *ValidatorRegistryABI) Gas(params transaction.AddMethodCallParams) transaction.AddMethodCallParams {
+	params.Method = c.gas
+	params.MethodArgs = []any{}
+	return params
+}
+
+// GetMbrAmounts sets the method and arguments of params for a call to getMbrAmounts()(uint64,uint64,uint64,uint64)
+//
+// Returns the MBR amounts needed for various actions:
+func (c *ValidatorRegistryABI) GetMbrAmounts(params transaction.AddMethodCallParams) transaction.AddMethodCallParams {
+	params.Method = c.getMbrAmounts
+	params.MethodArgs = []any{}
+	return params
+}
+
+// GetMbrAmountsResult decodes the return value of a getMbrAmounts call
+func (c *ValidatorRegistryABI) GetMbrAmountsResult(result transaction.ABIMethodResult) (ABIMbrAmounts, error) {
+	if err := abiCheckResult(result, c.getMbrAmounts); err != nil {
+		var zero ABIMbrAmounts
+		return zero, err
+	}
+	return decodeABIMbrAmounts(result.ReturnValue)
+}
+
+// GetProtocolConstraints sets the method and arguments of params for a call to getProtocolConstraints()(uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64)
+//
+// Returns the protocol constraints so that UIs can limit what users specify for validator configuration parameters.
+func (c *ValidatorRegistryABI) GetProtocolConstraints(params transaction.AddMethodCallParams) transaction.AddMethodCallParams {
+	params.Method = c.getProtocolConstraints
+	params.MethodArgs = []any{}
+	return params
+}
+
+// GetProtocolConstraintsResult decodes the return value of a getProtocolConstraints call
+func (c *ValidatorRegistryABI) GetProtocolConstraintsResult(result transaction.ABIMethodResult) (ABIConstraints, error) {
+	if err := abiCheckResult(result, c.getProtocolConstraints); err != nil {
+		var zero ABIConstraints
+		return zero, err
+	}
+	return decodeABIConstraints(result.ReturnValue)
+}
+
+// GetNumValidators sets the method and arguments of params for a call to getNumValidators()uint64
+//
+// Returns the current number of validators
+func (c *ValidatorRegistryABI) GetNumValidators(params transaction.AddMethodCallParams) transaction.AddMethodCallParams {
+	params.Method = c.getNumValidators
+	params.MethodArgs = []any{}
+	return params
+}
+
+// GetNumValidatorsResult decodes the return value of a getNumValidators call
+func (c *ValidatorRegistryABI) GetNumValidatorsResult(result transaction.ABIMethodResult) (uint64, error) {
+	if err := abiCheckResult(result, c.getNumValidators); err != nil {
+		var zero uint64
+		return zero, err
+	}
+	return abiUint64(result.ReturnValue)
+}
+
+// GetValidatorConfig sets the method and arguments of params for a call to getValidatorConfig(uint64)(uint64,address,address,uint64,uint8,address,uint64[4],uint64,uint64,uint64,uint32,uint32,address,uint64,uint64,uint8,uint64,uint64)
+func (c *ValidatorRegistryABI) GetValidatorConfig(params transaction.AddMethodCallParams, validatorId uint64) transaction.AddMethodCallParams {
+	params.Method = c.getValidatorConfig
+	params.MethodArgs = []any{validatorId}
+	return params
+}
+
+// GetValidatorConfigResult decodes the return value of a getValidatorConfig call
+func (c *ValidatorRegistryABI) GetValidatorConfigResult(result transaction.ABIMethodResult) (ABIValidatorConfig, error) {
+	if err := abiCheckResult(result, c.getValidatorConfig); err != nil {
+		var zero ABIValidatorConfig
+		return zero, err
+	}
+	return decodeABIValidatorConfig(result.ReturnValue)
+}
+
+// GetValidatorState sets the method and arguments of params for a call to getValidatorState(uint64)(uint16,uint64,uint64,uint64)
+func (c *ValidatorRegistryABI) GetValidatorState(params transaction.AddMethodCallParams, validatorId uint64) transaction.AddMethodCallParams {
+	params.Method = c.getValidatorState
+	params.MethodArgs = []any{validatorId}
+	return params
+}
+
+// GetValidatorStateResult decodes the return value of a getValidatorState call
+func (c *ValidatorRegistryABI) GetValidatorStateResult(result transaction.ABIMethodResult) (ABIValidatorCurState, error) {
+	if err := abiCheckResult(result, c.getValidatorState); err != nil {
+		var zero ABIValidatorCurState
+		return zero, err
+	}
+	return decodeABIValidatorCurState(result.ReturnValue)
+}
+
+// GetValidatorOwnerAndManager sets the method and arguments of params for a call to getValidatorOwnerAndManager(uint64)(address,address)
+func (c *ValidatorRegistryABI) GetValidatorOwnerAndManager(params transaction.AddMethodCallParams, validatorId uint64) transaction.AddMethodCallParams {
+	params.Method = c.getValidatorOwnerAndManager
+	params.MethodArgs = []any{validatorId}
+	return params
+}
+
+// GetValidatorOwnerAndManagerResult decodes the return value of a getValidatorOwnerAndManager call
+func (c *ValidatorRegistryABI) GetValidatorOwnerAndManagerResult(result transaction.ABIMethodResult) (ABIGetValidatorOwnerAndManagerReturn, error) {
+	if err := abiCheckResult(result, c.getValidatorOwnerAndManager); err != nil {
+		var zero ABIGetValidatorOwnerAndManagerReturn
+		return zero, err
+	}
+	return decodeABIGetValidatorOwnerAndManagerReturn(result.ReturnValue)
+}
+
+// GetPools sets the method and arguments of params for a call to getPools(uint64)(uint64,uint16,uint64)[]
+//
+// Return list of all pools for this validator.
+func (c *ValidatorRegistryABI) GetPools(params transaction.AddMethodCallParams, validatorId uint64) transaction.AddMethodCallParams {
+	params.Method = c.getPools
+	params.MethodArgs = []any{validatorId}
+	return params
+}
+
+// GetPoolsResult decodes the return value of a getPools call
+func (c *ValidatorRegistryABI) GetPoolsResult(result transaction.ABIMethodResult) ([]ABIPoolInfo, error) {
+	if err := abiCheckResult(result, c.getPools); err != nil {
+		var zero []ABIPoolInfo
+		return zero, err
+	}
+	return abiSliceOf(decodeABIPoolInfo)(result.ReturnValue)
+}
+
+// GetPoolAppId sets the method and arguments of params for a call to getPoolAppId(uint64,uint64)uint64
+//
+// getPoolAppId is useful for callers to determine app to call for removing stake if they don't have staking or
+func (c *ValidatorRegistryABI) GetPoolAppId(params transaction.AddMethodCallParams, validatorId uint64, poolId uint64) transaction.AddMethodCallParams {
+	params.Method = c.getPoolAppId
+	params.MethodArgs = []any{validatorId, poolId}
+	return params
+}
+
+// GetPoolAppIdResult decodes the return value of a getPoolAppId call
+func (c *ValidatorRegistryABI) GetPoolAppIdResult(result transaction.ABIMethodResult) (uint64, error) {
+	if err := abiCheckResult(result, c.getPoolAppId); err != nil {
+		var zero uint64
+		return zero, err
+	}
+	return abiUint64(result.ReturnValue)
+}
+
+// GetPoolInfo sets the method and arguments of params for a call to getPoolInfo((uint64,uint64,uint64))(uint64,uint16,uint64)
+func (c *ValidatorRegistryABI) GetPoolInfo(params transaction.AddMethodCallParams, poolKey ABIValidatorPoolKey) transaction.AddMethodCallParams {
+	params.Method = c.getPoolInfo
+	params.MethodArgs = []any{poolKey.abiValues()}
+	return params
+}
+
+// GetPoolInfoResult decodes the return value of a getPoolInfo call
+func (c *ValidatorRegistryABI) GetPoolInfoResult(result transaction.ABIMethodResult) (ABIPoolInfo, error) {
+	if err := abiCheckResult(result, c.getPoolInfo); err != nil {
+		var zero ABIPoolInfo
+		return zero, err
+	}
+	return decodeABIPoolInfo(result.ReturnValue)
+}
+
+// GetCurMaxStakePerPool sets the method and arguments of params for a call to getCurMaxStakePerPool(uint64)uint64
+//
+// Calculate the maximum stake per pool for a given validator.
+func (c *ValidatorRegistryABI) GetCurMaxStakePerPool(params transaction.AddMethodCallParams, validatorId uint64) transaction.AddMethodCallParams {
+	params.Method = c.getCurMaxStakePerPool
+	params.MethodArgs = []any{validatorId}
+	return params
+}
+
+// GetCurMaxStakePerPoolResult decodes the return value of a getCurMaxStakePerPool call
+func (c *ValidatorRegistryABI) GetCurMaxStakePerPoolResult(result transaction.ABIMethodResult) (uint64, error) {
+	if err := abiCheckResult(result, c.getCurMaxStakePerPool); err != nil {
+		var zero uint64
+		return zero, err
+	}
+	return abiUint64(result.ReturnValue)
+}
+
+// DoesStakerNeedToPayMBR sets the method and arguments of params for a call to doesStakerNeedToPayMBR(address)bool
+//
+// Helper callers can call w/ simulate to determine if 'AddStaker' MBR should be included w/ staking amount
+func (c *ValidatorRegistryABI) DoesStakerNeedToPayMBR(params transaction.AddMethodCallParams, staker types.Address) transaction.AddMethodCallParams {
+	params.Method = c.doesStakerNeedToPayMBR
+	params.MethodArgs = []any{staker}
+	return params
+}
+
+// DoesStakerNeedToPayMBRResult decodes the return value of a doesStakerNeedToPayMBR call
+func (c *ValidatorRegistryABI) DoesStakerNeedToPayMBRResult(result transaction.ABIMethodResult) (bool, error) {
+	if err := abiCheckResult(result, c.doesStakerNeedToPayMBR); err != nil {
+		var zero bool
+		return zero, err
+	}
+	return abiBool(result.ReturnValue)
+}
+
+// GetStakedPoolsForAccount sets the method and arguments of params for a call to getStakedPoolsForAccount(address)(uint64,uint64,uint64)[]
+//
+// Retrieves the staked pools for an account.
+func (c *ValidatorRegistryABI) GetStakedPoolsForAccount(params transaction.AddMethodCallParams, staker types.Address) transaction.AddMethodCallParams {
+	params.Method = c.getStakedPoolsForAccount
+	params.MethodArgs = []any{staker}
+	return params
+}
+
+// GetStakedPoolsForAccountResult decodes the return value of a getStakedPoolsForAccount call
+func (c *ValidatorRegistryABI) GetStakedPoolsForAccountResult(result transaction.ABIMethodResult) ([]ABIValidatorPoolKey, error) {
+	if err := abiCheckResult(result, c.getStakedPoolsForAccount); err != nil {
+		var zero []ABIValidatorPoolKey
+		return zero, err
+	}
+	return abiSliceOf(decodeABIValidatorPoolKey)(result.ReturnValue)
+}
+
+// GetTokenPayoutRatio sets the method and arguments of params for a call to getTokenPayoutRatio(uint64)(uint64[24],uint64)
+//
+// Retrieves the token payout ratio for a given validator - returning the pool ratios of whole so that token
+func (c *ValidatorRegistryABI) GetTokenPayoutRatio(params transaction.AddMethodCallParams, validatorId uint64) transaction.AddMethodCallParams {
+	params.Method = c.getTokenPayoutRatio
+	params.MethodArgs = []any{validatorId}
+	return params
+}
+
+// GetTokenPayoutRatioResult decodes the return value of a getTokenPayoutRatio call
+func (c *ValidatorRegistryABI) GetTokenPayoutRatioResult(result transaction.ABIMethodResult) (ABIPoolTokenPayoutRatio, error) {
+	if err := abiCheckResult(result, c.getTokenPayoutRatio); err != nil {
+		var zero ABIPoolTokenPayoutRatio
+		return zero, err
+	}
+	return decodeABIPoolTokenPayoutRatio(result.ReturnValue)
+}
+
+// GetNodePoolAssignments sets the method and arguments of params for a call to getNodePoolAssignments(uint64)((uint64[3])[8])
+func (c *ValidatorRegistryABI) GetNodePoolAssignments(params transaction.AddMethodCallParams, validatorId uint64) transaction.AddMethodCallParams {
+	params.Method = c.getNodePoolAssignments
+	params.MethodArgs = []any{validatorId}
+	return params
+}
+
+// GetNodePoolAssignmentsResult decodes the return value of a getNodePoolAssignments call
+func (c *ValidatorRegistryABI) GetNodePoolAssignmentsResult(result transaction.ABIMethodResult) (ABINodePoolAssignmentConfig, error) {
+	if err := abiCheckResult(result, c.getNodePoolAssignments); err != nil {
+		var zero ABINodePoolAssignmentConfig
+		return zero, err
+	}
+	return decodeABINodePoolAssignmentConfig(result.ReturnValue)
+}
+
+// GetNFDRegistryID sets the method and arguments of params for a call to getNFDRegistryID()uint64
+func (c *ValidatorRegistryABI) GetNFDRegistryID(params transaction.AddMethodCallParams) transaction.AddMethodCallParams {
+	params.Method = c.getNFDRegistryID
+	params.MethodArgs = []any{}
+	return params
+}
+
+// GetNFDRegistryIDResult decodes the return value of a getNFDRegistryID call
+func (c *ValidatorRegistryABI) GetNFDRegistryIDResult(result transaction.ABIMethodResult) (uint64, error) {
+	if err := abiCheckResult(result, c.getNFDRegistryID); err != nil {
+		var zero uint64
+		return zero, err
+	}
+	return abiUint64(result.ReturnValue)
+}
+
+// AddValidator sets the method and arguments of params for a call to addValidator(pay,string,(uint64,address,address,uint64,uint8,address,uint64[4],uint64,uint64,uint64,uint32,uint32,address,uint64,uint64,uint8,uint64,uint64))uint64
+//
+// Adds a new validator
+func (c *ValidatorRegistryABI) AddValidator(params transaction.AddMethodCallParams, mbrPayment transaction.TransactionWithSigner, nfdName string, config ABIValidatorConfig) transaction.AddMethodCallParams {
+	params.Method = c.addValidator
+	params.MethodArgs = []any{mbrPayment, nfdName, config.abiValues()}
+	return params
+}
+
+// AddValidatorResult decodes the return value of a addValidator call
+func (c *ValidatorRegistryABI) AddValidatorResult(result transaction.ABIMethodResult) (uint64, error) {
+	if err := abiCheckResult(result, c.addValidator); err != nil {
+		var zero uint64
+		return zero, err
+	}
+	return abiUint64(result.ReturnValue)
+}
+
+// ChangeValidatorManager sets the method and arguments of params for a call to changeValidatorManager(uint64,address)void
+//
+// Changes the Validator manager for a specific Validator id.
+func (c *ValidatorRegistryABI) ChangeValidatorManager(params transaction.AddMethodCallParams, validatorId uint64, manager types.Address) transaction.AddMethodCallParams {
+	params.Method = c.changeValidatorManager
+	params.MethodArgs = []any{validatorId, manager}
+	return params
+}
+
+// ChangeValidatorSunsetInfo sets the method and arguments of params for a call to changeValidatorSunsetInfo(uint64,uint64,uint64)void
+//
+// Updates the sunset information for a given validator.
+func (c *ValidatorRegistryABI) ChangeValidatorSunsetInfo(params transaction.AddMethodCallParams, validatorId uint64, sunsettingOn uint64, sunsettingTo uint64) transaction.AddMethodCallParams {
+	params.Method = c.changeValidatorSunsetInfo
+	params.MethodArgs = []any{validatorId, sunsettingOn, sunsettingTo}
+	return params
+}
+
+// ChangeValidatorNFD sets the method and arguments of params for a call to changeValidatorNFD(uint64,uint64,string)void
+//
+// Changes the NFD for a validator in the validatorList contract.
+func (c *ValidatorRegistryABI) ChangeValidatorNFD(params transaction.AddMethodCallParams, validatorId uint64, nfdAppID uint64, nfdName string) transaction.AddMethodCallParams {
+	params.Method = c.changeValidatorNFD
+	params.MethodArgs = []any{validatorId, nfdAppID, nfdName}
+	return params
+}
+
+// ChangeValidatorCommissionAddress sets the method and arguments of params for a call to changeValidatorCommissionAddress(uint64,address)void
+//
+// Change the commission address that validator rewards are sent to.
+func (c *ValidatorRegistryABI) ChangeValidatorCommissionAddress(params transaction.AddMethodCallParams, validatorId uint64, commissionAddress types.Address) transaction.AddMethodCallParams {
+	params.Method = c.changeValidatorCommissionAddress
+	params.MethodArgs = []any{validatorId, commissionAddress}
+	return params
+}
+
+// ChangeValidatorRewardInfo sets the method and arguments of params for a call to changeValidatorRewardInfo(uint64,uint8,address,uint64[4],uint64,uint64)void
+//
+// Allow the additional rewards (gating entry, additional token rewards) information be changed at will.
+func (c *ValidatorRegistryABI) ChangeValidatorRewardInfo(params transaction.AddMethodCallParams, validatorId uint64, entryGatingType uint8, entryGatingAddress types.Address, entryGatingAssets [4]uint64, gatingAssetMinBalance uint64, rewardPerPayout uint64) transaction.AddMethodCallParams {
+	params.Method = c.changeValidatorRewardInfo
+	params.MethodArgs = []any{validatorId, entryGatingType, entryGatingAddress, entryGatingAssets, gatingAssetMinBalance, rewardPerPayout}
+	return params
+}
+
+// AddPool sets the method and arguments of params for a call to addPool(pay,uint64,uint64)(uint64,uint64,uint64)
+//
+// Adds a new pool to a validator's pool set, returning the 'key' to reference the pool in the future for staking, etc.
+func (c *ValidatorRegistryABI) AddPool(params transaction.AddMethodCallParams, mbrPayment transaction.TransactionWithSigner, validatorId uint64, nodeNum uint64) transaction.AddMethodCallParams {
+	params.Method = c.addPool
+	params.MethodArgs = []any{mbrPayment, validatorId, nodeNum}
+	return params
+}
+
+// AddPoolResult decodes the return value of a addPool call
+func (c *ValidatorRegistryABI) AddPoolResult(result transaction.ABIMethodResult) (ABIValidatorPoolKey, error) {
+	if err := abiCheckResult(result, c.addPool); err != nil {
+		var zero ABIValidatorPoolKey
+		return zero, err
+	}
+	return decodeABIValidatorPoolKey(result.ReturnValue)
+}
+
+// AddStake sets the method and arguments of params for a call to addStake(pay,uint64,uint64)(uint64,uint64,uint64)
+//
+// Adds stake to a validator pool.
+func (c *ValidatorRegistryABI) AddStake(params transaction.AddMethodCallParams, stakedAmountPayment transaction.TransactionWithSigner, validatorId uint64, valueToVerify uint64) transaction.AddMethodCallParams {
+	params.Method = c.addStake
+	params.MethodArgs = []any{stakedAmountPayment, validatorId, valueToVerify}
+	return params
+}
+
+// AddStakeResult decodes the return value of a addStake call
+func (c *ValidatorRegistryABI) AddStakeResult(result transaction.ABIMethodResult) (ABIValidatorPoolKey, error) {
+	if err := abiCheckResult(result, c.addStake); err != nil {
+		var zero ABIValidatorPoolKey
+		return zero, err
+	}
+	return decodeABIValidatorPoolKey(result.ReturnValue)
+}
+
+// SetTokenPayoutRatio sets the method and arguments of params for a call to setTokenPayoutRatio(uint64)(uint64[24],uint64)
+//
+// setTokenPayoutRatio is called by Staking Pool # 1 (ONLY) to ask the validator (us) to calculate the ratios
+func (c *ValidatorRegistryABI) SetTokenPayoutRatio(params transaction.AddMethodCallParams, validatorId uint64) transaction.AddMethodCallParams {
+	params.Method = c.setTokenPayoutRatio
+	params.MethodArgs = []any{validatorId}
+	return params
+}
+
+// SetTokenPayoutRatioResult decodes the return value of a setTokenPayoutRatio call
+func (c *ValidatorRegistryABI) SetTokenPayoutRatioResult(result transaction.ABIMethodResult) (ABIPoolTokenPayoutRatio, error) {
+	if err := abiCheckResult(result, c.setTokenPayoutRatio); err != nil {
+		var zero ABIPoolTokenPayoutRatio
+		return zero, err
+	}
+	return decodeABIPoolTokenPayoutRatio(result.ReturnValue)
+}
+
+// StakeUpdatedViaRewards sets the method and arguments of params for a call to stakeUpdatedViaRewards((uint64,uint64,uint64),uint64,uint64,uint64,uint64)void
+//
+// stakeUpdatedViaRewards is called by Staking pools to inform the validator (us) that a particular amount of total
+func (c *ValidatorRegistryABI) StakeUpdatedViaRewards(params transaction.AddMethodCallParams, poolKey ABIValidatorPoolKey, algoToAdd uint64, rewardTokenAmountReserved uint64, validatorCommission uint64, saturatedBurnToFeeSink uint64) transaction.AddMethodCallParams {
+	params.Method = c.stakeUpdatedViaRewards
+	params.MethodArgs = []any{poolKey.abiValues(), algoToAdd, rewardTokenAmountReserved, validatorCommission, saturatedBurnToFeeSink}
+	return params
+}
+
+// StakeRemoved sets the method and arguments of params for a call to stakeRemoved((uint64,uint64,uint64),address,uint64,uint64,bool)void
+//
+// stakeRemoved is called by Staking pools to inform the validator (us) that a particular amount of total stake has been removed
+func (c *ValidatorRegistryABI) StakeRemoved(params transaction.AddMethodCallParams, poolKey ABIValidatorPoolKey, staker types.Address, amountRemoved uint64, rewardRemoved uint64, stakerRemoved bool) transaction.AddMethodCallParams {
+	params.Method = c.stakeRemoved
+	params.MethodArgs = []any{poolKey.abiValues(), staker, amountRemoved, rewardRemoved, stakerRemoved}
+	return params
+}
+
+// FindPoolForStaker sets the method and arguments of params for a call to findPoolForStaker(uint64,address,uint64)((uint64,uint64,uint64),bool,bool)
+//
+// Finds the pool for a staker based on the provided validator id, staker address, and amount to stake.
+func (c *ValidatorRegistryABI) FindPoolForStaker(params transaction.AddMethodCallParams, validatorId uint64, staker types.Address, amountToStake uint64) transaction.AddMethodCallParams {
+	params.Method = c.findPoolForStaker
+	params.MethodArgs = []any{validatorId, staker, amountToStake}
+	return params
+}
+
+// FindPoolForStakerResult decodes the return value of a findPoolForStaker call
+func (c *ValidatorRegistryABI) FindPoolForStakerResult(result transaction.ABIMethodResult) (ABIFindPoolForStakerReturn, error) {
+	if err := abiCheckResult(result, c.findPoolForStaker); err != nil {
+		var zero ABIFindPoolForStakerReturn
+		return zero, err
+	}
+	return decodeABIFindPoolForStakerReturn(result.ReturnValue)
+}
+
+// MovePoolToNode sets the method and arguments of params for a call to movePoolToNode(uint64,uint64,uint64)void
+//
+// Find the specified pool (in any node number) and move it to the specified node.
+func (c *ValidatorRegistryABI) MovePoolToNode(params transaction.AddMethodCallParams, validatorId uint64, poolAppId uint64, nodeNum uint64) transaction.AddMethodCallParams {
+	params.Method = c.movePoolToNode
+	params.MethodArgs = []any{validatorId, poolAppId, nodeNum}
+	return params
+}
+
+// EmptyTokenRewards sets the method and arguments of params for a call to emptyTokenRewards(uint64,address)uint64
+//
+// Sends the reward tokens held in pool 1 to specified receiver.
+func (c *ValidatorRegistryABI) EmptyTokenRewards(params transaction.AddMethodCallParams, validatorId uint64, receiver types.Address) transaction.AddMethodCallParams {
+	params.Method = c.emptyTokenRewards
+	params.MethodArgs = []any{validatorId, receiver}
+	return params
+}
+
+// EmptyTokenRewardsResult decodes the return value of a emptyTokenRewards call
+func (c *ValidatorRegistryABI) EmptyTokenRewardsResult(result transaction.ABIMethodResult) (uint64, error) {
+	if err := abiCheckResult(result, c.emptyTokenRewards); err != nil {
+		var zero uint64
+		return zero, err
+	}
+	return abiUint64(result.ReturnValue)
+}
+
+// DecodeStakerPoolSetBox decodes the contents of a stakerPoolSet box, encoded as (uint64,uint64,uint64)[6]
+func (c *ValidatorRegistryABI) DecodeStakerPoolSetBox(value []byte) ([6]ABIValidatorPoolKey, error) {
+	var zero [6]ABIValidatorPoolKey
+	if size, err := c.stakerPoolSetBox.ByteLen(); err == nil && len(value) < size {
+		return zero, fmt.Errorf("stakerPoolSet box of %d bytes, expected %d", len(value), size)
+	}
+	decoded, err := c.stakerPoolSetBox.Decode(value)
+	if err != nil {
+		return zero, fmt.Errorf("stakerPoolSet box: %w", err)
+	}
+	return func(v any) ([6]ABIValidatorPoolKey, error) {
+		var arr [6]ABIValidatorPoolKey
+		values, err := abiSliceOf(decodeABIValidatorPoolKey)(v)
+		if err != nil {
+			return arr, err
+		}
+		if len(values) != len(arr) {
+			return arr, fmt.Errorf("expected 6 elements, got %d", len(values))
+		}
+		copy(arr[:], values)
+		return arr, nil
+	}(decoded)
+}
+
+// EncodeStakerPoolSetBox encodes value as the contents of a stakerPoolSet box - the inverse of DecodeStakerPoolSetBox
+func (c *ValidatorRegistryABI) EncodeStakerPoolSetBox(value [6]ABIValidatorPoolKey) ([]byte, error) {
+	return c.stakerPoolSetBox.Encode(abiValuesOf(value[:], func(v ABIValidatorPoolKey) any { return v.abiValues() }))
+}
+
+// DecodeValidatorListBox decodes the contents of a validatorList box, encoded as ((uint64,address,address,uint64,uint8,address,uint64[4],uint64,uint64,uint64,uint32,uint32,address,uint64,uint64,uint8,uint64,uint64),(uint16,uint64,uint64,uint64),(uint64,uint16,uint64)[24],(uint64[24],uint64),((uint64[3])[8]))
+func (c *ValidatorRegistryABI) DecodeValidatorListBox(value []byte) (ABIValidatorInfo, error) {
+	var zero ABIValidatorInfo
+	if size, err := c.validatorListBox.ByteLen(); err == nil && len(value) < size {
+		return zero, fmt.Errorf("validatorList box of %d bytes, expected %d", len(value), size)
+	}
+	decoded, err := c.validatorListBox.Decode(value)
+	if err != nil {
+		return zero, fmt.Errorf("validatorList box: %w", err)
+	}
+	return decodeABIValidatorInfo(decoded)
+}
+
+// EncodeValidatorListBox encodes value as the contents of a validatorList box - the inverse of DecodeValidatorListBox
+func (c *ValidatorRegistryABI) EncodeValidatorListBox(value ABIValidatorInfo) ([]byte, error) {
+	return c.validatorListBox.Encode(value.abiValues())
+}
+
+// StakingPoolABI provides typed calls to the methods of the StakingPool contract
+type StakingPoolABI struct {
+	createApplication          abi.Method
+	gas                        abi.Method
+	initStorage                abi.Method
+	addStake                   abi.Method
+	removeStake                abi.Method
+	claimTokens                abi.Method
+	getStakerInfo              abi.Method
+	payTokenReward             abi.Method
+	updateAlgodVer             abi.Method
+	epochBalanceUpdate         abi.Method
+	goOnline                   abi.Method
+	goOffline                  abi.Method
+	linkToNFD                  abi.Method
+	proxiedSetTokenPayoutRatio abi.Method
+}
+
+// NewStakingPoolABI binds the methods of contract, failing if any generated method signature doesn't match the contract.
+func NewStakingPoolABI(contract *abi.Contract) (*StakingPoolABI, error) {
+	var (
+		c   = &StakingPoolABI{}
+		err error
+	)
+	for _, bind := range []struct {
+		method    *abi.Method
+		signature string
+	}{
+		{&c.createApplication, "createApplication(uint64,uint64,uint64,uint64)void"},
+		{&c.gas, "gas()void"},
+		{&c.initStorage, "initStorage(pay)void"},
+		{&c.addStake, "addStake(pay,address)uint64"},
+		{&c.removeStake, "removeStake(address,uint64)void"},
+		{&c.claimTokens, "claimTokens()void"},
+		{&c.getStakerInfo, "getStakerInfo(address)(address,uint64,uint64,uint64,uint64)"},
+		{&c.payTokenReward, "payTokenReward(address,uint64,uint64)void"},
+		{&c.updateAlgodVer, "updateAlgodVer(string)void"},
+		{&c.epochBalanceUpdate, "epochBalanceUpdate()void"},
+		{&c.goOnline, "goOnline(pay,byte[],byte[],byte[],uint64,uint64,uint64)void"},
+		{&c.goOffline, "goOffline()void"},
+		{&c.linkToNFD, "linkToNFD(uint64,string)void"},
+		{&c.proxiedSetTokenPayoutRatio, "proxiedSetTokenPayoutRatio((uint64,uint64,uint64))(uint64[24],uint64)"},
+	} {
+		if *bind.method, err = abiBindMethod(contract, bind.signature); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// CreateApplication sets the method and arguments of params for a call to createApplication(uint64,uint64,uint64,uint64)void
+//
+// Initialize the staking pool w/ owner and manager, but can only be created by the validator contract.
+func (c *StakingPoolABI) CreateApplication(params transaction.AddMethodCallParams, creatingContractId uint64, validatorId uint64, poolId uint64, minEntryStake uint64) transaction.AddMethodCallParams {
+	params.Method = c.createApplication
+	params.MethodArgs = []any{creatingContractId, validatorId, poolId, minEntryStake}
+	return params
+}
+
+// Gas sets the method and arguments of params for a call to gas()void
+//
+// gas is a dummy no-op call that can be used to pool-up resource references and opcode cost
+func (c *StakingPoolABI) Gas(params transaction.AddMethodCallParams) transaction.AddMethodCallParams {
+	params.Method = c.gas
+	params.MethodArgs = []any{}
+	return params
+}
+
+// InitStorage sets the method and arguments of params for a call to initStorage(pay)void
+//
+// Called after we're created and then funded, so we can create our large stakers ledger storage
+func (c *StakingPoolABI) InitStorage(params transaction.AddMethodCallParams, mbrPayment transaction.TransactionWithSigner) transaction.AddMethodCallParams {
+	params.Method = c.initStorage
+	params.MethodArgs = []any{mbrPayment}
+	return params
+}
+
+// AddStake sets the method and arguments of params for a call to addStake(pay,address)uint64
+//
+// Adds stake to the given account.
+func (c *StakingPoolABI) AddStake(params transaction.AddMethodCallParams, stakedAmountPayment transaction.TransactionWithSigner, staker types.Address) transaction.AddMethodCallParams {
+	params.Method = c.addStake
+	params.MethodArgs = []any{stakedAmountPayment, staker}
+	return params
+}
+
+// AddStakeResult decodes the return value of a addStake call
+func (c *StakingPoolABI) AddStakeResult(result transaction.ABIMethodResult) (uint64, error) {
+	if err := abiCheckResult(result, c.addStake); err != nil {
+		var zero uint64
+		return zero, err
+	}
+	return abiUint64(result.ReturnValue)
+}
+
+// RemoveStake sets the method and arguments of params for a call to removeStake(address,uint64)void
+//
+// Removes stake on behalf of caller (removing own stake).  If any token rewards exist, those are always sent in
+func (c *StakingPoolABI) RemoveStake(params transaction.AddMethodCallParams, staker types.Address, amountToUnstake uint64) transaction.AddMethodCallParams {
+	params.Method = c.removeStake
+	params.MethodArgs = []any{staker, amountToUnstake}
+	return params
+}
+
+// ClaimTokens sets the method and arguments of params for a call to claimTokens()void
+//
+// Claims all the available reward tokens a staker has available, sending their entire balance to the staker from
+func (c *StakingPoolABI) ClaimTokens(params transaction.AddMethodCallParams) transaction.AddMethodCallParams {
+	params.Method = c.claimTokens
+	params.MethodArgs = []any{}
+	return params
+}
+
+// GetStakerInfo sets the method and arguments of params for a call to getStakerInfo(address)(address,uint64,uint64,uint64,uint64)
+//
+// Retrieves the staked information for a given staker.
+func (c *StakingPoolABI) GetStakerInfo(params transaction.AddMethodCallParams, staker types.Address) transaction.AddMethodCallParams {
+	params.Method = c.getStakerInfo
+	params.MethodArgs = []any{staker}
+	return params
+}
+
+// GetStakerInfoResult decodes the return value of a getStakerInfo call
+func (c *StakingPoolABI) GetStakerInfoResult(result transaction.ABIMethodResult) (ABIStakedInfo, error) {
+	if err := abiCheckResult(result, c.getStakerInfo); err != nil {
+		var zero ABIStakedInfo
+		return zero, err
+	}
+	return decodeABIStakedInfo(result.ReturnValue)
+}
+
+// PayTokenReward sets the method and arguments of params for a call to payTokenReward(address,uint64,uint64)void
+//
+// [Internal protocol method] Remove a specified amount of 'community token' rewards for a staker.
+func (c *StakingPoolABI) PayTokenReward(params transaction.AddMethodCallParams, staker types.Address, rewardToken uint64, amountToSend uint64) transaction.AddMethodCallParams {
+	params.Method = c.payTokenReward
+	params.MethodArgs = []any{staker, rewardToken, amountToSend}
+	return params
+}
+
+// UpdateAlgodVer sets the method and arguments of params for a call to updateAlgodVer(string)void
+//
+// Update the (honor system) algod version for the node associated to this pool.  The node management daemon
+func (c *StakingPoolABI) UpdateAlgodVer(params transaction.AddMethodCallParams, algodVer string) transaction.AddMethodCallParams {
+	params.Method = c.updateAlgodVer
+	params.MethodArgs = []any{algodVer}
+	return params
+}
+
+// EpochBalanceUpdate sets the method and arguments of params for a call to epochBalanceUpdate()void
+//
+// Updates the balance of stakers in the pool based on the received 'rewards' (current balance vs known staked balance)
+func (c *StakingPoolABI) EpochBalanceUpdate(params transaction.AddMethodCallParams) transaction.AddMethodCallParams {
+	params.Method = c.epochBalanceUpdate
+	params.MethodArgs = []any{}
+	return params
+}
+
+// GoOnline sets the method and arguments of params for a call to goOnline(pay,byte[],byte[],byte[],uint64,uint64,uint64)void
+//
+// Registers a staking pool key online against a participation key.
+func (c *StakingPoolABI) GoOnline(params transaction.AddMethodCallParams, feePayment transaction.TransactionWithSigner, votePK []byte, selectionPK []byte, stateProofPK []byte, voteFirst uint64, voteLast uint64, voteKeyDilution uint64) transaction.AddMethodCallParams {
+	params.Method = c.goOnline
+	params.MethodArgs = []any{feePayment, votePK, selectionPK, stateProofPK, voteFirst, voteLast, voteKeyDilution}
+	return params
+}
+
+// GoOffline sets the method and arguments of params for a call to goOffline()void
+//
+// Marks a staking pool key OFFLINE.
+func (c *StakingPoolABI) GoOffline(params transaction.AddMethodCallParams) transaction.AddMethodCallParams {
+	params.Method = c.goOffline
+	params.MethodArgs = []any{}
+	return params
+}
+
+// LinkToNFD sets the method and arguments of params for a call to linkToNFD(uint64,string)void
+func (c *StakingPoolABI) LinkToNFD(params transaction.AddMethodCallParams, nfdAppId uint64, nfdName string) transaction.AddMethodCallParams {
+	params.Method = c.linkToNFD
+	params.MethodArgs = []any{nfdAppId, nfdName}
+	return params
+}
+
+// ProxiedSetTokenPayoutRatio sets the method and arguments of params for a call to proxiedSetTokenPayoutRatio((uint64,uint64,uint64))(uint64[24],uint64)
+//
+// proxiedSetTokenPayoutRatio is meant to be called by pools != 1 - calling US, pool #1
+func (c *StakingPoolABI) ProxiedSetTokenPayoutRatio(params transaction.AddMethodCallParams, poolKey ABIValidatorPoolKey) transaction.AddMethodCallParams {
+	params.Method = c.proxiedSetTokenPayoutRatio
+	params.MethodArgs = []any{poolKey.abiValues()}
+	return params
+}
+
+// ProxiedSetTokenPayoutRatioResult decodes the return value of a proxiedSetTokenPayoutRatio call
+func (c *StakingPoolABI) ProxiedSetTokenPayoutRatioResult(result transaction.ABIMethodResult) (ABIPoolTokenPayoutRatio, error) {
+	if err := abiCheckResult(result, c.proxiedSetTokenPayoutRatio); err != nil {
+		var zero ABIPoolTokenPayoutRatio
+		return zero, err
+	}
+	return decodeABIPoolTokenPayoutRatio(result.ReturnValue)
+}
+
+// ErrABIMismatch is returned when a contract doesn't match the signatures the bindings were generated from
+var ErrABIMismatch = errors.New("contract abi doesn't match generated bindings")
+
+func abiBindMethod(contract *abi.Contract, signature string) (abi.Method, error) {
+	expected, err := abi.MethodFromSignature(signature)
+	if err != nil {
+		return abi.Method{}, err
+	}
+	method, err := contract.GetMethodByName(expected.Name)
+	if err != nil {
+		return abi.Method{}, fmt.Errorf("%w: %s: %w", ErrABIMismatch, contract.Name, err)
+	}
+	if method.GetSignature() != signature {
+		return abi.Method{}, fmt.Errorf("%w: %s method is %s, expected %s", ErrABIMismatch, contract.Name, method.GetSignature(), signature)
+	}
+	return method, nil
+}
+
+func abiCheckResult(result transaction.ABIMethodResult, method abi.Method) error {
+	if result.Method.GetSignature() != method.GetSignature() {
+		return fmt.Errorf("result is for method %s, not %s", result.Method.Name, method.Name)
+	}
+	if result.DecodeError != nil {
+		return fmt.Errorf("%s return value: %w", method.Name, result.DecodeError)
+	}
+	return nil
+}
+
+func abiValue[T any](v any) (T, error) {
+	value, ok := v.(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("expected %T value, got %T", zero, v)
+	}
+	return value, nil
+}
+
+func abiUint8(v any) (uint8, error)     { return abiValue[uint8](v) }
+func abiUint16(v any) (uint16, error)   { return abiValue[uint16](v) }
+func abiUint32(v any) (uint32, error)   { return abiValue[uint32](v) }
+func abiUint64(v any) (uint64, error)   { return abiValue[uint64](v) }
+func abiBigInt(v any) (*big.Int, error) { return abiValue[*big.Int](v) }
+func abiBool(v any) (bool, error)       { return abiValue[bool](v) }
+func abiString(v any) (string, error)   { return abiValue[string](v) }
+func abiAny(v any) (any, error)         { return v, nil }
+
+func abiAddress(v any) (types.Address, error) {
+	var addr types.Address
+	pk, ok := v.([]byte)
+	if !ok || len(pk) != len(addr) {
+		return addr, fmt.Errorf("expected 32 byte address, got %T", v)
+	}
+	copy(addr[:], pk)
+	return addr, nil
+}
+
+func abiSliceOf[T any](decode func(any) (T, error)) func(any) ([]T, error) {
+	return func(v any) ([]T, error) {
+		values, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expected array, got %T", v)
+		}
+		ret := make([]T, len(values))
+		for i, value := range values {
+			var err error
+			if ret[i], err = decode(value); err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		return ret, nil
+	}
+}
+
+func abiValuesOf[T any](items []T, encode func(T) any) []any {
+	values := make([]any, len(items))
+	for i, item := range items {
+		values[i] = encode(item)
+	}
+	return values
+}
@@ -6,4 +6,5 @@ import (
 
 var (
 	ErrCantFetchPoolKey = errors.New("couldn't fetch poolkey data")
+	ErrContractMismatch = errors.New("deployed contracts don't match embedded contract version")
 )
@@ -0,0 +1,335 @@
+// Package fakereti deploys a validator registry, with a single validator and its staking pools, on a fakechain.Chain.
+// It maintains the registry and pool state the reti client reads (the validator list box, pool global state and
+// staker ledgers) and handles the contract methods the daemon calls - recording payouts and removed stakers so tests
+// can check what the daemon did.
+package fakereti
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sync"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/mnemonic"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/algorandfoundation/reti/internal/lib/algo"
+	"github.com/algorandfoundation/reti/internal/lib/algo/fakechain"
+	"github.com/algorandfoundation/reti/internal/lib/reti"
+)
+
+const (
+	RegistryAppID = 1000
+	// ValidatorID is the id of the single validator in the registry
+	ValidatorID = 1
+	// FirstPoolAppID is the app id of the validator's first pool - later pools follow sequentially
+	FirstPoolAppID = 2000
+	// NFDRegistryID is the NFD registry the registry reports having been deployed with
+	NFDRegistryID = 3000
+
+	// ledgerSize is the number of staker slots in each pool's ledger box
+	ledgerSize = 8
+	// poolMinBalance is the minimum balance of each pool account
+	poolMinBalance = 1_000_000
+)
+
+// Constraints are the protocol constraints the registry returns
+var Constraints = reti.ABIConstraints{
+	EpochPayoutRoundsMin:           1,
+	EpochPayoutRoundsMax:           1_000_000,
+	MinPctToValidatorWFourDecimals: 0,
+	MaxPctToValidatorWFourDecimals: 1_000_000,
+	MinEntryStake:                  1_000_000,
+	MaxAlgoPerPool:                 70_000_000_000_000,
+	MaxAlgoPerValidator:            300_000_000_000_000,
+	AmtConsideredSaturated:         200_000_000_000_000,
+	MaxNodes:                       8,
+	MaxPoolsPerNode:                3,
+	MaxStakersPerPool:              ledgerSize,
+}
+
+// RemovedStake is a removeStake call the pool handled
+type RemovedStake struct {
+	PoolAppID uint64
+	Staker    types.Address
+	Amount    uint64
+}
+
+// Registry is the deployed registry and its validator.  Use New.
+type Registry struct {
+	Chain *fakechain.Chain
+
+	validatorABI *reti.ValidatorRegistryABI
+
+	sync.Mutex
+	validator reti.ABIValidatorInfo
+	ledgers   map[uint64][]reti.StakedInfo
+	payouts   []uint64
+	removed   []RemovedStake
+}
+
+// New deploys the registry on chain with a single validator configured as config (its id is set to ValidatorID),
+// and no pools yet - see AddPool
+func New(chain *fakechain.Chain, config reti.ABIValidatorConfig) (*Registry, error) {
+	registryContract, err := reti.EmbeddedContract(reti.RegistryContractName)
+	if err != nil {
+		return nil, err
+	}
+	poolContract, err := reti.EmbeddedContract(reti.PoolContractName)
+	if err != nil {
+		return nil, err
+	}
+	validatorABI, err := reti.NewValidatorRegistryABI(registryContract)
+	if err != nil {
+		return nil, err
+	}
+	r := &Registry{
+		Chain:        chain,
+		validatorABI: validatorABI,
+		ledgers:      map[uint64][]reti.StakedInfo{},
+	}
+	config.Id = ValidatorID
+	r.validator.Config = config
+
+	// read-only calls are simulated from the dummy sender, which has to be able to cover their fees
+	chain.Fund(reti.DummyAlgoSender.String(), 1_000_000_000)
+	chain.RegisterContract(registryContract)
+	chain.RegisterContract(poolContract)
+	if err := chain.HandleKeyRegistration(poolContract); err != nil {
+		return nil, err
+	}
+	for _, handler := range []struct {
+		method  string
+		pool    bool
+		handler fakechain.MethodHandler
+	}{
+		{"getProtocolConstraints", false, func(_ *fakechain.Chain, _ fakechain.MethodCall) (any, error) {
+			c := Constraints
+			return []any{c.EpochPayoutRoundsMin, c.EpochPayoutRoundsMax, c.MinPctToValidatorWFourDecimals,
+				c.MaxPctToValidatorWFourDecimals, c.MinEntryStake, c.MaxAlgoPerPool, c.MaxAlgoPerValidator,
+				c.AmtConsideredSaturated, c.MaxNodes, c.MaxPoolsPerNode, c.MaxStakersPerPool}, nil
+		}},
+		{"getNFDRegistryID", false, func(_ *fakechain.Chain, _ fakechain.MethodCall) (any, error) {
+			return uint64(NFDRegistryID), nil
+		}},
+		{"getMbrAmounts", false, func(_ *fakechain.Chain, _ fakechain.MethodCall) (any, error) {
+			return []any{uint64(1_000_000), uint64(1_000_000), uint64(1_000_000), uint64(100_000)}, nil
+		}},
+		{"epochBalanceUpdate", true, r.epochBalanceUpdate},
+		{"removeStake", true, r.removeStake},
+	} {
+		contract := registryContract
+		if handler.pool {
+			contract = poolContract
+		}
+		method, err := contract.GetMethodByName(handler.method)
+		if err != nil {
+			return nil, err
+		}
+		chain.HandleMethod(method, handler.handler)
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	chain.SetGlobalUint(RegistryAppID, reti.VldtrNumValidators, 1)
+	return r, r.update()
+}
+
+// NewSigner returns a local signer with the keys of accounts - stored in a keystore created in dir
+func NewSigner(log *slog.Logger, dir string, accounts ...crypto.Account) (algo.MultipleWalletSigner, error) {
+	ks, err := algo.CreateKeystore(filepath.Join(dir, "keystore.json"), []byte("fakereti"))
+	if err != nil {
+		return nil, err
+	}
+	for i, account := range accounts {
+		accountMnemonic, err := mnemonic.FromPrivateKey(account.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := ks.AddMnemonic(fmt.Sprintf("account%d", i+1), accountMnemonic); err != nil {
+			return nil, err
+		}
+	}
+	return algo.NewLocalKeyStore(log, false, ks)
+}
+
+// AddPool adds a pool to node (1 based) with the specified stakers, funding the pool account with their stake
+// plus rewards (available for the next payout).  The pool's app id is returned.
+func (r *Registry) AddPool(node int, rewards uint64, stakers ...reti.StakedInfo) (uint64, error) {
+	r.Lock()
+	defer r.Unlock()
+	poolIdx := int(r.validator.State.NumPools)
+	if poolIdx >= len(r.validator.Pools) {
+		return 0, fmt.Errorf("validator already has %d pools", poolIdx)
+	}
+	if len(stakers) > ledgerSize {
+		return 0, fmt.Errorf("pools have at most %d stakers", ledgerSize)
+	}
+	nodeIdx := node - 1
+	if nodeIdx < 0 || nodeIdx >= len(r.validator.NodePoolAssignments.Nodes) {
+		return 0, fmt.Errorf("invalid node:%d", node)
+	}
+	slot := -1
+	for i, appID := range r.validator.NodePoolAssignments.Nodes[nodeIdx].Field0 {
+		if appID == 0 {
+			slot = i
+			break
+		}
+	}
+	if slot == -1 {
+		return 0, fmt.Errorf("node:%d already has its maximum pools", node)
+	}
+
+	poolAppID := uint64(FirstPoolAppID + poolIdx)
+	r.validator.State.NumPools++
+	r.validator.Pools[poolIdx].PoolAppId = poolAppID
+	r.validator.NodePoolAssignments.Nodes[nodeIdx].Field0[slot] = poolAppID
+	r.ledgers[poolAppID] = append([]reti.StakedInfo{}, stakers...)
+
+	r.Chain.SetGlobalUint(poolAppID, reti.StakePoolCreatorApp, RegistryAppID)
+	r.Chain.SetGlobalUint(poolAppID, reti.StakePoolValidatorId, ValidatorID)
+	r.Chain.SetGlobalUint(poolAppID, reti.StakePoolPoolId, uint64(poolIdx+1))
+	r.Chain.SetGlobalUint(poolAppID, reti.StakePoolMinEntryStake, r.validator.Config.MinEntryStake)
+	r.Chain.SetGlobalUint(poolAppID, reti.StakePoolMaxStake, r.validator.Config.MaxAlgoPerPool)
+	r.Chain.SetGlobalUint(poolAppID, reti.StakePoolLastPayout, 0)
+	r.Chain.SetGlobalBytes(poolAppID, reti.StakePoolAlgodVer, nil)
+
+	var staked uint64
+	for _, staker := range stakers {
+		staked += staker.Balance
+	}
+	r.Chain.SetAccount(crypto.GetApplicationAddress(poolAppID).String(), fakechain.Account{
+		Amount:     staked + poolMinBalance + rewards,
+		MinBalance: poolMinBalance,
+	})
+	return poolAppID, r.update()
+}
+
+// SetLastPayout sets the round of the pool's last epoch payout
+func (r *Registry) SetLastPayout(poolAppID uint64, round uint64) {
+	r.Chain.SetGlobalUint(poolAppID, reti.StakePoolLastPayout, round)
+}
+
+// SetConfig changes the validator's configuration (its id is kept)
+func (r *Registry) SetConfig(config reti.ABIValidatorConfig) error {
+	r.Lock()
+	defer r.Unlock()
+	config.Id = ValidatorID
+	r.validator.Config = config
+	return r.update()
+}
+
+// Payouts returns the app ids of the pools paid out (epochBalanceUpdate), in order
+func (r *Registry) Payouts() []uint64 {
+	r.Lock()
+	defer r.Unlock()
+	return append([]uint64{}, r.payouts...)
+}
+
+// Removed returns the stakers removed (removeStake), in order
+func (r *Registry) Removed() []RemovedStake {
+	r.Lock()
+	defer r.Unlock()
+	return append([]RemovedStake{}, r.removed...)
+}
+
+// update writes the validator list box and each pool's ledger box and staker totals from the current state.
+// Must be called with the lock held.
+func (r *Registry) update() error {
+	r.validator.State.TotalStakers, r.validator.State.TotalAlgoStaked = 0, 0
+	for i := 0; i < int(r.validator.State.NumPools); i++ {
+		pool := &r.validator.Pools[i]
+		var (
+			numStakers uint16
+			staked     uint64
+			ledger     = make([]byte, 0, ledgerSize*64)
+		)
+		for _, staker := range r.ledgers[pool.PoolAppId] {
+			if staker.Account != types.ZeroAddress {
+				numStakers++
+				staked += staker.Balance
+			}
+			ledger = append(ledger, staker.Account[:]...)
+			ledger = binary.BigEndian.AppendUint64(ledger, staker.Balance)
+			ledger = binary.BigEndian.AppendUint64(ledger, staker.TotalRewarded)
+			ledger = binary.BigEndian.AppendUint64(ledger, staker.RewardTokenBalance)
+			ledger = binary.BigEndian.AppendUint64(ledger, staker.EntryRound)
+		}
+		// the rest of the ledger is empty slots
+		ledger = append(ledger, make([]byte, cap(ledger)-len(ledger))...)
+		r.Chain.SetBox(pool.PoolAppId, reti.GetStakerLedgerBoxName(), ledger)
+		r.Chain.SetGlobalUint(pool.PoolAppId, reti.StakePoolNumStakers, uint64(numStakers))
+		r.Chain.SetGlobalUint(pool.PoolAppId, reti.StakePoolStaked, staked)
+
+		pool.TotalStakers, pool.TotalAlgoStaked = numStakers, staked
+		r.validator.State.TotalStakers += uint64(numStakers)
+		r.validator.State.TotalAlgoStaked += staked
+	}
+	box, err := r.validatorABI.EncodeValidatorListBox(r.validator)
+	if err != nil {
+		return fmt.Errorf("unable to encode validator list box: %w", err)
+	}
+	r.Chain.SetBox(RegistryAppID, reti.GetValidatorListBoxName(ValidatorID), box)
+	return nil
+}
+
+// epochBalanceUpdate records the payout and sets the pool's last payout round - the round the call is committed in
+func (r *Registry) epochBalanceUpdate(c *fakechain.Chain, call fakechain.MethodCall) (any, error) {
+	if call.Simulate {
+		return nil, nil
+	}
+	r.Lock()
+	defer r.Unlock()
+	if _, found := r.ledgers[call.AppID]; !found {
+		return nil, fmt.Errorf("app id:%d isn't a pool", call.AppID)
+	}
+	r.payouts = append(r.payouts, call.AppID)
+	c.SetGlobalUint(call.AppID, reti.StakePoolLastPayout, c.Round()+1)
+	return nil, nil
+}
+
+// removeStake removes the staker (amount 0 for all of their stake) from the pool's ledger and pays it back to them
+func (r *Registry) removeStake(c *fakechain.Chain, call fakechain.MethodCall) (any, error) {
+	if len(call.Args) != 2 {
+		return nil, fmt.Errorf("removeStake expects 2 args, got %d", len(call.Args))
+	}
+	var staker types.Address
+	copy(staker[:], call.Args[0].([]byte))
+	amount := call.Args[1].(uint64)
+
+	r.Lock()
+	defer r.Unlock()
+	ledger := r.ledgers[call.AppID]
+	idx := -1
+	for i, info := range ledger {
+		if info.Account == staker {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return nil, fmt.Errorf("account:%s isn't staked in pool app id:%d", staker, call.AppID)
+	}
+	if amount == 0 {
+		amount = ledger[idx].Balance
+	}
+	if amount > ledger[idx].Balance {
+		return nil, fmt.Errorf("account:%s has only %d staked", staker, ledger[idx].Balance)
+	}
+	if call.Simulate {
+		return nil, nil
+	}
+	ledger[idx].Balance -= amount
+	if ledger[idx].Balance == 0 {
+		ledger[idx] = reti.StakedInfo{}
+	}
+	poolAccount := crypto.GetApplicationAddress(call.AppID).String()
+	account := c.GetAccount(poolAccount)
+	account.Amount -= amount
+	c.SetAccount(poolAccount, account)
+	c.Fund(staker.String(), amount)
+	r.removed = append(r.removed, RemovedStake{PoolAppID: call.AppID, Staker: staker, Amount: amount})
+	return nil, r.update()
+}
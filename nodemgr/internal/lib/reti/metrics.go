@@ -30,4 +30,8 @@ var (
 		Subsystem: "reti",
 		Name:      "max_stake_allowed_total",
 	})
+	promContractInfo = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Subsystem: "reti",
+		Name:      "contract_info",
+	}, []string{"contract", "app_id", "version", "matches", "status"})
 )
@@ -0,0 +1,117 @@
+package reti
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/mailgun/holster/v4/syncutil"
+)
+
+// PoolState is a snapshot of all the global state of a staking pool, decoded from a single fetch of the application.
+// Keys not present (pools created by older contract versions) are left as zero values.
+type PoolState struct {
+	AppID         uint64
+	CreatorApp    uint64
+	ValidatorID   uint64
+	PoolID        uint64
+	NumStakers    uint64
+	Staked        uint64
+	MinEntryStake uint64
+	MaxStake      uint64
+	LastPayout    uint64
+	AlgodVer      string
+	// AvgApr is the exponentially weighted moving average of the pool's APR (ewma)
+	AvgApr        *big.Int
+	StakeAccum    *big.Int
+	BinRoundStart uint64
+	RoundsPerDay  uint64
+}
+
+// PoolStateFromGlobalState decodes the global state of a staking pool application
+func PoolStateFromGlobalState(appID uint64, globalState []models.TealKeyValue) (*PoolState, error) {
+	state := &PoolState{
+		AppID:      appID,
+		AvgApr:     new(big.Int),
+		StakeAccum: new(big.Int),
+	}
+	for _, gs := range globalState {
+		rawKey, err := base64.StdEncoding.DecodeString(gs.Key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid global state key in pool app id:%d: %w", appID, err)
+		}
+		var bytesVal []byte
+		if gs.Value.Type == 1 {
+			bytesVal, err = base64.StdEncoding.DecodeString(gs.Value.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("invalid global state value for key:%s in pool app id:%d: %w", string(rawKey), appID, err)
+			}
+		}
+		switch string(rawKey) {
+		case StakePoolCreatorApp:
+			state.CreatorApp = gs.Value.Uint
+		case StakePoolValidatorId:
+			state.ValidatorID = gs.Value.Uint
+		case StakePoolPoolId:
+			state.PoolID = gs.Value.Uint
+		case StakePoolNumStakers:
+			state.NumStakers = gs.Value.Uint
+		case StakePoolStaked:
+			state.Staked = gs.Value.Uint
+		case StakePoolMinEntryStake:
+			state.MinEntryStake = gs.Value.Uint
+		case StakePoolMaxStake:
+			state.MaxStake = gs.Value.Uint
+		case StakePoolLastPayout:
+			state.LastPayout = gs.Value.Uint
+		case StakePoolAlgodVer:
+			state.AlgodVer = string(bytesVal)
+		case StakePoolEWMA:
+			state.AvgApr.SetBytes(bytesVal)
+		case StakePoolStakeAccum:
+			state.StakeAccum.SetBytes(bytesVal)
+		case StakePoolBinRoundStart:
+			state.BinRoundStart = gs.Value.Uint
+		case StakePoolRoundsPerDay:
+			state.RoundsPerDay = gs.Value.Uint
+		}
+	}
+	return state, nil
+}
+
+// GetPoolState fetches the current global state of a staking pool
+func (r *Reti) GetPoolState(poolAppID uint64) (*PoolState, error) {
+	appInfo, err := r.chain.ApplicationByID(context.Background(), poolAppID)
+	if err != nil {
+		return nil, err
+	}
+	return PoolStateFromGlobalState(poolAppID, appInfo.Params.GlobalState)
+}
+
+// GetPoolStates fetches the state of multiple staking pools in parallel, returned keyed by pool app id
+func (r *Reti) GetPoolStates(poolAppIDs []uint64) (map[uint64]*PoolState, error) {
+	var (
+		fanOut = syncutil.NewFanOut(10)
+		mutex  sync.Mutex
+		states = make(map[uint64]*PoolState, len(poolAppIDs))
+	)
+	for _, poolAppID := range poolAppIDs {
+		fanOut.Run(func(val any) error {
+			state, err := r.GetPoolState(val.(uint64))
+			if err != nil {
+				return fmt.Errorf("unable to fetch state of pool app id:%d: %w", val.(uint64), err)
+			}
+			mutex.Lock()
+			states[state.AppID] = state
+			mutex.Unlock()
+			return nil
+		}, poolAppID)
+	}
+	if errs := fanOut.Wait(); len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return states, nil
+}
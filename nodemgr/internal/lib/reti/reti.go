@@ -1,6 +1,7 @@
 package reti
 
 import (
+	"bytes"
 	"context"
 	"embed"
 	"encoding/json"
@@ -10,7 +11,6 @@ import (
 	"sync"
 
 	"github.com/algorand/go-algorand-sdk/v2/abi"
-	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
 	"github.com/algorand/go-algorand-sdk/v2/types"
 
 	"github.com/algorandfoundation/reti/internal/lib/algo"
@@ -18,22 +18,36 @@ import (
 )
 
 type Reti struct {
-	Logger     *slog.Logger
-	algoClient *algod.Client
-	signer     algo.MultipleWalletSigner
+	Logger *slog.Logger
+	chain  algo.Chain
+	signer algo.MultipleWalletSigner
 
 	// RetiAppId is simply the master validator contract id
 	RetiAppId   uint64
 	ValidatorId uint64
 	NodeNum     uint64
 
-	validatorContract *abi.Contract
-	poolContract      *abi.Contract
+	// StrictContractCheck makes LoadState fail if the deployed contracts don't match the embedded artifacts rather
+	// than just warning
+	StrictContractCheck bool
+
+	// ReadOnly allows loading the state of any validator without its owner or manager keys, and without a node number
+	// (so no pools are local) - for looking at, not running, a validator
+	ReadOnly bool
+
+	validatorABI *ValidatorRegistryABI
+	poolABI      *StakingPoolABI
 
 	// Loaded from on-chain state at start and on-demand via LoadStateFromChain
 	// Mutex wrap is just lazy way of allowing single shared-state of instance data that's periodically updated
 	sync.RWMutex
-	info ValidatorInfo
+	info             ValidatorInfo
+	contractVersions ContractVersions
+
+	// cached contract version check data - see checkContractVersions
+	versionLock      sync.Mutex
+	expectedHashes   map[string]string
+	checkedContracts map[uint64]ContractVersion
 }
 
 func (r *Reti) Info() ValidatorInfo {
@@ -51,7 +65,7 @@ func (r *Reti) setInfo(Info ValidatorInfo) {
 func New(
 	validatorAppId uint64,
 	logger *slog.Logger,
-	algoClient *algod.Client,
+	chain algo.Chain,
 	signer algo.MultipleWalletSigner,
 	validatorId uint64,
 	nodeNum uint64,
@@ -62,9 +76,9 @@ func New(
 		ValidatorId: validatorId,
 		NodeNum:     nodeNum,
 
-		Logger:     logger,
-		algoClient: algoClient,
-		signer:     signer,
+		Logger: logger,
+		chain:  chain,
+		signer: signer,
 	}
 	validatorContract, err := loadContract("artifacts/contracts/ValidatorRegistry.arc32.json")
 	if err != nil {
@@ -74,27 +88,47 @@ func New(
 	if err != nil {
 		return nil, err
 	}
-	retReti.validatorContract = validatorContract
-	retReti.poolContract = poolContract
+	// Bind the typed method wrappers - failing now if the embedded contracts don't match what the bindings were
+	// generated from (the bindings need to be re-generated)
+	retReti.validatorABI, err = NewValidatorRegistryABI(validatorContract)
+	if err != nil {
+		return nil, err
+	}
+	retReti.poolABI, err = NewStakingPoolABI(poolContract)
+	if err != nil {
+		return nil, err
+	}
 
 	misc.Infof(logger, "client initialized, Protocol App id:%d, Validator id:%d, Node Number:%d", validatorAppId, validatorId, nodeNum)
 
 	return retReti, nil
 }
 
+// ExportUnsigned makes calls that would sign and send transactions write them (unsigned) to filename instead, to be
+// signed elsewhere.  See algo.NewExportChain.
+func (r *Reti) ExportUnsigned(filename string, format string, validRounds uint64) {
+	r.chain = algo.NewExportChain(r.chain, filename, format, validRounds)
+}
+
 func (r *Reti) IsConfigured() bool {
-	return r.RetiAppId != 0 && r.ValidatorId != 0 && r.NodeNum != 0
+	return r.RetiAppId != 0 && r.ValidatorId != 0 && (r.NodeNum != 0 || r.ReadOnly)
 }
 
 // LoadState loads the state of the Reti instance by retrieving information from
 // the chain and setting the local values to the on-chain current state.
 // It also verifies that the validator has either owner or manager keys present, and match the
 // keys we have available (which will have to sign for either owner or manager depending on call)
+// The deployed registry and pool contracts are checked against the embedded contract artifacts as well.
 // Prometheus metrics are also updated based on loaded state.
 func (r *Reti) LoadState(ctx context.Context) error {
 	if r.RetiAppId == 0 {
 		return errors.New("reti App id not defined")
 	}
+	if r.ValidatorId == 0 {
+		if err := r.checkContractVersions(ctx, nil); err != nil {
+			return err
+		}
+	}
 
 	// Now load all the data from the chain for our validator, etc.
 	if r.ValidatorId != 0 {
@@ -105,39 +139,38 @@ func (r *Reti) LoadState(ctx context.Context) error {
 		if r.ValidatorId > numValidators {
 			return fmt.Errorf("validator id:%d is invalid, maximum is %d", r.ValidatorId, numValidators)
 		}
-		config, err := r.GetValidatorConfig(r.ValidatorId)
+		validator, err := r.GetValidatorInfo(r.ValidatorId)
 		if err != nil {
-			return fmt.Errorf("unable to GetValidatorConfig: %w", err)
+			return fmt.Errorf("unable to GetValidatorInfo: %w", err)
 		}
-		// verify this validator is one we have either owner or manager keys for !!
-		_, err = r.signer.FindFirstSigner([]string{config.Owner, config.Manager})
-		if err != nil {
-			return fmt.Errorf("neither owner or manager address for validator id:%d has local keys present", r.ValidatorId)
+		// verify this validator is one we have either owner or manager keys for !! (unless just looking)
+		if !r.ReadOnly {
+			_, err = r.signer.FindFirstSigner([]string{validator.Config.Owner, validator.Config.Manager})
+			if err != nil {
+				return fmt.Errorf("neither owner or manager address for validator id:%d has local keys present", r.ValidatorId)
+			}
 		}
 		constraints, err := r.GetProtocolConstraints()
 		if err != nil {
 			return fmt.Errorf("unable to GetProtocolConstraints: %w", err)
 		}
 
+		if err := r.checkContractVersions(ctx, validator.Pools); err != nil {
+			return err
+		}
+
 		// We could get total stake etc for all pools at once via the validator state but since there will be multiple instances
 		// of this daemon we should just report per-validator data and the validator can max / sum, etc. as appropriate
 		// in their metrics dashboard - taking data from all daemons.
-		pools, err := r.GetValidatorPools(r.ValidatorId)
-		if err != nil {
-			return fmt.Errorf("unable to GetValidatorPools: %w", err)
-		}
-
-		assignments, err := r.GetValidatorNodePoolAssignments(r.ValidatorId)
-		if err != nil {
-			return fmt.Errorf("unable to GetValidatorNodePoolAssignments: %w", err)
-		}
-		newInfo := ValidatorInfo{
-			Config:              *config,
-			Pools:               pools,
-			NodePoolAssignments: *assignments,
-			LocalPools:          map[uint64]uint64{},
+		newInfo := *validator
+		newInfo.LocalPools = map[uint64]uint64{}
+
+		if r.ReadOnly && r.NodeNum == 0 {
+			// no node of our own - nothing is local
+			r.Logger.Debug("state re-loaded (read-only)")
+			r.setInfo(newInfo)
+			return nil
 		}
-
 		if r.NodeNum == 0 || int(r.NodeNum) > len(newInfo.NodePoolAssignments.Nodes) {
 			return fmt.Errorf("configured Node number:%d is invalid for number of on-chain nodes configured: %d", r.NodeNum, len(newInfo.NodePoolAssignments.Nodes))
 		}
@@ -152,7 +185,7 @@ func (r *Reti) LoadState(ctx context.Context) error {
 		)
 		for _, poolAppID := range newInfo.NodePoolAssignments.Nodes[r.NodeNum-1].PoolAppIds {
 			var poolID uint64
-			for poolIdx, pool := range pools {
+			for poolIdx, pool := range newInfo.Pools {
 				if pool.PoolAppId == poolAppID {
 					localStakers += uint64(pool.TotalStakers)
 					localTotalStaked += pool.TotalAlgoStaked
@@ -187,6 +220,8 @@ func (r *Reti) getLocalSignerForSimulateCalls() (types.Address, error) {
 	return DummyAlgoSender, nil
 }
 
+//go:generate go run ../../tools/arc32gen -pkg reti -out contracts_gen.go artifacts/contracts/ValidatorRegistry.arc32.json artifacts/contracts/StakingPool.arc32.json
+
 //go:embed artifacts/contracts/ValidatorRegistry.arc32.json
 //go:embed artifacts/contracts/StakingPool.arc32.json
 var embeddedF embed.FS
@@ -199,6 +234,48 @@ func loadContract(fname string) (*abi.Contract, error) {
 	return loadContractFromArc32(data)
 }
 
+// EmbeddedContract returns the ABI of the embedded contract artifact - RegistryContractName or PoolContractName
+func EmbeddedContract(name string) (*abi.Contract, error) {
+	fname, found := contractArtifacts[name]
+	if !found {
+		return nil, fmt.Errorf("unknown contract:%s", name)
+	}
+	return loadContract(fname)
+}
+
+// EmbeddedMethod returns the method, and the name of its contract, of the embedded contracts with the ABI selector
+func EmbeddedMethod(selector []byte) (abi.Method, string, error) {
+	for _, name := range []string{RegistryContractName, PoolContractName} {
+		contract, err := EmbeddedContract(name)
+		if err != nil {
+			return abi.Method{}, "", err
+		}
+		for _, method := range contract.Methods {
+			if bytes.Equal(method.GetSelector(), selector) {
+				return method, name, nil
+			}
+		}
+	}
+	return abi.Method{}, "", fmt.Errorf("no method with selector:%x", selector)
+}
+
+// PoolMethodSelectors returns the ABI selectors of the named staking pool methods (ie: goOnline)
+func PoolMethodSelectors(names []string) ([][]byte, error) {
+	poolContract, err := loadContract("artifacts/contracts/StakingPool.arc32.json")
+	if err != nil {
+		return nil, err
+	}
+	var selectors [][]byte
+	for _, name := range names {
+		method, err := poolContract.GetMethodByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("unknown staking pool method:%s: %w", name, err)
+		}
+		selectors = append(selectors, method.GetSelector())
+	}
+	return selectors, nil
+}
+
 // ABIContractWrap struct is just so we can unmarshal an arc32 document into the abi.contract type
 // we ignore everything else in arc32
 type ABIContractWrap struct {
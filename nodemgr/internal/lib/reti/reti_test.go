@@ -0,0 +1,91 @@
+package reti_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+
+	"github.com/algorandfoundation/reti/internal/lib/algo/fakechain"
+	"github.com/algorandfoundation/reti/internal/lib/reti"
+	"github.com/algorandfoundation/reti/internal/lib/reti/fakereti"
+)
+
+type testValidator struct {
+	chain    *fakechain.Chain
+	registry *fakereti.Registry
+	owner    crypto.Account
+	manager  crypto.Account
+}
+
+func newTestValidator(t *testing.T, config reti.ABIValidatorConfig) *testValidator {
+	t.Helper()
+	tv := &testValidator{
+		chain:   fakechain.New(10_000),
+		owner:   crypto.GenerateAccount(),
+		manager: crypto.GenerateAccount(),
+	}
+	tv.chain.SetAccount(tv.manager.Address.String(), fakechain.Account{Amount: 100_000_000, MinBalance: 100_000})
+	config.Owner, config.Manager, config.ValidatorCommissionAddress = tv.owner.Address, tv.manager.Address, tv.owner.Address
+	if config.EpochRoundLength == 0 {
+		config.EpochRoundLength = 100
+	}
+	if config.PoolsPerNode == 0 {
+		config.PoolsPerNode = 3
+	}
+	config.MinEntryStake, config.MaxAlgoPerPool = 1_000_000, 70_000_000_000_000
+	var err error
+	tv.registry, err = fakereti.New(tv.chain, config)
+	if err != nil {
+		t.Fatalf("fakereti.New: %v", err)
+	}
+	return tv
+}
+
+func (tv *testValidator) addPool(t *testing.T, node int, rewards uint64, stakers ...reti.StakedInfo) uint64 {
+	t.Helper()
+	poolAppID, err := tv.registry.AddPool(node, rewards, stakers...)
+	if err != nil {
+		t.Fatalf("AddPool: %v", err)
+	}
+	return poolAppID
+}
+
+// client returns a reti client for the validator, running as nodeNum, with its state loaded
+func (tv *testValidator) client(t *testing.T, nodeNum uint64) *reti.Reti {
+	t.Helper()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	signer, err := fakereti.NewSigner(log, t.TempDir(), tv.manager)
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+	client, err := reti.New(fakereti.RegistryAppID, log, tv.chain, signer, fakereti.ValidatorID, nodeNum)
+	if err != nil {
+		t.Fatalf("reti.New: %v", err)
+	}
+	if err := client.LoadState(context.Background()); err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	return client
+}
+
+func staker(balance uint64) reti.StakedInfo {
+	return reti.StakedInfo{Account: crypto.GenerateAccount().Address, Balance: balance, EntryRound: 1}
+}
+
+func TestLoadStateLocalPools(t *testing.T) {
+	tv := newTestValidator(t, reti.ABIValidatorConfig{})
+	pool1 := tv.addPool(t, 1, 0, staker(10_000_000))
+	tv.addPool(t, 2, 0, staker(20_000_000))
+	pool3 := tv.addPool(t, 1, 0)
+
+	info := tv.client(t, 1).Info()
+	if len(info.LocalPools) != 2 || info.LocalPools[1] != pool1 || info.LocalPools[3] != pool3 {
+		t.Fatalf("unexpected local pools for node 1: %v", info.LocalPools)
+	}
+	if info.Config.Manager != tv.manager.Address.String() {
+		t.Fatalf("unexpected manager:%s", info.Config.Manager)
+	}
+}
@@ -26,7 +26,7 @@ type StakedInfo struct {
 
 func (r *Reti) GetLedgerForPool(poolAppID uint64) ([]StakedInfo, error) {
 	var retLedger []StakedInfo
-	boxData, err := r.algoClient.GetApplicationBoxByName(poolAppID, GetStakerLedgerBoxName()).Do(context.Background())
+	boxData, err := r.chain.ApplicationBoxByName(context.Background(), poolAppID, GetStakerLedgerBoxName())
 	if err != nil {
 		return nil, err
 	}
@@ -48,80 +48,21 @@ func (r *Reti) GetLedgerForPool(poolAppID uint64) ([]StakedInfo, error) {
 	return retLedger, nil
 }
 
-func (r *Reti) GetPoolID(poolAppID uint64) (uint64, error) {
-	appInfo, err := r.algoClient.GetApplicationByID(poolAppID).Do(context.Background())
-	if err != nil {
-		return 0, err
-	}
-	return algo.GetUint64FromGlobalState(appInfo.Params.GlobalState, StakePoolPoolId)
-}
-
-func (r *Reti) GetLastPayout(poolAppID uint64) (uint64, error) {
-	appInfo, err := r.algoClient.GetApplicationByID(poolAppID).Do(context.Background())
-	if err != nil {
-		return 0, err
-	}
-	return algo.GetUint64FromGlobalState(appInfo.Params.GlobalState, StakePoolLastPayout)
-}
-
-func (r *Reti) GetAvgApr(poolAppID uint64) (*big.Int, error) {
-	appInfo, err := r.algoClient.GetApplicationByID(poolAppID).Do(context.Background())
-	if err != nil {
-		return nil, err
-	}
-	return algo.GetUint128FromGlobalState(appInfo.Params.GlobalState, StakePoolEWMA)
-}
-
-func (r *Reti) GetBinRoundStart(poolAppID uint64) (uint64, error) {
-	appInfo, err := r.algoClient.GetApplicationByID(poolAppID).Do(context.Background())
-	if err != nil {
-		return 0, err
-	}
-	return algo.GetUint64FromGlobalState(appInfo.Params.GlobalState, StakePoolBinRoundStart)
-}
-
-func (r *Reti) GetRoundsPerDay(poolAppID uint64) (uint64, error) {
-	appInfo, err := r.algoClient.GetApplicationByID(poolAppID).Do(context.Background())
-	if err != nil {
-		return 0, err
-	}
-	return algo.GetUint64FromGlobalState(appInfo.Params.GlobalState, StakePoolRoundsPerDay)
-}
-
-func (r *Reti) GetStakeAccum(poolAppID uint64) (*big.Int, error) {
-	appInfo, err := r.algoClient.GetApplicationByID(poolAppID).Do(context.Background())
-	if err != nil {
-		return nil, err
-	}
-	return algo.GetUint128FromGlobalState(appInfo.Params.GlobalState, StakePoolStakeAccum)
-}
-
-func (r *Reti) GetAlgodVer(poolAppID uint64) (string, error) {
-	appInfo, err := r.algoClient.GetApplicationByID(poolAppID).Do(context.Background())
-	if err != nil {
-		return "", err
-	}
-	return algo.GetStringFromGlobalState(appInfo.Params.GlobalState, StakePoolAlgodVer)
-}
-
 func (r *Reti) UpdateAlgodVer(poolAppID uint64, algodVer string, caller types.Address) error {
 	var err error
 
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return err
 	}
 
 	atc := transaction.AtomicTransactionComposer{}
-	updateAlgodVerMethod, _ := r.poolContract.GetMethodByName("updateAlgodVer")
 
 	params.FlatFee = true
 	params.Fee = transaction.MinTxnFee * 2
 
-	err = atc.AddMethodCall(transaction.AddMethodCallParams{
+	err = atc.AddMethodCall(r.poolABI.UpdateAlgodVer(transaction.AddMethodCallParams{
 		AppID:       poolAppID,
-		Method:      updateAlgodVerMethod,
-		MethodArgs:  []any{algodVer},
 		ForeignApps: []uint64{r.RetiAppId},
 		BoxReferences: []types.AppBoxReference{
 			{AppID: r.RetiAppId, Name: GetValidatorListBoxName(r.ValidatorId)},
@@ -131,12 +72,12 @@ func (r *Reti) UpdateAlgodVer(poolAppID uint64, algodVer string, caller types.Ad
 		OnComplete:      types.NoOpOC,
 		Sender:          caller,
 		Signer:          algo.SignWithAccountForATC(r.signer, caller.String()),
-	})
+	}, algodVer))
 	if err != nil {
 		return err
 	}
 
-	_, err = atc.Execute(r.algoClient, context.Background(), 4)
+	_, err = r.chain.ExecuteATC(context.Background(), &atc, 4)
 	if err != nil {
 		return err
 	}
@@ -156,7 +97,7 @@ func (r *Reti) EpochBalanceUpdate(poolID int, poolAppID uint64, caller types.Add
 	}
 	rewardAvail := r.PoolAvailableRewards(poolAppID, pools[poolID-1].TotalAlgoStaked)
 
-	status, err := r.algoClient.Status().Do(context.Background())
+	status, err := r.chain.Status(context.Background())
 	if err != nil {
 		return fmt.Errorf("failed to get algod status at start: %w", err)
 	}
@@ -167,13 +108,18 @@ func (r *Reti) EpochBalanceUpdate(poolID int, poolAppID uint64, caller types.Add
 	} else {
 		epochStr = fmt.Sprintf("EpochStart:%d", epochStart)
 	}
-	apr, _ := r.GetAvgApr(poolAppID)
-	floatApr, _, _ := new(big.Float).Parse(apr.String(), 10)
-	floatApr.Quo(floatApr, big.NewFloat(100.0))
+	// the apr is just for logging - not a reason to skip the payout
+	aprStr := "unknown"
+	if poolState, err := r.GetPoolState(poolAppID); err != nil {
+		misc.Warnf(r.Logger, "[EpochBalanceUpdate] pool:%d unable to get pool state for apr, err:%v", poolID, err)
+	} else {
+		floatApr, _, _ := new(big.Float).Parse(poolState.AvgApr.String(), 10)
+		aprStr = floatApr.Quo(floatApr, big.NewFloat(100.0)).String()
+	}
 
-	misc.Infof(r.Logger, "[EpochBalanceUpdate] pool:%d epoch update at %s for app id:%d, avail rewards:%s, pre-epoch apr:%s", poolID, epochStr, poolAppID, algo.FormattedAlgoAmount(rewardAvail), floatApr.String())
+	misc.Infof(r.Logger, "[EpochBalanceUpdate] pool:%d epoch update at %s for app id:%d, avail rewards:%s, pre-epoch apr:%s", poolID, epochStr, poolAppID, algo.FormattedAlgoAmount(rewardAvail), aprStr)
 
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return err
 	}
@@ -183,8 +129,6 @@ func (r *Reti) EpochBalanceUpdate(poolID int, poolAppID uint64, caller types.Add
 
 	getAtc := func(feesToUse uint64) (transaction.AtomicTransactionComposer, error) {
 		atc := transaction.AtomicTransactionComposer{}
-		gasMethod, _ := r.poolContract.GetMethodByName("gas")
-		epochUpdateMethod, _ := r.poolContract.GetMethodByName("epochBalanceUpdate")
 
 		newParams := params
 
@@ -206,9 +150,8 @@ func (r *Reti) EpochBalanceUpdate(poolID int, poolAppID uint64, caller types.Add
 		}
 
 		// we need to stack up references in these two gas methods for resource pooling
-		err = atc.AddMethodCall(transaction.AddMethodCallParams{
+		err = atc.AddMethodCall(r.poolABI.Gas(transaction.AddMethodCallParams{
 			AppID:       poolAppID,
-			Method:      gasMethod,
 			ForeignApps: []uint64{r.RetiAppId},
 			BoxReferences: []types.AppBoxReference{
 				{AppID: r.RetiAppId, Name: GetValidatorListBoxName(r.ValidatorId)},
@@ -223,13 +166,12 @@ func (r *Reti) EpochBalanceUpdate(poolID int, poolAppID uint64, caller types.Add
 			OnComplete:      types.NoOpOC,
 			Sender:          caller,
 			Signer:          algo.SignWithAccountForATC(r.signer, caller.String()),
-		})
+		}))
 		if err != nil {
 			return atc, err
 		}
-		err = atc.AddMethodCall(transaction.AddMethodCallParams{
+		err = atc.AddMethodCall(r.poolABI.Gas(transaction.AddMethodCallParams{
 			AppID:         poolAppID,
-			Method:        gasMethod,
 			ForeignAssets: extraAssets,
 			ForeignApps:   extraApps,
 			ForeignAccounts: []string{
@@ -240,7 +182,7 @@ func (r *Reti) EpochBalanceUpdate(poolID int, poolAppID uint64, caller types.Add
 			OnComplete:      types.NoOpOC,
 			Sender:          caller,
 			Signer:          algo.SignWithAccountForATC(r.signer, caller.String()),
-		})
+		}))
 		if err != nil {
 			return atc, err
 		}
@@ -249,9 +191,8 @@ func (r *Reti) EpochBalanceUpdate(poolID int, poolAppID uint64, caller types.Add
 			feesToUse = 240 * transaction.MinTxnFee
 		}
 		newParams.Fee = types.MicroAlgos(feesToUse)
-		err = atc.AddMethodCall(transaction.AddMethodCallParams{
-			AppID:  poolAppID,
-			Method: epochUpdateMethod,
+		err = atc.AddMethodCall(r.poolABI.EpochBalanceUpdate(transaction.AddMethodCallParams{
+			AppID: poolAppID,
 			BoxReferences: []types.AppBoxReference{
 				{AppID: 0, Name: nil}, // extra i/o
 				{AppID: 0, Name: nil}, // extra i/o
@@ -265,7 +206,7 @@ func (r *Reti) EpochBalanceUpdate(poolID int, poolAppID uint64, caller types.Add
 			OnComplete:      types.NoOpOC,
 			Sender:          caller,
 			Signer:          algo.SignWithAccountForATC(r.signer, caller.String()),
-		})
+		}))
 		if err != nil {
 			return atc, err
 		}
@@ -277,7 +218,7 @@ func (r *Reti) EpochBalanceUpdate(poolID int, poolAppID uint64, caller types.Add
 	if err != nil {
 		return err
 	}
-	simResult, err := atc.Simulate(context.Background(), r.algoClient, models.SimulateRequest{
+	simResult, err := r.chain.SimulateATC(context.Background(), &atc, models.SimulateRequest{
 		AllowUnnamedResources: true,
 	})
 	if err != nil {
@@ -292,7 +233,7 @@ func (r *Reti) EpochBalanceUpdate(poolID int, poolAppID uint64, caller types.Add
 		return err
 	}
 
-	_, err = atc.Execute(r.algoClient, context.Background(), 4)
+	_, err = r.chain.ExecuteATC(context.Background(), &atc, 4)
 	if err != nil {
 		return err
 	}
@@ -306,19 +247,18 @@ func (r *Reti) GoOnline(poolAppID uint64, caller types.Address, votePK []byte, s
 		goOnlineFee uint64 = 0
 	)
 
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return err
 	}
 
 	atc := transaction.AtomicTransactionComposer{}
-	goOnlineMethod, _ := r.poolContract.GetMethodByName("goOnline")
 
 	params.FlatFee = true
 	params.Fee = transaction.MinTxnFee * 3
 
 	// if account isn't currently incentive eligible, we need to pay the extra fee
-	account, err := algo.GetBareAccount(context.Background(), r.algoClient, poolAddress)
+	account, err := algo.GetBareAccount(context.Background(), r.chain, poolAddress)
 	if err != nil {
 		return err
 	}
@@ -334,20 +274,8 @@ func (r *Reti) GoOnline(poolAppID uint64, caller types.Address, votePK []byte, s
 		Signer: algo.SignWithAccountForATC(r.signer, caller.String()),
 	}
 
-	err = atc.AddMethodCall(transaction.AddMethodCallParams{
-		AppID:  poolAppID,
-		Method: goOnlineMethod,
-		MethodArgs: []any{
-			// -- payment transaction to cover fee of going online (if needed)
-			payTxWithSigner,
-			//
-			votePK,
-			selectionPK,
-			stateProofPK,
-			voteFirst,
-			voteLast,
-			voteKeyDilution,
-		},
+	err = atc.AddMethodCall(r.poolABI.GoOnline(transaction.AddMethodCallParams{
+		AppID:       poolAppID,
 		ForeignApps: []uint64{r.RetiAppId},
 		BoxReferences: []types.AppBoxReference{
 			{AppID: r.RetiAppId, Name: GetValidatorListBoxName(r.ValidatorId)},
@@ -357,12 +285,22 @@ func (r *Reti) GoOnline(poolAppID uint64, caller types.Address, votePK []byte, s
 		OnComplete:      types.NoOpOC,
 		Sender:          caller,
 		Signer:          algo.SignWithAccountForATC(r.signer, caller.String()),
-	})
+	},
+		// -- payment transaction to cover fee of going online (if needed)
+		payTxWithSigner,
+		//
+		votePK,
+		selectionPK,
+		stateProofPK,
+		voteFirst,
+		voteLast,
+		voteKeyDilution,
+	))
 	if err != nil {
 		return err
 	}
 
-	result, err := atc.Execute(r.algoClient, context.Background(), 4)
+	result, err := r.chain.ExecuteATC(context.Background(), &atc, 4)
 	if err != nil {
 		return err
 	}
@@ -373,20 +311,18 @@ func (r *Reti) GoOnline(poolAppID uint64, caller types.Address, votePK []byte, s
 func (r *Reti) GoOffline(poolAppID uint64, caller types.Address) error {
 	var err error
 
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return err
 	}
 
 	atc := transaction.AtomicTransactionComposer{}
-	goOfflineMethod, _ := r.poolContract.GetMethodByName("goOffline")
 
 	params.FlatFee = true
 	params.Fee = transaction.MinTxnFee * 3
 
-	err = atc.AddMethodCall(transaction.AddMethodCallParams{
+	err = atc.AddMethodCall(r.poolABI.GoOffline(transaction.AddMethodCallParams{
 		AppID:       poolAppID,
-		Method:      goOfflineMethod,
 		ForeignApps: []uint64{r.RetiAppId},
 		BoxReferences: []types.AppBoxReference{
 			{AppID: r.RetiAppId, Name: GetValidatorListBoxName(r.ValidatorId)},
@@ -396,12 +332,12 @@ func (r *Reti) GoOffline(poolAppID uint64, caller types.Address) error {
 		OnComplete:      types.NoOpOC,
 		Sender:          caller,
 		Signer:          algo.SignWithAccountForATC(r.signer, caller.String()),
-	})
+	}))
 	if err != nil {
 		return err
 	}
 
-	_, err = atc.Execute(r.algoClient, context.Background(), 4)
+	_, err = r.chain.ExecuteATC(context.Background(), &atc, 4)
 	if err != nil {
 		return err
 	}
@@ -414,7 +350,7 @@ func (r *Reti) PoolBalance(poolAppID uint64) uint64 {
 }
 
 func (r *Reti) PoolAvailableRewards(poolAppID uint64, totalAlgoStaked uint64) uint64 {
-	acctInfo, _ := algo.GetBareAccount(context.Background(), r.algoClient, crypto.GetApplicationAddress(poolAppID).String())
+	acctInfo, _ := algo.GetBareAccount(context.Background(), r.chain, crypto.GetApplicationAddress(poolAppID).String())
 	if acctInfo.Amount < acctInfo.MinBalance {
 		// pool isn't properly initialized yet - so don't underflow on 'reward amount'
 		return 0
@@ -0,0 +1,76 @@
+package reti_test
+
+import (
+	"testing"
+
+	"github.com/algorandfoundation/reti/internal/lib/reti"
+)
+
+func TestEpochBalanceUpdate(t *testing.T) {
+	tv := newTestValidator(t, reti.ABIValidatorConfig{})
+	poolAppID := tv.addPool(t, 1, 5_000_000, staker(10_000_000))
+	client := tv.client(t, 1)
+
+	if rewards := client.PoolAvailableRewards(poolAppID, 10_000_000); rewards != 5_000_000 {
+		t.Fatalf("expected 5 ALGO of rewards available, got %d", rewards)
+	}
+	if err := client.EpochBalanceUpdate(1, poolAppID, tv.manager.Address); err != nil {
+		t.Fatalf("EpochBalanceUpdate: %v", err)
+	}
+	if payouts := tv.registry.Payouts(); len(payouts) != 1 || payouts[0] != poolAppID {
+		t.Fatalf("expected a payout of pool app id:%d, got %v", poolAppID, payouts)
+	}
+	state, err := client.GetPoolState(poolAppID)
+	if err != nil {
+		t.Fatalf("GetPoolState: %v", err)
+	}
+	if state.LastPayout != tv.chain.Round() {
+		t.Fatalf("expected last payout at round %d, got %d", tv.chain.Round(), state.LastPayout)
+	}
+}
+
+func TestEpochBalanceUpdateWithoutPoolState(t *testing.T) {
+	tv := newTestValidator(t, reti.ABIValidatorConfig{})
+	poolAppID := tv.addPool(t, 1, 5_000_000, staker(10_000_000))
+	client := tv.client(t, 1)
+
+	// the pool state is only needed for logging the apr - not being able to fetch it mustn't stop the payout
+	tv.chain.DeleteApplication(poolAppID)
+	if _, err := client.GetPoolState(poolAppID); err == nil {
+		t.Fatal("expected GetPoolState to fail")
+	}
+	if err := client.EpochBalanceUpdate(1, poolAppID, tv.manager.Address); err != nil {
+		t.Fatalf("EpochBalanceUpdate: %v", err)
+	}
+	if payouts := tv.registry.Payouts(); len(payouts) != 1 || payouts[0] != poolAppID {
+		t.Fatalf("expected a payout of pool app id:%d, got %v", poolAppID, payouts)
+	}
+}
+
+func TestRemoveStake(t *testing.T) {
+	tv := newTestValidator(t, reti.ABIValidatorConfig{})
+	leaving, staying := staker(10_000_000), staker(20_000_000)
+	poolAppID := tv.addPool(t, 1, 0, leaving, staying)
+	client := tv.client(t, 1)
+
+	poolKey := reti.ValidatorPoolKey{ID: 1, PoolId: 1, PoolAppId: poolAppID}
+	if err := client.RemoveStake(poolKey, tv.manager.Address, leaving.Account, 0); err != nil {
+		t.Fatalf("RemoveStake: %v", err)
+	}
+	ledger, err := client.GetLedgerForPool(poolAppID)
+	if err != nil {
+		t.Fatalf("GetLedgerForPool: %v", err)
+	}
+	var stakers []reti.StakedInfo
+	for _, info := range ledger {
+		if info.Balance != 0 {
+			stakers = append(stakers, info)
+		}
+	}
+	if len(stakers) != 1 || stakers[0] != staying {
+		t.Fatalf("expected only %s left in the ledger, got %+v", staying.Account, stakers)
+	}
+	if balance := tv.chain.GetAccount(leaving.Account.String()).Amount; balance != leaving.Balance {
+		t.Fatalf("removed staker was paid %d, expected %d", balance, leaving.Balance)
+	}
+}
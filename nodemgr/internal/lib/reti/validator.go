@@ -90,49 +90,104 @@ type ValidatorConfig struct {
 
 }
 
-func ValidatorConfigFromABIReturn(returnVal any) (*ValidatorConfig, error) {
-	if arrReturn, ok := returnVal.([]any); ok {
-		if len(arrReturn) != 18 {
-			return nil, fmt.Errorf("should be 18 elements returned in ValidatorConfig response")
+// Validate checks the configuration against the protocol constraints and the rules the registry enforces
+// when adding a validator or changing its settings.
+func (v *ValidatorConfig) Validate(constraints *ProtocolConstraints) error {
+	if addr, err := types.DecodeAddress(v.Owner); err != nil || addr == types.ZeroAddress {
+		return fmt.Errorf("invalid owner address:%s", v.Owner)
+	}
+	if addr, err := types.DecodeAddress(v.Manager); err != nil || addr == types.ZeroAddress {
+		return fmt.Errorf("invalid manager address:%s", v.Manager)
+	}
+	commissionAddr, err := decodeOptionalAddress(v.ValidatorCommissionAddress)
+	if err != nil {
+		return fmt.Errorf("invalid commission address: %w", err)
+	}
+	if v.PercentToValidator != 0 && commissionAddr == types.ZeroAddress {
+		return errors.New("commission address must be set if commission percentage isn't 0")
+	}
+	if v.EpochRoundLength < int(constraints.EpochPayoutRoundsMin) || v.EpochRoundLength > int(constraints.EpochPayoutRoundsMax) {
+		return fmt.Errorf("epoch length must be between %d and %d rounds", constraints.EpochPayoutRoundsMin, constraints.EpochPayoutRoundsMax)
+	}
+	if v.PercentToValidator < int(constraints.MinPctToValidatorWFourDecimals) || v.PercentToValidator > int(constraints.MaxPctToValidatorWFourDecimals) {
+		return fmt.Errorf("commission percentage must be between %d and %d (four decimals)", constraints.MinPctToValidatorWFourDecimals, constraints.MaxPctToValidatorWFourDecimals)
+	}
+	if v.MinEntryStake < constraints.MinEntryStake {
+		return fmt.Errorf("minimum entry stake must be at least %s", algo.FormattedAlgoAmount(constraints.MinEntryStake))
+	}
+	if v.MaxAlgoPerPool > constraints.MaxAlgoPerPool {
+		return fmt.Errorf("max stake per pool can't exceed %s", algo.FormattedAlgoAmount(constraints.MaxAlgoPerPool))
+	}
+	if v.PoolsPerNode < 1 || v.PoolsPerNode > int(constraints.MaxPoolsPerNode) {
+		return fmt.Errorf("pools per node must be between 1 and %d", constraints.MaxPoolsPerNode)
+	}
+	if len(v.EntryGatingAssets) > 4 {
+		return errors.New("at most 4 entry gating assets can be specified")
+	}
+	var firstGatingAsset uint64
+	if len(v.EntryGatingAssets) > 0 {
+		firstGatingAsset = v.EntryGatingAssets[0]
+	}
+	switch v.EntryGatingType {
+	case GatingTypeNone:
+	case GatingTypeAssetsCreatedBy:
+		if gatingAddr, err := decodeOptionalAddress(v.EntryGatingAddress); err != nil || gatingAddr == types.ZeroAddress {
+			return errors.New("entry gating by asset creator requires a valid creator address")
 		}
-		pkAsString := func(pk []uint8) string {
-			addr, _ := types.EncodeAddress(pk)
-			return addr
+	case GatingTypeAssetId:
+		if firstGatingAsset == 0 {
+			return errors.New("entry gating by asset requires at least one asset id")
 		}
-		arrValues := func(arr []any) []uint64 {
-			values := make([]uint64, len(arr))
-			for i, v := range arr {
-				values[i] = v.(uint64)
-			}
-			return values
+	case GatingTypeCreatedByNFDAddresses, GatingTypeSegmentOfNFD:
+		if firstGatingAsset == 0 {
+			return errors.New("entry gating by nfd requires the nfd app id as the first gating asset")
 		}
-		config := &ValidatorConfig{}
-		config.ID = arrReturn[0].(uint64)
-		config.Owner = pkAsString(arrReturn[1].([]uint8))
-		config.Manager = pkAsString(arrReturn[2].([]uint8))
-		config.NFDForInfo = arrReturn[3].(uint64)
-		config.EntryGatingType = arrReturn[4].(uint8)
-		config.EntryGatingAddress = pkAsString(arrReturn[5].([]uint8))
-		config.EntryGatingAssets = arrValues(arrReturn[6].([]any))
-		config.GatingAssetMinBalance = arrReturn[7].(uint64)
-		config.RewardTokenId = arrReturn[8].(uint64)
-		config.RewardPerPayout = arrReturn[9].(uint64)
-		config.EpochRoundLength = int(arrReturn[10].(uint32))
-		config.PercentToValidator = int(arrReturn[11].(uint32))
-		config.ValidatorCommissionAddress = pkAsString(arrReturn[12].([]uint8))
-		config.MinEntryStake = arrReturn[13].(uint64)
-		config.MaxAlgoPerPool = arrReturn[14].(uint64)
-		config.PoolsPerNode = int(arrReturn[15].(uint8))
-		config.SunsettingOn = arrReturn[16].(uint64)
-		config.SunsettingTo = arrReturn[17].(uint64)
-		return config, nil
-	}
-	return nil, fmt.Errorf("unknown value returned from abi, type:%T", returnVal)
+	default:
+		return fmt.Errorf("invalid entry gating type:%d", v.EntryGatingType)
+	}
+	if v.RewardPerPayout != 0 && v.RewardTokenId == 0 {
+		return errors.New("reward per payout can only be set if a reward token is defined")
+	}
+	if v.ID != 0 && v.SunsettingTo == v.ID {
+		return errors.New("validator can't sunset to itself")
+	}
+	return nil
+}
+
+// decodeOptionalAddress decodes an algorand address, treating an empty string as the zero address
+func decodeOptionalAddress(address string) (types.Address, error) {
+	if address == "" {
+		return types.ZeroAddress, nil
+	}
+	return types.DecodeAddress(address)
+}
+
+func ValidatorConfigFromABI(abiConfig ABIValidatorConfig) *ValidatorConfig {
+	return &ValidatorConfig{
+		ID:                         abiConfig.Id,
+		Owner:                      abiConfig.Owner.String(),
+		Manager:                    abiConfig.Manager.String(),
+		NFDForInfo:                 abiConfig.NfdForInfo,
+		EntryGatingType:            abiConfig.EntryGatingType,
+		EntryGatingAddress:         abiConfig.EntryGatingAddress.String(),
+		EntryGatingAssets:          abiConfig.EntryGatingAssets[:],
+		GatingAssetMinBalance:      abiConfig.GatingAssetMinBalance,
+		RewardTokenId:              abiConfig.RewardTokenId,
+		RewardPerPayout:            abiConfig.RewardPerPayout,
+		EpochRoundLength:           int(abiConfig.EpochRoundLength),
+		PercentToValidator:         int(abiConfig.PercentToValidator),
+		ValidatorCommissionAddress: abiConfig.ValidatorCommissionAddress.String(),
+		MinEntryStake:              abiConfig.MinEntryStake,
+		MaxAlgoPerPool:             abiConfig.MaxAlgoPerPool,
+		PoolsPerNode:               int(abiConfig.PoolsPerNode),
+		SunsettingOn:               abiConfig.SunsettingOn,
+		SunsettingTo:               abiConfig.SunsettingTo,
+	}
 }
 
 type ProtocolConstraints struct {
-	epochPayoutRoundsMin           uint64
-	epochPayoutRoundsMax           uint64
+	EpochPayoutRoundsMin           uint64
+	EpochPayoutRoundsMax           uint64
 	MinPctToValidatorWFourDecimals uint64
 	MaxPctToValidatorWFourDecimals uint64
 	MinEntryStake                  uint64 // in microAlgo
@@ -144,26 +199,20 @@ type ProtocolConstraints struct {
 	MaxStakersPerPool              uint64
 }
 
-func ProtocolConstraintsFromABIReturn(returnVal any) (*ProtocolConstraints, error) {
-	if arrReturn, ok := returnVal.([]any); ok {
-		if len(arrReturn) != 11 {
-			return nil, fmt.Errorf("should be 10 elements returned in ProtocolConstraints response")
-		}
-		constraints := &ProtocolConstraints{}
-		constraints.epochPayoutRoundsMin = arrReturn[0].(uint64)
-		constraints.epochPayoutRoundsMax = arrReturn[1].(uint64)
-		constraints.MinPctToValidatorWFourDecimals = arrReturn[2].(uint64)
-		constraints.MaxPctToValidatorWFourDecimals = arrReturn[3].(uint64)
-		constraints.MinEntryStake = arrReturn[4].(uint64)
-		constraints.MaxAlgoPerPool = arrReturn[5].(uint64)
-		constraints.MaxAlgoPerValidator = arrReturn[6].(uint64)
-		constraints.AmtConsideredSaturated = arrReturn[7].(uint64)
-		constraints.MaxNodes = arrReturn[8].(uint64)
-		constraints.MaxPoolsPerNode = arrReturn[9].(uint64)
-		constraints.MaxStakersPerPool = arrReturn[10].(uint64)
-		return constraints, nil
-	}
-	return nil, fmt.Errorf("unknown value returned from abi, type:%T", returnVal)
+func ProtocolConstraintsFromABI(abiConstraints ABIConstraints) *ProtocolConstraints {
+	return &ProtocolConstraints{
+		EpochPayoutRoundsMin:           abiConstraints.EpochPayoutRoundsMin,
+		EpochPayoutRoundsMax:           abiConstraints.EpochPayoutRoundsMax,
+		MinPctToValidatorWFourDecimals: abiConstraints.MinPctToValidatorWFourDecimals,
+		MaxPctToValidatorWFourDecimals: abiConstraints.MaxPctToValidatorWFourDecimals,
+		MinEntryStake:                  abiConstraints.MinEntryStake,
+		MaxAlgoPerPool:                 abiConstraints.MaxAlgoPerPool,
+		MaxAlgoPerValidator:            abiConstraints.MaxAlgoPerValidator,
+		AmtConsideredSaturated:         abiConstraints.AmtConsideredSaturated,
+		MaxNodes:                       abiConstraints.MaxNodes,
+		MaxPoolsPerNode:                abiConstraints.MaxPoolsPerNode,
+		MaxStakersPerPool:              abiConstraints.MaxStakersPerPool,
+	}
 }
 
 func formattedMinutes(mins int) string {
@@ -240,20 +289,13 @@ func (v *ValidatorCurState) String() string {
 	return fmt.Sprintf("numPools: %d, totalStakers: %d, totalAlgoStaked: %d", v.NumPools, v.TotalStakers, v.TotalAlgoStaked)
 }
 
-func ValidatorCurStateFromABIReturn(returnVal any) (*ValidatorCurState, error) {
-	if arrReturn, ok := returnVal.([]any); ok {
-		if len(arrReturn) != 4 {
-			return nil, fmt.Errorf("should be 4 elements returned in ValidatorCurState response")
-		}
-		state := &ValidatorCurState{}
-		state.NumPools = int(arrReturn[0].(uint16))
-		state.TotalStakers = arrReturn[1].(uint64)
-		state.TotalAlgoStaked = arrReturn[2].(uint64)
-		state.RewardTokenHeldBack = arrReturn[3].(uint64)
-
-		return state, nil
+func ValidatorCurStateFromABI(abiState ABIValidatorCurState) *ValidatorCurState {
+	return &ValidatorCurState{
+		NumPools:            int(abiState.NumPools),
+		TotalStakers:        abiState.TotalStakers,
+		TotalAlgoStaked:     abiState.TotalAlgoStaked,
+		RewardTokenHeldBack: abiState.RewardTokenHeldBack,
 	}
-	return nil, fmt.Errorf("unknown value returned from abi, type:%T", returnVal)
 }
 
 type ValidatorPoolKey struct {
@@ -266,19 +308,20 @@ func (v *ValidatorPoolKey) String() string {
 	return fmt.Sprintf("ValidatorPoolKey{id: %d, poolId: %d, poolAppId: %d}", v.ID, v.PoolId, v.PoolAppId)
 }
 
-func ValidatorPoolKeyFromABIReturn(returnVal any) (*ValidatorPoolKey, error) {
-	if arrReturn, ok := returnVal.([]any); ok {
-		if len(arrReturn) != 3 {
-			return nil, fmt.Errorf("should be 3 elements returned in ValidatorPoolKey response")
-		}
-		key := &ValidatorPoolKey{}
-		key.ID = arrReturn[0].(uint64)
-		key.PoolId = arrReturn[1].(uint64)
-		key.PoolAppId = arrReturn[2].(uint64)
+func ValidatorPoolKeyFromABI(abiKey ABIValidatorPoolKey) *ValidatorPoolKey {
+	return &ValidatorPoolKey{
+		ID:        abiKey.Id,
+		PoolId:    abiKey.PoolId,
+		PoolAppId: abiKey.PoolAppId,
+	}
+}
 
-		return key, nil
+func (v *ValidatorPoolKey) abiKey() ABIValidatorPoolKey {
+	return ABIValidatorPoolKey{
+		Id:        v.ID,
+		PoolId:    v.PoolId,
+		PoolAppId: v.PoolAppId,
 	}
-	return nil, ErrCantFetchPoolKey
 }
 
 type PoolInfo struct {
@@ -287,45 +330,18 @@ type PoolInfo struct {
 	TotalAlgoStaked uint64
 }
 
-func ValidatorPoolsFromABIReturn(returnVal any) ([]PoolInfo, error) {
-	var retPools []PoolInfo
-	if arrReturn, ok := returnVal.([]any); ok {
-		for _, poolInfoAny := range arrReturn {
-			if poolInfo, ok := poolInfoAny.([]any); ok {
-				if len(poolInfo) != 3 {
-					return nil, fmt.Errorf("should be 3 elements returned in PoolInfo response")
-				}
-				retPools = append(retPools, PoolInfo{
-					PoolAppId:       poolInfo[0].(uint64),
-					TotalStakers:    int(poolInfo[1].(uint16)),
-					TotalAlgoStaked: poolInfo[2].(uint64),
-				})
-			}
-		}
-		return retPools, nil
+func PoolInfoFromABI(abiPool ABIPoolInfo) *PoolInfo {
+	return &PoolInfo{
+		PoolAppId:       abiPool.PoolAppId,
+		TotalStakers:    int(abiPool.TotalStakers),
+		TotalAlgoStaked: abiPool.TotalAlgoStaked,
 	}
-	return retPools, ErrCantFetchPoolKey
-}
-
-func ValidatorPoolInfoFromABIReturn(returnVal any) (*PoolInfo, error) {
-	if arrReturn, ok := returnVal.([]any); ok {
-		if len(arrReturn) != 3 {
-			return nil, fmt.Errorf("should be 3 elements returned in PoolInfo response")
-		}
-		key := &PoolInfo{}
-		key.PoolAppId = arrReturn[0].(uint64)
-		key.TotalStakers = int(arrReturn[1].(uint16))
-		key.PoolAppId = arrReturn[2].(uint64)
-
-		return key, nil
-	}
-	return nil, ErrCantFetchPoolKey
 }
 
 func (r *Reti) AddValidator(info *ValidatorInfo, nfdName string) (uint64, error) {
 	var err error
 
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return 0, err
 	}
@@ -333,10 +349,31 @@ func (r *Reti) AddValidator(info *ValidatorInfo, nfdName string) (uint64, error)
 	ownerAddr, _ := types.DecodeAddress(info.Config.Owner)
 	managerAddr, _ := types.DecodeAddress(info.Config.Manager)
 	commissionAddr, _ := types.DecodeAddress(info.Config.ValidatorCommissionAddress)
-	//mustHoldCreatorAddr, _ := types.DecodeAddress(info.config.MustHoldCreatorNFT)
+	gatingAddr, err := decodeOptionalAddress(info.Config.EntryGatingAddress)
+	if err != nil {
+		return 0, fmt.Errorf("invalid entry gating address: %w", err)
+	}
+	var gatingAssets [4]uint64
+	copy(gatingAssets[:], info.Config.EntryGatingAssets)
+
+	// the validator nfd and any nfd used for gating are verified by the registry calling the nfd registry
+	var foreignApps []uint64
+	if info.Config.NFDForInfo != 0 {
+		foreignApps = append(foreignApps, info.Config.NFDForInfo)
+	}
+	if info.Config.EntryGatingType == GatingTypeCreatedByNFDAddresses || info.Config.EntryGatingType == GatingTypeSegmentOfNFD {
+		foreignApps = append(foreignApps, gatingAssets[0])
+	}
+	if len(foreignApps) > 0 {
+		nfdRegistryID, err := r.GetNFDRegistryID()
+		if err != nil {
+			return 0, err
+		}
+		foreignApps = append(foreignApps, nfdRegistryID)
+	}
 
 	// first determine how much we have to add in MBR to the validator
-	mbrs, err := r.getMbrAmounts(ownerAddr)
+	mbrs, err := r.GetMbrAmounts(ownerAddr)
 	if err != nil {
 		return 0, err
 	}
@@ -344,10 +381,6 @@ func (r *Reti) AddValidator(info *ValidatorInfo, nfdName string) (uint64, error)
 	// Now try to actually create the validator !!
 	atc := transaction.AtomicTransactionComposer{}
 
-	addValidatorMethod, err := r.validatorContract.GetMethodByName("addValidator")
-	if err != nil {
-		return 0, err
-	}
 	// We need to set all the box references ourselves still in go, so we need the id of the 'next' validator
 	// We'll do the next two just to be safe (for race condition of someone else adding validator before us)
 	curValidatorId, err := r.GetNumValidators()
@@ -367,35 +400,9 @@ func (r *Reti) AddValidator(info *ValidatorInfo, nfdName string) (uint64, error)
 	}
 	params.Fee = 1000
 
-	err = atc.AddMethodCall(transaction.AddMethodCallParams{
-		AppID:  r.RetiAppId,
-		Method: addValidatorMethod,
-		MethodArgs: []any{
-			// MBR payment
-			payTxWithSigner,
-			// --
-			nfdName,
-			[]any{
-				0, // id is ignored and assigned by contract
-				ownerAddr,
-				managerAddr,
-				info.Config.NFDForInfo,
-				0, // gating type none
-				types.ZeroAddress,
-				[]uint64{0, 0, 0, 0},
-				info.Config.GatingAssetMinBalance,
-				info.Config.RewardTokenId,
-				info.Config.RewardPerPayout,
-				uint32(info.Config.EpochRoundLength),
-				uint16(info.Config.PercentToValidator),
-				commissionAddr,
-				info.Config.MinEntryStake,
-				info.Config.MaxAlgoPerPool,
-				uint8(info.Config.PoolsPerNode),
-				info.Config.SunsettingOn,
-				info.Config.SunsettingTo,
-			},
-		},
+	err = atc.AddMethodCall(r.validatorABI.AddValidator(transaction.AddMethodCallParams{
+		AppID:       r.RetiAppId,
+		ForeignApps: foreignApps,
 		BoxReferences: []types.AppBoxReference{
 			{AppID: 0, Name: GetValidatorListBoxName(curValidatorId + 1)},
 			{AppID: 0, Name: GetValidatorListBoxName(curValidatorId + 2)},
@@ -405,25 +412,47 @@ func (r *Reti) AddValidator(info *ValidatorInfo, nfdName string) (uint64, error)
 		OnComplete:      types.NoOpOC,
 		Sender:          ownerAddr,
 		Signer:          algo.SignWithAccountForATC(r.signer, ownerAddr.String()),
-	})
+	},
+		// MBR payment
+		payTxWithSigner,
+		// --
+		nfdName,
+		ABIValidatorConfig{
+			Id:                         0, // id is ignored and assigned by contract
+			Owner:                      ownerAddr,
+			Manager:                    managerAddr,
+			NfdForInfo:                 info.Config.NFDForInfo,
+			EntryGatingType:            info.Config.EntryGatingType,
+			EntryGatingAddress:         gatingAddr,
+			EntryGatingAssets:          gatingAssets,
+			GatingAssetMinBalance:      info.Config.GatingAssetMinBalance,
+			RewardTokenId:              info.Config.RewardTokenId,
+			RewardPerPayout:            info.Config.RewardPerPayout,
+			EpochRoundLength:           uint32(info.Config.EpochRoundLength),
+			PercentToValidator:         uint32(info.Config.PercentToValidator),
+			ValidatorCommissionAddress: commissionAddr,
+			MinEntryStake:              info.Config.MinEntryStake,
+			MaxAlgoPerPool:             info.Config.MaxAlgoPerPool,
+			PoolsPerNode:               uint8(info.Config.PoolsPerNode),
+			SunsettingOn:               info.Config.SunsettingOn,
+			SunsettingTo:               info.Config.SunsettingTo,
+		},
+	))
 	if err != nil {
 		return 0, fmt.Errorf("error in atc compose: %w", err)
 	}
 
-	result, err := atc.Execute(r.algoClient, context.Background(), 4)
+	result, err := r.chain.ExecuteATC(context.Background(), &atc, 4)
 	if err != nil {
 		return 0, err
 	}
-	if validatorId, ok := result.MethodResults[0].ReturnValue.(uint64); ok {
-		return validatorId, nil
-	}
-	return 0, nil
+	return r.validatorABI.AddValidatorResult(result.MethodResults[0])
 }
 
 func (r *Reti) GetProtocolConstraints() (*ProtocolConstraints, error) {
 	var err error
 
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -435,18 +464,15 @@ func (r *Reti) GetProtocolConstraints() (*ProtocolConstraints, error) {
 	// Now try to actually create the validator !!
 	atc := transaction.AtomicTransactionComposer{}
 
-	method, _ := r.validatorContract.GetMethodByName("getProtocolConstraints")
-	atc.AddMethodCall(transaction.AddMethodCallParams{
+	atc.AddMethodCall(r.validatorABI.GetProtocolConstraints(transaction.AddMethodCallParams{
 		AppID:           r.RetiAppId,
-		Method:          method,
-		MethodArgs:      []any{},
 		SuggestedParams: params,
 		OnComplete:      types.NoOpOC,
 		Sender:          dummyAddr,
 		Signer:          transaction.EmptyTransactionSigner{},
-	})
+	}))
 
-	result, err := atc.Simulate(context.Background(), r.algoClient, models.SimulateRequest{
+	result, err := r.chain.SimulateATC(context.Background(), &atc, models.SimulateRequest{
 		AllowEmptySignatures:  true,
 		AllowUnnamedResources: true,
 	})
@@ -456,13 +482,147 @@ func (r *Reti) GetProtocolConstraints() (*ProtocolConstraints, error) {
 	if result.SimulateResponse.TxnGroups[0].FailureMessage != "" {
 		return nil, fmt.Errorf("error retrieving protocol constraints: %s", result.SimulateResponse.TxnGroups[0].FailureMessage)
 	}
-	return ProtocolConstraintsFromABIReturn(result.MethodResults[0].ReturnValue)
+	constraints, err := r.validatorABI.GetProtocolConstraintsResult(result.MethodResults[0])
+	if err != nil {
+		return nil, err
+	}
+	return ProtocolConstraintsFromABI(constraints), nil
+}
+
+// GetNFDRegistryID returns the NFD registry application id the validator registry was deployed with
+func (r *Reti) GetNFDRegistryID() (uint64, error) {
+	var err error
+
+	params, err := r.chain.SuggestedParams(context.Background())
+	if err != nil {
+		return 0, err
+	}
+
+	dummyAddr, err := r.getLocalSignerForSimulateCalls()
+	if err != nil {
+		return 0, err
+	}
+	atc := transaction.AtomicTransactionComposer{}
+
+	atc.AddMethodCall(r.validatorABI.GetNFDRegistryID(transaction.AddMethodCallParams{
+		AppID:           r.RetiAppId,
+		SuggestedParams: params,
+		OnComplete:      types.NoOpOC,
+		Sender:          dummyAddr,
+		Signer:          transaction.EmptyTransactionSigner{},
+	}))
+
+	result, err := r.chain.SimulateATC(context.Background(), &atc, models.SimulateRequest{
+		AllowEmptySignatures:  true,
+		AllowUnnamedResources: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if result.SimulateResponse.TxnGroups[0].FailureMessage != "" {
+		return 0, fmt.Errorf("error retrieving nfd registry id: %s", result.SimulateResponse.TxnGroups[0].FailureMessage)
+	}
+	return r.validatorABI.GetNFDRegistryIDResult(result.MethodResults[0])
+}
+
+// Validator is a validator's record in the registry's validator list - everything its validator list box holds
+type Validator struct {
+	Config              ValidatorConfig
+	State               ValidatorCurState
+	Pools               []PoolInfo
+	NodePoolAssignments NodePoolAssignmentConfig
+}
+
+// GetValidator returns the validator's record, decoded from a single fetch of its validator list box, falling back to
+// simulating the registry's getters if that fails.  Use it rather than the individual getters (which each fetch the
+// box) when more than one part of the record is needed.
+func (r *Reti) GetValidator(id uint64) (*Validator, error) {
+	if validator, err := r.readValidatorListBox(id); err == nil {
+		return validator, nil
+	}
+	config, err := r.simulateGetValidatorConfig(id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to GetValidatorConfig: %w", err)
+	}
+	state, err := r.simulateGetValidatorState(id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to GetValidatorState: %w", err)
+	}
+	pools, err := r.simulateGetValidatorPools(id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to GetValidatorPools: %w", err)
+	}
+	assignments, err := r.simulateGetValidatorNodePoolAssignments(id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to GetValidatorNodePoolAssignments: %w", err)
+	}
+	return &Validator{
+		Config:              *config,
+		State:               *state,
+		Pools:               pools,
+		NodePoolAssignments: *assignments,
+	}, nil
+}
+
+// GetValidatorInfo returns the configuration, pools and node pool assignments of a validator (see GetValidator).
+// LocalPools isn't set.
+func (r *Reti) GetValidatorInfo(id uint64) (*ValidatorInfo, error) {
+	validator, err := r.GetValidator(id)
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatorInfo{
+		Config:              validator.Config,
+		Pools:               validator.Pools,
+		NodePoolAssignments: validator.NodePoolAssignments,
+	}, nil
+}
+
+// readValidatorListBox fetches and decodes the validator's box directly - avoiding a simulate call for each of the
+// registry's getters.  Callers fall back to simulate if it fails (box layout changed with a contract update, etc.)
+func (r *Reti) readValidatorListBox(id uint64) (*Validator, error) {
+	box, err := r.chain.ApplicationBoxByName(context.Background(), r.RetiAppId, GetValidatorListBoxName(id))
+	if err != nil {
+		r.Logger.Debug("unable to fetch validator box, falling back to simulate", "id", id, "error", err)
+		return nil, err
+	}
+	validator, err := r.validatorABI.DecodeValidatorListBox(box.Value)
+	if err != nil {
+		r.Logger.Debug("unable to decode validator box, falling back to simulate", "id", id, "error", err)
+		return nil, err
+	}
+	return &Validator{
+		Config:              *ValidatorConfigFromABI(validator.Config),
+		State:               *ValidatorCurStateFromABI(validator.State),
+		Pools:               poolsFromValidatorBox(&validator),
+		NodePoolAssignments: *NodePoolAssignmentFromABI(validator.NodePoolAssignments),
+	}, nil
+}
+
+// poolsFromValidatorBox returns the validator's pools, stopping at the first unused slot as the registry's getPools
+// does
+func poolsFromValidatorBox(validator *ABIValidatorInfo) []PoolInfo {
+	var retPools []PoolInfo
+	for _, pool := range validator.Pools {
+		if pool.PoolAppId == 0 {
+			break
+		}
+		retPools = append(retPools, *PoolInfoFromABI(pool))
+	}
+	return retPools
 }
 
 func (r *Reti) GetValidatorConfig(id uint64) (*ValidatorConfig, error) {
+	if validator, err := r.readValidatorListBox(id); err == nil {
+		return &validator.Config, nil
+	}
+	return r.simulateGetValidatorConfig(id)
+}
+
+func (r *Reti) simulateGetValidatorConfig(id uint64) (*ValidatorConfig, error) {
 	var err error
 
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -474,14 +634,8 @@ func (r *Reti) GetValidatorConfig(id uint64) (*ValidatorConfig, error) {
 	// Now try to actually create the validator !!
 	atc := transaction.AtomicTransactionComposer{}
 
-	method, err := r.validatorContract.GetMethodByName("getValidatorConfig")
-	if err != nil {
-		return nil, err
-	}
-	atc.AddMethodCall(transaction.AddMethodCallParams{
-		AppID:      r.RetiAppId,
-		Method:     method,
-		MethodArgs: []any{id},
+	atc.AddMethodCall(r.validatorABI.GetValidatorConfig(transaction.AddMethodCallParams{
+		AppID: r.RetiAppId,
 		BoxReferences: []types.AppBoxReference{
 			{AppID: 0, Name: GetValidatorListBoxName(id)},
 			{AppID: 0, Name: nil}, // extra i/o
@@ -490,9 +644,9 @@ func (r *Reti) GetValidatorConfig(id uint64) (*ValidatorConfig, error) {
 		OnComplete:      types.NoOpOC,
 		Sender:          dummyAddr,
 		Signer:          transaction.EmptyTransactionSigner{},
-	})
+	}, id))
 
-	result, err := atc.Simulate(context.Background(), r.algoClient, models.SimulateRequest{
+	result, err := r.chain.SimulateATC(context.Background(), &atc, models.SimulateRequest{
 		AllowEmptySignatures:  true,
 		AllowUnnamedResources: true,
 	})
@@ -502,13 +656,24 @@ func (r *Reti) GetValidatorConfig(id uint64) (*ValidatorConfig, error) {
 	if result.SimulateResponse.TxnGroups[0].FailureMessage != "" {
 		return nil, fmt.Errorf("error retrieving validator config: %s", result.SimulateResponse.TxnGroups[0].FailureMessage)
 	}
-	return ValidatorConfigFromABIReturn(result.MethodResults[0].ReturnValue)
+	config, err := r.validatorABI.GetValidatorConfigResult(result.MethodResults[0])
+	if err != nil {
+		return nil, err
+	}
+	return ValidatorConfigFromABI(config), nil
 }
 
 func (r *Reti) GetValidatorState(id uint64) (*ValidatorCurState, error) {
+	if validator, err := r.readValidatorListBox(id); err == nil {
+		return &validator.State, nil
+	}
+	return r.simulateGetValidatorState(id)
+}
+
+func (r *Reti) simulateGetValidatorState(id uint64) (*ValidatorCurState, error) {
 	var err error
 
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -521,14 +686,8 @@ func (r *Reti) GetValidatorState(id uint64) (*ValidatorCurState, error) {
 	// Now try to actually create the validator !!
 	atc := transaction.AtomicTransactionComposer{}
 
-	method, err := r.validatorContract.GetMethodByName("getValidatorState")
-	if err != nil {
-		return nil, err
-	}
-	atc.AddMethodCall(transaction.AddMethodCallParams{
-		AppID:      r.RetiAppId,
-		Method:     method,
-		MethodArgs: []any{id},
+	atc.AddMethodCall(r.validatorABI.GetValidatorState(transaction.AddMethodCallParams{
+		AppID: r.RetiAppId,
 		BoxReferences: []types.AppBoxReference{
 			{AppID: 0, Name: GetValidatorListBoxName(id)},
 			{AppID: 0, Name: nil}, // extra i/o
@@ -537,22 +696,33 @@ func (r *Reti) GetValidatorState(id uint64) (*ValidatorCurState, error) {
 		OnComplete:      types.NoOpOC,
 		Sender:          dummyAddr,
 		Signer:          transaction.EmptyTransactionSigner{},
-	})
+	}, id))
 
-	result, err := atc.Simulate(context.Background(), r.algoClient, models.SimulateRequest{
+	result, err := r.chain.SimulateATC(context.Background(), &atc, models.SimulateRequest{
 		AllowEmptySignatures:  true,
 		AllowUnnamedResources: true,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return ValidatorCurStateFromABIReturn(result.MethodResults[0].ReturnValue)
+	state, err := r.validatorABI.GetValidatorStateResult(result.MethodResults[0])
+	if err != nil {
+		return nil, err
+	}
+	return ValidatorCurStateFromABI(state), nil
 }
 
 func (r *Reti) GetValidatorPools(id uint64) ([]PoolInfo, error) {
+	if validator, err := r.readValidatorListBox(id); err == nil {
+		return validator.Pools, nil
+	}
+	return r.simulateGetValidatorPools(id)
+}
+
+func (r *Reti) simulateGetValidatorPools(id uint64) ([]PoolInfo, error) {
 	var err error
 
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -565,14 +735,8 @@ func (r *Reti) GetValidatorPools(id uint64) ([]PoolInfo, error) {
 	// Now try to actually create the validator !!
 	atc := transaction.AtomicTransactionComposer{}
 
-	getPoolInfoMethod, err := r.validatorContract.GetMethodByName("getPools")
-	if err != nil {
-		return nil, err
-	}
-	atc.AddMethodCall(transaction.AddMethodCallParams{
-		AppID:      r.RetiAppId,
-		Method:     getPoolInfoMethod,
-		MethodArgs: []any{id},
+	atc.AddMethodCall(r.validatorABI.GetPools(transaction.AddMethodCallParams{
+		AppID: r.RetiAppId,
 		BoxReferences: []types.AppBoxReference{
 			{AppID: 0, Name: GetValidatorListBoxName(id)},
 			{AppID: 0, Name: nil}, // extra i/o
@@ -581,9 +745,9 @@ func (r *Reti) GetValidatorPools(id uint64) ([]PoolInfo, error) {
 		OnComplete:      types.NoOpOC,
 		Sender:          dummyAddr,
 		Signer:          transaction.EmptyTransactionSigner{},
-	})
+	}, id))
 
-	result, err := atc.Simulate(context.Background(), r.algoClient, models.SimulateRequest{
+	result, err := r.chain.SimulateATC(context.Background(), &atc, models.SimulateRequest{
 		AllowEmptySignatures:  true,
 		AllowMoreLogging:      true,
 		AllowUnnamedResources: true,
@@ -591,13 +755,21 @@ func (r *Reti) GetValidatorPools(id uint64) ([]PoolInfo, error) {
 	if err != nil {
 		return nil, err
 	}
-	return ValidatorPoolsFromABIReturn(result.MethodResults[0].ReturnValue)
+	pools, err := r.validatorABI.GetPoolsResult(result.MethodResults[0])
+	if err != nil {
+		return nil, err
+	}
+	var retPools []PoolInfo
+	for _, pool := range pools {
+		retPools = append(retPools, *PoolInfoFromABI(pool))
+	}
+	return retPools, nil
 }
 
 func (r *Reti) GetValidatorPoolInfo(poolKey ValidatorPoolKey) (*PoolInfo, error) {
 	var err error
 
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -610,11 +782,8 @@ func (r *Reti) GetValidatorPoolInfo(poolKey ValidatorPoolKey) (*PoolInfo, error)
 	// Now try to actually create the validator !!
 	atc := transaction.AtomicTransactionComposer{}
 
-	getPoolInfoMethod, _ := r.validatorContract.GetMethodByName("getPoolInfo")
-	_ = atc.AddMethodCall(transaction.AddMethodCallParams{
+	_ = atc.AddMethodCall(r.validatorABI.GetPoolInfo(transaction.AddMethodCallParams{
 		AppID:       r.RetiAppId,
-		Method:      getPoolInfoMethod,
-		MethodArgs:  []any{poolKey.ID, poolKey.PoolId, poolKey.PoolAppId},
 		ForeignApps: []uint64{poolKey.PoolAppId},
 		BoxReferences: []types.AppBoxReference{
 			{AppID: 0, Name: GetValidatorListBoxName(poolKey.PoolAppId)},
@@ -624,39 +793,37 @@ func (r *Reti) GetValidatorPoolInfo(poolKey ValidatorPoolKey) (*PoolInfo, error)
 		OnComplete:      types.NoOpOC,
 		Sender:          dummyAddr,
 		Signer:          transaction.EmptyTransactionSigner{},
-	})
+	}, poolKey.abiKey()))
 
-	result, err := atc.Simulate(context.Background(), r.algoClient, models.SimulateRequest{
+	result, err := r.chain.SimulateATC(context.Background(), &atc, models.SimulateRequest{
 		AllowEmptySignatures:  true,
 		AllowUnnamedResources: true,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return ValidatorPoolInfoFromABIReturn(result.MethodResults[0].ReturnValue)
-}
-
-func (r *Reti) GetStakedPoolsForAccount(staker types.Address) ([]*ValidatorPoolKey, error) {
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	poolInfo, err := r.validatorABI.GetPoolInfoResult(result.MethodResults[0])
 	if err != nil {
 		return nil, err
 	}
+	return PoolInfoFromABI(poolInfo), nil
+}
 
-	method, err := r.validatorContract.GetMethodByName("getStakedPoolsForAccount")
+func (r *Reti) GetStakedPoolsForAccount(staker types.Address) ([]*ValidatorPoolKey, error) {
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return nil, err
 	}
+
 	atc := transaction.AtomicTransactionComposer{}
-	atc.AddMethodCall(transaction.AddMethodCallParams{
+	atc.AddMethodCall(r.validatorABI.GetStakedPoolsForAccount(transaction.AddMethodCallParams{
 		AppID:           r.RetiAppId,
-		Method:          method,
-		MethodArgs:      []any{staker},
 		SuggestedParams: params,
 		OnComplete:      types.NoOpOC,
 		Sender:          staker,
 		Signer:          transaction.EmptyTransactionSigner{},
-	})
-	result, err := atc.Simulate(context.Background(), r.algoClient, models.SimulateRequest{
+	}, staker))
+	result, err := r.chain.SimulateATC(context.Background(), &atc, models.SimulateRequest{
 		AllowEmptySignatures:  true,
 		AllowUnnamedResources: true,
 	})
@@ -666,25 +833,28 @@ func (r *Reti) GetStakedPoolsForAccount(staker types.Address) ([]*ValidatorPoolK
 	if result.SimulateResponse.TxnGroups[0].FailureMessage != "" {
 		return nil, errors.New(result.SimulateResponse.TxnGroups[0].FailureMessage)
 	}
+	poolKeys, err := r.validatorABI.GetStakedPoolsForAccountResult(result.MethodResults[0])
+	if err != nil {
+		return nil, err
+	}
 	var retPools []*ValidatorPoolKey
-	if arrReturn, ok := result.MethodResults[0].ReturnValue.([]any); ok {
-		for _, poolInfoAny := range arrReturn {
-			poolKey, err := ValidatorPoolKeyFromABIReturn(poolInfoAny)
-			if err != nil {
-				return nil, err
-			}
-			retPools = append(retPools, poolKey)
-		}
-		return retPools, nil
+	for _, poolKey := range poolKeys {
+		retPools = append(retPools, ValidatorPoolKeyFromABI(poolKey))
 	}
-
-	return nil, fmt.Errorf("unknown result type:%#v", result.MethodResults)
+	return retPools, nil
 }
 
 func (r *Reti) GetValidatorNodePoolAssignments(id uint64) (*NodePoolAssignmentConfig, error) {
+	if validator, err := r.readValidatorListBox(id); err == nil {
+		return &validator.NodePoolAssignments, nil
+	}
+	return r.simulateGetValidatorNodePoolAssignments(id)
+}
+
+func (r *Reti) simulateGetValidatorNodePoolAssignments(id uint64) (*NodePoolAssignmentConfig, error) {
 	var err error
 
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -697,14 +867,8 @@ func (r *Reti) GetValidatorNodePoolAssignments(id uint64) (*NodePoolAssignmentCo
 	// Now try to actually create the validator !!
 	atc := transaction.AtomicTransactionComposer{}
 
-	getNodePoolAssignmentsMethod, err := r.validatorContract.GetMethodByName("getNodePoolAssignments")
-	if err != nil {
-		return nil, err
-	}
-	atc.AddMethodCall(transaction.AddMethodCallParams{
-		AppID:      r.RetiAppId,
-		Method:     getNodePoolAssignmentsMethod,
-		MethodArgs: []any{id},
+	atc.AddMethodCall(r.validatorABI.GetNodePoolAssignments(transaction.AddMethodCallParams{
+		AppID: r.RetiAppId,
 		BoxReferences: []types.AppBoxReference{
 			{AppID: 0, Name: GetValidatorListBoxName(id)},
 			{AppID: 0, Name: nil}, // extra i/o
@@ -713,9 +877,9 @@ func (r *Reti) GetValidatorNodePoolAssignments(id uint64) (*NodePoolAssignmentCo
 		OnComplete:      types.NoOpOC,
 		Sender:          dummyAddr,
 		Signer:          transaction.EmptyTransactionSigner{},
-	})
+	}, id))
 
-	result, err := atc.Simulate(context.Background(), r.algoClient, models.SimulateRequest{
+	result, err := r.chain.SimulateATC(context.Background(), &atc, models.SimulateRequest{
 		AllowEmptySignatures:  true,
 		AllowMoreLogging:      true,
 		AllowUnnamedResources: true,
@@ -723,52 +887,43 @@ func (r *Reti) GetValidatorNodePoolAssignments(id uint64) (*NodePoolAssignmentCo
 	if err != nil {
 		return nil, err
 	}
-	return NodePoolAssignmentFromABIReturn(result.MethodResults[0].ReturnValue)
+	assignments, err := r.validatorABI.GetNodePoolAssignmentsResult(result.MethodResults[0])
+	if err != nil {
+		return nil, err
+	}
+	return NodePoolAssignmentFromABI(assignments), nil
 }
 
-func NodePoolAssignmentFromABIReturn(returnVal any) (*NodePoolAssignmentConfig, error) {
+func NodePoolAssignmentFromABI(abiAssignments ABINodePoolAssignmentConfig) *NodePoolAssignmentConfig {
 	var retPAC = &NodePoolAssignmentConfig{}
-	if arrReturn, ok := returnVal.([]any); ok {
-		for _, nodeConfigAny := range arrReturn {
-			if nodes, ok := nodeConfigAny.([]any); ok {
-				for _, pools := range nodes {
-					if poolIDs, ok := pools.([]any); ok {
-						var ids []uint64
-						for _, id := range poolIDs[0].([]any) {
-							convertedID := id.(uint64)
-							if convertedID == 0 {
-								continue
-							}
-							ids = append(ids, convertedID)
-						}
-						retPAC.Nodes = append(retPAC.Nodes, NodeConfig{PoolAppIds: ids})
-					}
-				}
+	for _, node := range abiAssignments.Nodes {
+		var ids []uint64
+		for _, id := range node.Field0 {
+			if id == 0 {
+				continue
 			}
+			ids = append(ids, id)
 		}
-		return retPAC, nil
+		retPAC.Nodes = append(retPAC.Nodes, NodeConfig{PoolAppIds: ids})
 	}
-	return nil, ErrCantFetchPoolKey
+	return retPAC
 }
 
 func (r *Reti) FindPoolForStaker(id uint64, staker types.Address, amount uint64) (*ValidatorPoolKey, error) {
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return nil, err
 	}
 
-	findPoolMethod, _ := r.validatorContract.GetMethodByName("findPoolForStaker")
 	atc := transaction.AtomicTransactionComposer{}
-	atc.AddMethodCall(transaction.AddMethodCallParams{
+	atc.AddMethodCall(r.validatorABI.FindPoolForStaker(transaction.AddMethodCallParams{
 		AppID:           r.RetiAppId,
-		Method:          findPoolMethod,
-		MethodArgs:      []any{id, staker, amount},
 		SuggestedParams: params,
 		OnComplete:      types.NoOpOC,
 		Sender:          staker,
 		Signer:          transaction.EmptyTransactionSigner{},
-	})
-	result, err := atc.Simulate(context.Background(), r.algoClient, models.SimulateRequest{
+	}, id, staker, amount))
+	result, err := r.chain.SimulateATC(context.Background(), &atc, models.SimulateRequest{
 		AllowEmptySignatures:  true,
 		AllowUnnamedResources: true,
 	})
@@ -778,29 +933,26 @@ func (r *Reti) FindPoolForStaker(id uint64, staker types.Address, amount uint64)
 	if result.SimulateResponse.TxnGroups[0].FailureMessage != "" {
 		return nil, errors.New(result.SimulateResponse.TxnGroups[0].FailureMessage)
 	}
-	// findPoolForStaker returns [ValidatorPoolKey, boolean]
-	return ValidatorPoolKeyFromABIReturn(result.MethodResults[0].ReturnValue.([]any)[0])
+	// findPoolForStaker returns [ValidatorPoolKey, boolean, boolean]
+	found, err := r.validatorABI.FindPoolForStakerResult(result.MethodResults[0])
+	if err != nil {
+		return nil, err
+	}
+	return ValidatorPoolKeyFromABI(found.Field0), nil
 }
 
 func (r *Reti) ChangeValidatorManagerAddress(id uint64, sender types.Address, managerAddress types.Address) error {
 	var err error
 
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return err
 	}
 
 	atc := transaction.AtomicTransactionComposer{}
 
-	changeAddressMethod, _ := r.validatorContract.GetMethodByName("changeValidatorManager")
-	// We have to pay MBR into the Validator contract itself for adding a pool
-	atc.AddMethodCall(transaction.AddMethodCallParams{
-		AppID:  r.RetiAppId,
-		Method: changeAddressMethod,
-		MethodArgs: []any{
-			id,
-			managerAddress,
-		},
+	atc.AddMethodCall(r.validatorABI.ChangeValidatorManager(transaction.AddMethodCallParams{
+		AppID: r.RetiAppId,
 		BoxReferences: []types.AppBoxReference{
 			{AppID: 0, Name: GetValidatorListBoxName(id)},
 			{AppID: 0, Name: nil}, // extra i/o
@@ -809,8 +961,8 @@ func (r *Reti) ChangeValidatorManagerAddress(id uint64, sender types.Address, ma
 		OnComplete:      types.NoOpOC,
 		Sender:          sender,
 		Signer:          algo.SignWithAccountForATC(r.signer, sender.String()),
-	})
-	_, err = atc.Execute(r.algoClient, context.Background(), 4)
+	}, id, managerAddress))
+	_, err = r.chain.ExecuteATC(context.Background(), &atc, 4)
 	if err != nil {
 		return err
 	}
@@ -822,22 +974,15 @@ func (r *Reti) ChangeValidatorManagerAddress(id uint64, sender types.Address, ma
 func (r *Reti) ChangeValidatorCommissionAddress(id uint64, sender types.Address, commissionAddress types.Address) error {
 	var err error
 
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return err
 	}
 
 	atc := transaction.AtomicTransactionComposer{}
 
-	changeAddressMethod, _ := r.validatorContract.GetMethodByName("changeValidatorCommissionAddress")
-	// We have to pay MBR into the Validator contract itself for adding a pool
-	atc.AddMethodCall(transaction.AddMethodCallParams{
-		AppID:  r.RetiAppId,
-		Method: changeAddressMethod,
-		MethodArgs: []any{
-			id,
-			commissionAddress,
-		},
+	atc.AddMethodCall(r.validatorABI.ChangeValidatorCommissionAddress(transaction.AddMethodCallParams{
+		AppID: r.RetiAppId,
 		BoxReferences: []types.AppBoxReference{
 			{AppID: 0, Name: GetValidatorListBoxName(id)},
 			{AppID: 0, Name: nil}, // extra i/o
@@ -846,8 +991,8 @@ func (r *Reti) ChangeValidatorCommissionAddress(id uint64, sender types.Address,
 		OnComplete:      types.NoOpOC,
 		Sender:          sender,
 		Signer:          algo.SignWithAccountForATC(r.signer, sender.String()),
-	})
-	_, err = atc.Execute(r.algoClient, context.Background(), 4)
+	}, id, commissionAddress))
+	_, err = r.chain.ExecuteATC(context.Background(), &atc, 4)
 	if err != nil {
 		return err
 	}
@@ -856,13 +1001,159 @@ func (r *Reti) ChangeValidatorCommissionAddress(id uint64, sender types.Address,
 
 }
 
+func (r *Reti) ChangeValidatorNFD(id uint64, sender types.Address, nfdAppID uint64, nfdName string) error {
+	var err error
+
+	params, err := r.chain.SuggestedParams(context.Background())
+	if err != nil {
+		return err
+	}
+	nfdRegistryID, err := r.GetNFDRegistryID()
+	if err != nil {
+		return err
+	}
+
+	atc := transaction.AtomicTransactionComposer{}
+
+	// pay for the inner call to the nfd registry to verify the nfd
+	params.FlatFee = true
+	params.Fee = transaction.MinTxnFee * 2
+
+	atc.AddMethodCall(r.validatorABI.ChangeValidatorNFD(transaction.AddMethodCallParams{
+		AppID:       r.RetiAppId,
+		ForeignApps: []uint64{nfdAppID, nfdRegistryID},
+		BoxReferences: []types.AppBoxReference{
+			{AppID: 0, Name: GetValidatorListBoxName(id)},
+			{AppID: 0, Name: nil}, // extra i/o
+		},
+		SuggestedParams: params,
+		OnComplete:      types.NoOpOC,
+		Sender:          sender,
+		Signer:          algo.SignWithAccountForATC(r.signer, sender.String()),
+	}, id, nfdAppID, nfdName))
+	_, err = r.chain.ExecuteATC(context.Background(), &atc, 4)
+	return err
+}
+
+// ChangeValidatorRewardInfo sets the entry gating and reward per payout settings of the validator to those in config.
+// The reward token itself can't be changed once the validator is created.
+func (r *Reti) ChangeValidatorRewardInfo(config *ValidatorConfig, sender types.Address) error {
+	var err error
+
+	params, err := r.chain.SuggestedParams(context.Background())
+	if err != nil {
+		return err
+	}
+
+	gatingAddress, err := decodeOptionalAddress(config.EntryGatingAddress)
+	if err != nil {
+		return fmt.Errorf("invalid entry gating address: %w", err)
+	}
+	var gatingAssets [4]uint64
+	if len(config.EntryGatingAssets) > len(gatingAssets) {
+		return fmt.Errorf("at most %d entry gating assets can be specified", len(gatingAssets))
+	}
+	copy(gatingAssets[:], config.EntryGatingAssets)
+
+	var foreignApps []uint64
+	params.FlatFee = true
+	params.Fee = transaction.MinTxnFee
+	if config.EntryGatingType == GatingTypeCreatedByNFDAddresses || config.EntryGatingType == GatingTypeSegmentOfNFD {
+		// the gating nfd is verified via an inner call to the nfd registry
+		nfdRegistryID, err := r.GetNFDRegistryID()
+		if err != nil {
+			return err
+		}
+		foreignApps = append(foreignApps, gatingAssets[0], nfdRegistryID)
+		params.Fee = transaction.MinTxnFee * 2
+	}
+
+	atc := transaction.AtomicTransactionComposer{}
+
+	atc.AddMethodCall(r.validatorABI.ChangeValidatorRewardInfo(transaction.AddMethodCallParams{
+		AppID:       r.RetiAppId,
+		ForeignApps: foreignApps,
+		BoxReferences: []types.AppBoxReference{
+			{AppID: 0, Name: GetValidatorListBoxName(config.ID)},
+			{AppID: 0, Name: nil}, // extra i/o
+		},
+		SuggestedParams: params,
+		OnComplete:      types.NoOpOC,
+		Sender:          sender,
+		Signer:          algo.SignWithAccountForATC(r.signer, sender.String()),
+	}, config.ID, config.EntryGatingType, gatingAddress, gatingAssets, config.GatingAssetMinBalance, config.RewardPerPayout))
+	_, err = r.chain.ExecuteATC(context.Background(), &atc, 4)
+	return err
+}
+
+func (r *Reti) ChangeValidatorSunsetInfo(id uint64, sender types.Address, sunsettingOn uint64, sunsettingTo uint64) error {
+	var err error
+
+	params, err := r.chain.SuggestedParams(context.Background())
+	if err != nil {
+		return err
+	}
+
+	atc := transaction.AtomicTransactionComposer{}
+
+	atc.AddMethodCall(r.validatorABI.ChangeValidatorSunsetInfo(transaction.AddMethodCallParams{
+		AppID: r.RetiAppId,
+		BoxReferences: []types.AppBoxReference{
+			{AppID: 0, Name: GetValidatorListBoxName(id)},
+			{AppID: 0, Name: nil}, // extra i/o
+		},
+		SuggestedParams: params,
+		OnComplete:      types.NoOpOC,
+		Sender:          sender,
+		Signer:          algo.SignWithAccountForATC(r.signer, sender.String()),
+	}, id, sunsettingOn, sunsettingTo))
+	_, err = r.chain.ExecuteATC(context.Background(), &atc, 4)
+	return err
+}
+
+// LinkPoolToNFD verifies the staking pool's account in the specified NFD.  The pool's address must already have been
+// added to the NFD's (unverified) algo addresses.
+func (r *Reti) LinkPoolToNFD(poolAppID uint64, sender types.Address, nfdAppID uint64, nfdName string) error {
+	var err error
+
+	params, err := r.chain.SuggestedParams(context.Background())
+	if err != nil {
+		return err
+	}
+	nfdRegistryID, err := r.GetNFDRegistryID()
+	if err != nil {
+		return err
+	}
+
+	atc := transaction.AtomicTransactionComposer{}
+
+	// pay for the inner calls to the validator (owner/manager check) and to the nfd registry
+	params.FlatFee = true
+	params.Fee = transaction.MinTxnFee * 3
+
+	atc.AddMethodCall(r.poolABI.LinkToNFD(transaction.AddMethodCallParams{
+		AppID:       poolAppID,
+		ForeignApps: []uint64{r.RetiAppId, nfdRegistryID, nfdAppID},
+		BoxReferences: []types.AppBoxReference{
+			{AppID: r.RetiAppId, Name: GetValidatorListBoxName(r.ValidatorId)},
+			{AppID: 0, Name: nil}, // extra i/o
+		},
+		SuggestedParams: params,
+		OnComplete:      types.NoOpOC,
+		Sender:          sender,
+		Signer:          algo.SignWithAccountForATC(r.signer, sender.String()),
+	}, nfdAppID, nfdName))
+	_, err = r.chain.ExecuteATC(context.Background(), &atc, 4)
+	return err
+}
+
 func (r *Reti) AddStakingPool(nodeNum uint64) (*ValidatorPoolKey, error) {
 	var (
 		info = r.Info()
 		err  error
 	)
 
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -871,7 +1162,7 @@ func (r *Reti) AddStakingPool(nodeNum uint64) (*ValidatorPoolKey, error) {
 	managerAddr, _ := types.DecodeAddress(info.Config.Manager)
 
 	// first determine how much we have to add in MBR to the validator for adding a staking pool
-	mbrs, err := r.getMbrAmounts(managerAddr)
+	mbrs, err := r.GetMbrAmounts(managerAddr)
 	if err != nil {
 		return nil, err
 	}
@@ -880,7 +1171,6 @@ func (r *Reti) AddStakingPool(nodeNum uint64) (*ValidatorPoolKey, error) {
 	atc := transaction.AtomicTransactionComposer{}
 
 	misc.Infof(r.Logger, "adding staking pool to node:%d", nodeNum)
-	addPoolMethod, _ := r.validatorContract.GetMethodByName("addPool")
 	// We have to pay MBR into the Validator contract itself for adding a pool
 	paymentTxn, err := transaction.MakePaymentTxn(managerAddr.String(), crypto.GetApplicationAddress(r.RetiAppId).String(), mbrs.AddPoolMbr, nil, "", params)
 	payTxWithSigner := transaction.TransactionWithSigner{
@@ -891,16 +1181,8 @@ func (r *Reti) AddStakingPool(nodeNum uint64) (*ValidatorPoolKey, error) {
 	params.FlatFee = true
 	params.Fee = types.MicroAlgos(max(uint64(params.MinFee), 1000) + params.MinFee)
 
-	atc.AddMethodCall(transaction.AddMethodCallParams{
-		AppID:  r.RetiAppId,
-		Method: addPoolMethod,
-		MethodArgs: []any{
-			// MBR payment
-			payTxWithSigner,
-			// --
-			info.Config.ID,
-			nodeNum,
-		},
+	atc.AddMethodCall(r.validatorABI.AddPool(transaction.AddMethodCallParams{
+		AppID: r.RetiAppId,
 		BoxReferences: []types.AppBoxReference{
 			{AppID: 0, Name: GetValidatorListBoxName(info.Config.ID)},
 			{AppID: 0, Name: nil}, // extra i/o
@@ -914,16 +1196,23 @@ func (r *Reti) AddStakingPool(nodeNum uint64) (*ValidatorPoolKey, error) {
 		OnComplete:      types.NoOpOC,
 		Sender:          managerAddr,
 		Signer:          algo.SignWithAccountForATC(r.signer, managerAddr.String()),
-	})
-	result, err := atc.Execute(r.algoClient, context.Background(), 4)
+	},
+		// MBR payment
+		payTxWithSigner,
+		// --
+		info.Config.ID,
+		nodeNum,
+	))
+	result, err := r.chain.ExecuteATC(context.Background(), &atc, 4)
 	if err != nil {
 		return nil, err
 	}
 
-	poolKey, err := ValidatorPoolKeyFromABIReturn(result.MethodResults[0].ReturnValue)
+	abiPoolKey, err := r.validatorABI.AddPoolResult(result.MethodResults[0])
 	if err != nil {
 		return nil, err
 	}
+	poolKey := ValidatorPoolKeyFromABI(abiPoolKey)
 
 	err = r.CheckAndInitStakingPoolStorage(poolKey)
 	if err != nil {
@@ -939,7 +1228,7 @@ func (r *Reti) MovePoolToNode(poolAppId uint64, nodeNum uint64) error {
 		err  error
 	)
 
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return err
 	}
@@ -948,29 +1237,20 @@ func (r *Reti) MovePoolToNode(poolAppId uint64, nodeNum uint64) error {
 
 	atc := transaction.AtomicTransactionComposer{}
 	misc.Infof(r.Logger, "trying to move pool app id:%d to node number:%d", poolAppId, nodeNum)
-	gasMethod, _ := r.validatorContract.GetMethodByName("gas")
-	movePoolMethod, _ := r.validatorContract.GetMethodByName("movePoolToNode")
 
 	// pay for go offline call as well
 	params.FlatFee = true
 	params.Fee = types.MicroAlgos(max(uint64(params.MinFee), 1000) + (2 * params.MinFee))
 
-	atc.AddMethodCall(transaction.AddMethodCallParams{
+	atc.AddMethodCall(r.validatorABI.Gas(transaction.AddMethodCallParams{
 		AppID:           r.RetiAppId,
-		Method:          gasMethod,
 		SuggestedParams: params,
 		OnComplete:      types.NoOpOC,
 		Sender:          managerAddr,
 		Signer:          algo.SignWithAccountForATC(r.signer, managerAddr.String()),
-	})
-	atc.AddMethodCall(transaction.AddMethodCallParams{
-		AppID:  r.RetiAppId,
-		Method: movePoolMethod,
-		MethodArgs: []any{
-			info.Config.ID,
-			poolAppId,
-			nodeNum,
-		},
+	}))
+	atc.AddMethodCall(r.validatorABI.MovePoolToNode(transaction.AddMethodCallParams{
+		AppID: r.RetiAppId,
 		ForeignApps: []uint64{
 			poolAppId,
 		},
@@ -982,8 +1262,12 @@ func (r *Reti) MovePoolToNode(poolAppId uint64, nodeNum uint64) error {
 		OnComplete:      types.NoOpOC,
 		Sender:          managerAddr,
 		Signer:          algo.SignWithAccountForATC(r.signer, managerAddr.String()),
-	})
-	_, err = atc.Execute(r.algoClient, context.Background(), 4)
+	},
+		info.Config.ID,
+		poolAppId,
+		nodeNum,
+	))
+	_, err = r.chain.ExecuteATC(context.Background(), &atc, 4)
 	if err != nil {
 		return err
 	}
@@ -992,14 +1276,14 @@ func (r *Reti) MovePoolToNode(poolAppId uint64, nodeNum uint64) error {
 
 func (r *Reti) CheckAndInitStakingPoolStorage(poolKey *ValidatorPoolKey) error {
 	// First determine if we NEED to initialize this pool !
-	if val, err := r.algoClient.GetApplicationBoxByName(poolKey.PoolAppId, GetStakerLedgerBoxName()).Do(context.Background()); err == nil {
+	if val, err := r.chain.ApplicationBoxByName(context.Background(), poolKey.PoolAppId, GetStakerLedgerBoxName()); err == nil {
 		if len(val.Value) > 0 {
 			// we have value already - we're already initialized.
 			return nil
 		}
 	}
 
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return err
 	}
@@ -1009,7 +1293,7 @@ func (r *Reti) CheckAndInitStakingPoolStorage(poolKey *ValidatorPoolKey) error {
 		managerAddr, _ = types.DecodeAddress(r.Info().Config.Manager)
 	)
 
-	mbrs, err := r.getMbrAmounts(managerAddr)
+	mbrs, err := r.GetMbrAmounts(managerAddr)
 	if err != nil {
 		return err
 	}
@@ -1020,9 +1304,6 @@ func (r *Reti) CheckAndInitStakingPoolStorage(poolKey *ValidatorPoolKey) error {
 	}
 
 	// Now we have to pay MBR into the staking pool itself (!) and tell it to initialize itself
-	gasMethod, _ := r.poolContract.GetMethodByName("gas")
-	initStorageMethod, _ := r.poolContract.GetMethodByName("initStorage")
-
 	misc.Infof(r.Logger, "initializing staking pool storage, mbr payment to pool:%s", algo.FormattedAlgoAmount(poolInitMbr))
 	atc := transaction.AtomicTransactionComposer{}
 	paymentTxn, err := transaction.MakePaymentTxn(managerAddr.String(), crypto.GetApplicationAddress(poolKey.PoolAppId).String(), poolInitMbr, nil, "", params)
@@ -1031,9 +1312,8 @@ func (r *Reti) CheckAndInitStakingPoolStorage(poolKey *ValidatorPoolKey) error {
 		Signer: algo.SignWithAccountForATC(r.signer, managerAddr.String()),
 	}
 	// we need to stack up references in this gas method for resource pooling
-	atc.AddMethodCall(transaction.AddMethodCallParams{
-		AppID:  poolKey.PoolAppId,
-		Method: gasMethod,
+	atc.AddMethodCall(r.poolABI.Gas(transaction.AddMethodCallParams{
+		AppID: poolKey.PoolAppId,
 		BoxReferences: []types.AppBoxReference{
 			{AppID: r.RetiAppId, Name: GetValidatorListBoxName(poolKey.ID)},
 			{AppID: 0, Name: GetStakerLedgerBoxName()},
@@ -1048,16 +1328,11 @@ func (r *Reti) CheckAndInitStakingPoolStorage(poolKey *ValidatorPoolKey) error {
 		OnComplete:      types.NoOpOC,
 		Sender:          managerAddr,
 		Signer:          algo.SignWithAccountForATC(r.signer, managerAddr.String()),
-	})
+	}))
 	params.FlatFee = true
 	params.Fee = 3 * transaction.MinTxnFee
-	atc.AddMethodCall(transaction.AddMethodCallParams{
-		AppID:  poolKey.PoolAppId,
-		Method: initStorageMethod,
-		MethodArgs: []any{
-			// MBR payment
-			payTxWithSigner,
-		},
+	atc.AddMethodCall(r.poolABI.InitStorage(transaction.AddMethodCallParams{
+		AppID: poolKey.PoolAppId,
 		BoxReferences: []types.AppBoxReference{
 			{AppID: 0, Name: nil}, // extra i/o
 			{AppID: 0, Name: nil}, // extra i/o
@@ -1072,8 +1347,11 @@ func (r *Reti) CheckAndInitStakingPoolStorage(poolKey *ValidatorPoolKey) error {
 		OnComplete:      types.NoOpOC,
 		Sender:          managerAddr,
 		Signer:          algo.SignWithAccountForATC(r.signer, managerAddr.String()),
-	})
-	_, err = atc.Execute(r.algoClient, context.Background(), 4)
+	},
+		// MBR payment
+		payTxWithSigner,
+	))
+	_, err = r.chain.ExecuteATC(context.Background(), &atc, 4)
 	if err != nil {
 		return err
 	}
@@ -1086,14 +1364,14 @@ func (r *Reti) AddStake(validatorId uint64, staker types.Address, amount uint64,
 		amountToStake = uint64(amount)
 	)
 
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return nil, err
 	}
 	params.LastRoundValid = params.FirstRoundValid + 100
 
 	// first determine how much we might have to add in MBR if this is a first-time staker
-	mbrs, err := r.getMbrAmounts(staker)
+	mbrs, err := r.GetMbrAmounts(staker)
 	if err != nil {
 		return nil, err
 	}
@@ -1116,8 +1394,6 @@ func (r *Reti) AddStake(validatorId uint64, staker types.Address, amount uint64,
 
 	getAtc := func(feesToUse uint64) (transaction.AtomicTransactionComposer, error) {
 		atc := transaction.AtomicTransactionComposer{}
-		gasMethod, _ := r.validatorContract.GetMethodByName("gas")
-		stakeMethod, _ := r.validatorContract.GetMethodByName("addStake")
 
 		params.FlatFee = true
 		params.Fee = transaction.MinTxnFee
@@ -1129,9 +1405,8 @@ func (r *Reti) AddStake(validatorId uint64, staker types.Address, amount uint64,
 		}
 
 		// we need to stack up references in this gas method for resource pooling
-		err = atc.AddMethodCall(transaction.AddMethodCallParams{
-			AppID:  r.RetiAppId,
-			Method: gasMethod,
+		err = atc.AddMethodCall(r.validatorABI.Gas(transaction.AddMethodCallParams{
+			AppID: r.RetiAppId,
 			BoxReferences: []types.AppBoxReference{
 				{AppID: 0, Name: GetValidatorListBoxName(validatorId)},
 				{AppID: 0, Name: nil}, // extra i/o
@@ -1145,7 +1420,7 @@ func (r *Reti) AddStake(validatorId uint64, staker types.Address, amount uint64,
 			OnComplete:      types.NoOpOC,
 			Sender:          staker,
 			Signer:          algo.SignWithAccountForATC(r.signer, staker.String()),
-		})
+		}))
 		if err != nil {
 			return atc, err
 		}
@@ -1155,16 +1430,8 @@ func (r *Reti) AddStake(validatorId uint64, staker types.Address, amount uint64,
 		}
 		params.FlatFee = true
 		params.Fee = types.MicroAlgos(feesToUse)
-		err = atc.AddMethodCall(transaction.AddMethodCallParams{
-			AppID:  r.RetiAppId,
-			Method: stakeMethod,
-			MethodArgs: []any{
-				// MBR payment
-				payTxWithSigner,
-				// --
-				validatorId,
-				assetIDToCheck,
-			},
+		err = atc.AddMethodCall(r.validatorABI.AddStake(transaction.AddMethodCallParams{
+			AppID:       r.RetiAppId,
 			ForeignApps: []uint64{futurePoolKey.PoolAppId},
 			BoxReferences: []types.AppBoxReference{
 				{AppID: futurePoolKey.PoolAppId, Name: GetStakerLedgerBoxName()},
@@ -1179,7 +1446,13 @@ func (r *Reti) AddStake(validatorId uint64, staker types.Address, amount uint64,
 			OnComplete:      types.NoOpOC,
 			Sender:          staker,
 			Signer:          algo.SignWithAccountForATC(r.signer, staker.String()),
-		})
+		},
+			// MBR payment
+			payTxWithSigner,
+			// --
+			validatorId,
+			assetIDToCheck,
+		))
 		if err != nil {
 			return atc, err
 		}
@@ -1191,7 +1464,7 @@ func (r *Reti) AddStake(validatorId uint64, staker types.Address, amount uint64,
 	if err != nil {
 		return nil, err
 	}
-	simResult, err := atc.Simulate(context.Background(), r.algoClient, models.SimulateRequest{
+	simResult, err := r.chain.SimulateATC(context.Background(), &atc, models.SimulateRequest{
 		AllowEmptySignatures:  true,
 		AllowUnnamedResources: true,
 	})
@@ -1207,17 +1480,21 @@ func (r *Reti) AddStake(validatorId uint64, staker types.Address, amount uint64,
 		return nil, err
 	}
 
-	result, err := atc.Execute(r.algoClient, context.Background(), 4)
+	result, err := r.chain.ExecuteATC(context.Background(), &atc, 4)
+	if err != nil {
+		return nil, err
+	}
+	poolKey, err := r.validatorABI.AddStakeResult(result.MethodResults[1])
 	if err != nil {
 		return nil, err
 	}
-	return ValidatorPoolKeyFromABIReturn(result.MethodResults[1].ReturnValue)
+	return ValidatorPoolKeyFromABI(poolKey), nil
 }
 
 func (r *Reti) RemoveStake(poolKey ValidatorPoolKey, signer types.Address, staker types.Address, amount uint64) error {
 	var err error
 
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return err
 	}
@@ -1226,14 +1503,11 @@ func (r *Reti) RemoveStake(poolKey ValidatorPoolKey, signer types.Address, stake
 	extraApps := []uint64{}
 	extraAssets := []uint64{}
 
-	config, err := r.GetValidatorConfig(poolKey.ID)
+	validator, err := r.GetValidator(poolKey.ID)
 	if err != nil {
-		return fmt.Errorf("get validator config err:%w", err)
-	}
-	pools, err := r.GetValidatorPools(poolKey.ID)
-	if err != nil {
-		return fmt.Errorf("unable to GetValidatorPools: %w", err)
+		return fmt.Errorf("unable to GetValidator: %w", err)
 	}
+	config, pools := validator.Config, validator.Pools
 
 	if config.RewardTokenId != 0 {
 		extraAssets = append(extraAssets, config.RewardTokenId)
@@ -1244,16 +1518,13 @@ func (r *Reti) RemoveStake(poolKey ValidatorPoolKey, signer types.Address, stake
 	}
 	getAtc := func(feesToUse uint64) (transaction.AtomicTransactionComposer, error) {
 		atc := transaction.AtomicTransactionComposer{}
-		gasMethod, _ := r.validatorContract.GetMethodByName("gas")
-		unstakeMethod, _ := r.poolContract.GetMethodByName("removeStake")
 
 		params.FlatFee = true
 		params.Fee = transaction.MinTxnFee
 
 		// we need to stack up references in this gas method for resource pooling
-		err = atc.AddMethodCall(transaction.AddMethodCallParams{
+		err = atc.AddMethodCall(r.validatorABI.Gas(transaction.AddMethodCallParams{
 			AppID:           r.RetiAppId,
-			Method:          gasMethod,
 			ForeignAccounts: []string{staker.String()},
 			BoxReferences: []types.AppBoxReference{
 				{AppID: r.RetiAppId, Name: GetValidatorListBoxName(poolKey.ID)},
@@ -1268,14 +1539,13 @@ func (r *Reti) RemoveStake(poolKey ValidatorPoolKey, signer types.Address, stake
 			OnComplete:      types.NoOpOC,
 			Sender:          signer,
 			Signer:          algo.SignWithAccountForATC(r.signer, signer.String()),
-		})
+		}))
 		if err != nil {
 			return atc, err
 		}
 		if len(extraAssets) > 0 || len(extraApps) > 0 {
-			err = atc.AddMethodCall(transaction.AddMethodCallParams{
+			err = atc.AddMethodCall(r.poolABI.Gas(transaction.AddMethodCallParams{
 				AppID:           poolKey.PoolAppId,
-				Method:          gasMethod,
 				ForeignAccounts: []string{staker.String()}, // account MUST be referenced in same txn w/ foreign asset
 				ForeignAssets:   extraAssets,
 				ForeignApps:     extraApps,
@@ -1283,7 +1553,7 @@ func (r *Reti) RemoveStake(poolKey ValidatorPoolKey, signer types.Address, stake
 				OnComplete:      types.NoOpOC,
 				Sender:          signer,
 				Signer:          algo.SignWithAccountForATC(r.signer, signer.String()),
-			})
+			}))
 		}
 		if feesToUse == 0 {
 			// we're simulating so go with super high budget
@@ -1291,13 +1561,8 @@ func (r *Reti) RemoveStake(poolKey ValidatorPoolKey, signer types.Address, stake
 		}
 		params.FlatFee = true
 		params.Fee = types.MicroAlgos(feesToUse)
-		err = atc.AddMethodCall(transaction.AddMethodCallParams{
-			AppID:  poolKey.PoolAppId,
-			Method: unstakeMethod,
-			MethodArgs: []any{
-				staker,
-				amount,
-			},
+		err = atc.AddMethodCall(r.poolABI.RemoveStake(transaction.AddMethodCallParams{
+			AppID:       poolKey.PoolAppId,
 			ForeignApps: []uint64{poolKey.PoolAppId},
 			BoxReferences: []types.AppBoxReference{
 				{AppID: 0, Name: GetStakerLedgerBoxName()},
@@ -1312,7 +1577,10 @@ func (r *Reti) RemoveStake(poolKey ValidatorPoolKey, signer types.Address, stake
 			OnComplete:      types.NoOpOC,
 			Sender:          signer,
 			Signer:          algo.SignWithAccountForATC(r.signer, signer.String()),
-		})
+		},
+			staker,
+			amount,
+		))
 		if err != nil {
 			return atc, err
 		}
@@ -1324,7 +1592,7 @@ func (r *Reti) RemoveStake(poolKey ValidatorPoolKey, signer types.Address, stake
 	if err != nil {
 		return err
 	}
-	simResult, err := atc.Simulate(context.Background(), r.algoClient, models.SimulateRequest{
+	simResult, err := r.chain.SimulateATC(context.Background(), &atc, models.SimulateRequest{
 		AllowEmptySignatures:  true,
 		AllowUnnamedResources: true,
 	})
@@ -1340,51 +1608,163 @@ func (r *Reti) RemoveStake(poolKey ValidatorPoolKey, signer types.Address, stake
 		return err
 	}
 
-	_, err = atc.Execute(r.algoClient, context.Background(), 4)
+	_, err = r.chain.ExecuteATC(context.Background(), &atc, 4)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *Reti) EmptyTokenRewards(id uint64, signer types.Address, receiver types.Address) error {
+// ClaimTokens sends the staker their reward token balance from the pool - only the staker can claim
+func (r *Reti) ClaimTokens(poolKey ValidatorPoolKey, staker types.Address) error {
 	var err error
 
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return err
 	}
+	params.LastRoundValid = params.FirstRoundValid + 100
 
+	validator, err := r.GetValidator(poolKey.ID)
+	if err != nil {
+		return fmt.Errorf("unable to GetValidator: %w", err)
+	}
+	config, pools := validator.Config, validator.Pools
+	if config.RewardTokenId == 0 {
+		return fmt.Errorf("validator:%d has no reward token", poolKey.ID)
+	}
 	extraApps := []uint64{}
-	extraAssets := []uint64{}
+	if poolKey.PoolId != 1 {
+		// If not pool 1 then we need to add reference for pool 1, so it can be called to pay out the tokens
+		extraApps = append(extraApps, pools[0].PoolAppId)
+	}
+
+	getAtc := func(feesToUse uint64) (transaction.AtomicTransactionComposer, error) {
+		atc := transaction.AtomicTransactionComposer{}
 
-	config, err := r.GetValidatorConfig(id)
+		params.FlatFee = true
+		params.Fee = transaction.MinTxnFee
+
+		// we need to stack up references in these gas methods for resource pooling
+		err = atc.AddMethodCall(r.validatorABI.Gas(transaction.AddMethodCallParams{
+			AppID:           r.RetiAppId,
+			ForeignAccounts: []string{staker.String()},
+			BoxReferences: []types.AppBoxReference{
+				{AppID: r.RetiAppId, Name: GetValidatorListBoxName(poolKey.ID)},
+				{AppID: r.RetiAppId, Name: nil}, // extra i/o
+				{AppID: r.RetiAppId, Name: GetStakerPoolSetBoxName(staker)},
+				{AppID: 0, Name: nil}, // extra i/o
+				{AppID: 0, Name: nil}, // extra i/o
+				{AppID: 0, Name: nil}, // extra i/o
+				{AppID: 0, Name: nil}, // extra i/o
+			},
+			SuggestedParams: params,
+			OnComplete:      types.NoOpOC,
+			Sender:          staker,
+			Signer:          algo.SignWithAccountForATC(r.signer, staker.String()),
+		}))
+		if err != nil {
+			return atc, err
+		}
+		err = atc.AddMethodCall(r.poolABI.Gas(transaction.AddMethodCallParams{
+			AppID:           poolKey.PoolAppId,
+			ForeignAccounts: []string{staker.String()}, // account MUST be referenced in same txn w/ foreign asset
+			ForeignAssets:   []uint64{config.RewardTokenId},
+			ForeignApps:     extraApps,
+			SuggestedParams: params,
+			OnComplete:      types.NoOpOC,
+			Sender:          staker,
+			Signer:          algo.SignWithAccountForATC(r.signer, staker.String()),
+		}))
+		if err != nil {
+			return atc, err
+		}
+		if feesToUse == 0 {
+			// we're simulating so go with super high budget
+			feesToUse = 240 * transaction.MinTxnFee
+		}
+		params.FlatFee = true
+		params.Fee = types.MicroAlgos(feesToUse)
+		err = atc.AddMethodCall(r.poolABI.ClaimTokens(transaction.AddMethodCallParams{
+			AppID:       poolKey.PoolAppId,
+			ForeignApps: []uint64{poolKey.PoolAppId},
+			BoxReferences: []types.AppBoxReference{
+				{AppID: 0, Name: GetStakerLedgerBoxName()},
+				{AppID: 0, Name: nil}, // extra i/o
+				{AppID: 0, Name: nil}, // extra i/o
+				{AppID: 0, Name: nil}, // extra i/o
+				{AppID: 0, Name: nil}, // extra i/o
+				{AppID: 0, Name: nil}, // extra i/o
+				{AppID: 0, Name: nil}, // extra i/o
+			},
+			SuggestedParams: params,
+			OnComplete:      types.NoOpOC,
+			Sender:          staker,
+			Signer:          algo.SignWithAccountForATC(r.signer, staker.String()),
+		}))
+		if err != nil {
+			return atc, err
+		}
+		return atc, err
+	}
+
+	// simulate first
+	atc, err := getAtc(0)
 	if err != nil {
-		return fmt.Errorf("get validator config err:%w", err)
+		return err
 	}
-	pools, err := r.GetValidatorPools(id)
+	simResult, err := r.chain.SimulateATC(context.Background(), &atc, models.SimulateRequest{
+		AllowEmptySignatures:  true,
+		AllowUnnamedResources: true,
+	})
 	if err != nil {
-		return fmt.Errorf("unable to GetValidatorPools: %w", err)
+		return err
+	}
+	if simResult.SimulateResponse.TxnGroups[0].FailureMessage != "" {
+		return errors.New(simResult.SimulateResponse.TxnGroups[0].FailureMessage)
+	}
+	// Figure out how much app budget was added so we can know the real fees to use when we execute
+	atc, err = getAtc(2*transaction.MinTxnFee + transaction.MinTxnFee*(simResult.SimulateResponse.TxnGroups[0].AppBudgetAdded/700))
+	if err != nil {
+		return err
 	}
 
+	_, err = r.chain.ExecuteATC(context.Background(), &atc, 4)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *Reti) EmptyTokenRewards(id uint64, signer types.Address, receiver types.Address) error {
+	var err error
+
+	params, err := r.chain.SuggestedParams(context.Background())
+	if err != nil {
+		return err
+	}
+
+	extraApps := []uint64{}
+	extraAssets := []uint64{}
+
+	validator, err := r.GetValidator(id)
+	if err != nil {
+		return fmt.Errorf("unable to GetValidator: %w", err)
+	}
+	config, pools := validator.Config, validator.Pools
+
 	if config.RewardTokenId != 0 {
 		extraAssets = append(extraAssets, config.RewardTokenId)
 		// If not pool 1 then we need to add reference for pool 1, so it can be called to update the pool token payout ratio
 		extraApps = append(extraApps, pools[0].PoolAppId)
 	}
 	atc := transaction.AtomicTransactionComposer{}
-	emptyTokenRewards, _ := r.validatorContract.GetMethodByName("emptyTokenRewards")
 
 	params.FlatFee = true
 	params.Fee = transaction.MinTxnFee * 3
 
-	err = atc.AddMethodCall(transaction.AddMethodCallParams{
-		AppID:  r.RetiAppId,
-		Method: emptyTokenRewards,
-		MethodArgs: []any{
-			id,
-			receiver,
-		},
+	err = atc.AddMethodCall(r.validatorABI.EmptyTokenRewards(transaction.AddMethodCallParams{
+		AppID:         r.RetiAppId,
 		ForeignApps:   extraApps,
 		ForeignAssets: extraAssets,
 		BoxReferences: []types.AppBoxReference{
@@ -1400,12 +1780,15 @@ func (r *Reti) EmptyTokenRewards(id uint64, signer types.Address, receiver types
 		OnComplete:      types.NoOpOC,
 		Sender:          signer,
 		Signer:          algo.SignWithAccountForATC(r.signer, signer.String()),
-	})
+	},
+		id,
+		receiver,
+	))
 	if err != nil {
 		return fmt.Errorf("ATC error in composing emptyTokenRewards err:%w", err)
 	}
 
-	_, err = atc.Execute(r.algoClient, context.Background(), 4)
+	_, err = r.chain.ExecuteATC(context.Background(), &atc, 4)
 	if err != nil {
 		return err
 	}
@@ -1419,26 +1802,21 @@ type MbrAmounts struct {
 	AddStakerMbr    uint64
 }
 
-func (r *Reti) getMbrAmounts(caller types.Address) (MbrAmounts, error) {
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
-	if err != nil {
-		return MbrAmounts{}, err
-	}
-
-	method, err := r.validatorContract.GetMethodByName("getMbrAmounts")
+// GetMbrAmounts returns the minimum balance amounts callers have to pay for adding validators, pools and stakers
+func (r *Reti) GetMbrAmounts(caller types.Address) (MbrAmounts, error) {
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return MbrAmounts{}, err
 	}
 	atc := transaction.AtomicTransactionComposer{}
-	atc.AddMethodCall(transaction.AddMethodCallParams{
+	atc.AddMethodCall(r.validatorABI.GetMbrAmounts(transaction.AddMethodCallParams{
 		AppID:           r.RetiAppId,
-		Method:          method,
 		SuggestedParams: params,
 		OnComplete:      types.NoOpOC,
 		Sender:          caller,
 		Signer:          transaction.EmptyTransactionSigner{},
-	})
-	result, err := atc.Simulate(context.Background(), r.algoClient, models.SimulateRequest{
+	}))
+	result, err := r.chain.SimulateATC(context.Background(), &atc, models.SimulateRequest{
 		AllowEmptySignatures:  true,
 		AllowUnnamedResources: true,
 	})
@@ -1449,56 +1827,43 @@ func (r *Reti) getMbrAmounts(caller types.Address) (MbrAmounts, error) {
 		return MbrAmounts{}, errors.New(result.SimulateResponse.TxnGroups[0].FailureMessage)
 	}
 
-	if results, ok := result.MethodResults[0].ReturnValue.([]any); ok {
-		if len(results) != 4 {
-			return MbrAmounts{}, errors.New("invalid number of results")
-		}
-		var mbrs MbrAmounts
-		mbrs.AddValidatorMbr = results[0].(uint64)
-		mbrs.AddPoolMbr = results[1].(uint64)
-		mbrs.PoolInitMbr = results[2].(uint64)
-		mbrs.AddStakerMbr = results[3].(uint64)
-		return mbrs, nil
-	}
-	return MbrAmounts{}, fmt.Errorf("unknown result type:%#v", result.MethodResults)
-}
-
-func (r *Reti) doesStakerNeedToPayMBR(staker types.Address) (bool, error) {
-	params, err := r.algoClient.SuggestedParams().Do(context.Background())
+	mbrs, err := r.validatorABI.GetMbrAmountsResult(result.MethodResults[0])
 	if err != nil {
-		return false, err
+		return MbrAmounts{}, err
 	}
+	return MbrAmounts{
+		AddValidatorMbr: mbrs.AddValidatorMbr,
+		AddPoolMbr:      mbrs.AddPoolMbr,
+		PoolInitMbr:     mbrs.PoolInitMbr,
+		AddStakerMbr:    mbrs.AddStakerMbr,
+	}, nil
+}
 
-	method, err := r.validatorContract.GetMethodByName("doesStakerNeedToPayMBR")
+func (r *Reti) doesStakerNeedToPayMBR(staker types.Address) (bool, error) {
+	params, err := r.chain.SuggestedParams(context.Background())
 	if err != nil {
 		return false, err
 	}
 	atc := transaction.AtomicTransactionComposer{}
-	atc.AddMethodCall(transaction.AddMethodCallParams{
+	atc.AddMethodCall(r.validatorABI.DoesStakerNeedToPayMBR(transaction.AddMethodCallParams{
 		AppID:           r.RetiAppId,
-		Method:          method,
-		MethodArgs:      []any{staker},
 		SuggestedParams: params,
 		OnComplete:      types.NoOpOC,
 		Sender:          staker,
 		Signer:          transaction.EmptyTransactionSigner{},
-	})
-	result, err := atc.Simulate(context.Background(), r.algoClient, models.SimulateRequest{
+	}, staker))
+	result, err := r.chain.SimulateATC(context.Background(), &atc, models.SimulateRequest{
 		AllowEmptySignatures:  true,
 		AllowUnnamedResources: true,
 	})
 	if err != nil {
 		return false, err
 	}
-	val := result.MethodResults[0].ReturnValue
-	if boolReturn, ok := val.(bool); ok {
-		return boolReturn, nil
-	}
-	return false, errors.New("unknown return value from doesStakerNeedToPayMBR")
+	return r.validatorABI.DoesStakerNeedToPayMBRResult(result.MethodResults[0])
 }
 
 func (r *Reti) GetNumValidators() (uint64, error) {
-	appInfo, err := r.algoClient.GetApplicationByID(r.RetiAppId).Do(context.Background())
+	appInfo, err := r.chain.ApplicationByID(context.Background(), r.RetiAppId)
 	if err != nil {
 		return 0, err
 	}
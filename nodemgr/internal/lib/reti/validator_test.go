@@ -0,0 +1,104 @@
+package reti_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+
+	"github.com/algorandfoundation/reti/internal/lib/reti"
+	"github.com/algorandfoundation/reti/internal/lib/reti/fakereti"
+)
+
+func TestGetValidatorDecodesListBox(t *testing.T) {
+	gatingAddress := crypto.GenerateAccount().Address
+	tv := newTestValidator(t, reti.ABIValidatorConfig{
+		NfdForInfo:            4321,
+		EntryGatingType:       reti.GatingTypeAssetId,
+		EntryGatingAddress:    gatingAddress,
+		EntryGatingAssets:     [4]uint64{111, 222},
+		GatingAssetMinBalance: 5,
+		RewardTokenId:         333,
+		RewardPerPayout:       1000,
+		EpochRoundLength:      1440,
+		PercentToValidator:    50_000,
+		PoolsPerNode:          2,
+		SunsettingOn:          1_900_000_000,
+		SunsettingTo:          7,
+	})
+	pool1 := tv.addPool(t, 1, 0, staker(10_000_000), staker(5_000_000))
+	pool2 := tv.addPool(t, 2, 0, staker(20_000_000))
+	pool3 := tv.addPool(t, 1, 0)
+
+	validator, err := tv.client(t, 1).GetValidator(fakereti.ValidatorID)
+	if err != nil {
+		t.Fatalf("GetValidator: %v", err)
+	}
+
+	expectedConfig := reti.ValidatorConfig{
+		ID:                         fakereti.ValidatorID,
+		Owner:                      tv.owner.Address.String(),
+		Manager:                    tv.manager.Address.String(),
+		NFDForInfo:                 4321,
+		EntryGatingType:            reti.GatingTypeAssetId,
+		EntryGatingAddress:         gatingAddress.String(),
+		EntryGatingAssets:          []uint64{111, 222, 0, 0},
+		GatingAssetMinBalance:      5,
+		RewardTokenId:              333,
+		RewardPerPayout:            1000,
+		EpochRoundLength:           1440,
+		PercentToValidator:         50_000,
+		ValidatorCommissionAddress: tv.owner.Address.String(),
+		MinEntryStake:              1_000_000,
+		MaxAlgoPerPool:             70_000_000_000_000,
+		PoolsPerNode:               2,
+		SunsettingOn:               1_900_000_000,
+		SunsettingTo:               7,
+	}
+	if !reflect.DeepEqual(validator.Config, expectedConfig) {
+		t.Errorf("config decoded as:\n%+v\nexpected:\n%+v", validator.Config, expectedConfig)
+	}
+	expectedState := reti.ValidatorCurState{NumPools: 3, TotalStakers: 3, TotalAlgoStaked: 35_000_000}
+	if validator.State != expectedState {
+		t.Errorf("state decoded as %+v, expected %+v", validator.State, expectedState)
+	}
+	// pools stop at the first unused slot
+	expectedPools := []reti.PoolInfo{
+		{PoolAppId: pool1, TotalStakers: 2, TotalAlgoStaked: 15_000_000},
+		{PoolAppId: pool2, TotalStakers: 1, TotalAlgoStaked: 20_000_000},
+		{PoolAppId: pool3},
+	}
+	if !reflect.DeepEqual(validator.Pools, expectedPools) {
+		t.Errorf("pools decoded as %+v, expected %+v", validator.Pools, expectedPools)
+	}
+	// every node is present, without the unused pool slots
+	nodes := validator.NodePoolAssignments.Nodes
+	if len(nodes) != 8 {
+		t.Fatalf("expected 8 nodes, got %d", len(nodes))
+	}
+	if !reflect.DeepEqual(nodes[0].PoolAppIds, []uint64{pool1, pool3}) || !reflect.DeepEqual(nodes[1].PoolAppIds, []uint64{pool2}) || len(nodes[2].PoolAppIds) != 0 {
+		t.Errorf("unexpected node pool assignments: %+v", nodes)
+	}
+
+	// the individual getters decode the same box
+	pools, err := tv.client(t, 1).GetValidatorPools(fakereti.ValidatorID)
+	if err != nil {
+		t.Fatalf("GetValidatorPools: %v", err)
+	}
+	if !reflect.DeepEqual(pools, expectedPools) {
+		t.Errorf("GetValidatorPools returned %+v, expected %+v", pools, expectedPools)
+	}
+}
+
+func TestGetValidatorInvalidBox(t *testing.T) {
+	tv := newTestValidator(t, reti.ABIValidatorConfig{})
+	tv.addPool(t, 1, 0)
+	client := tv.client(t, 1)
+
+	// a box that can't be decoded falls back to simulating the registry's getters - which the fake doesn't implement,
+	// so it has to fail rather than return a partially decoded validator
+	tv.chain.SetBox(fakereti.RegistryAppID, reti.GetValidatorListBoxName(fakereti.ValidatorID), []byte{1, 2, 3})
+	if validator, err := client.GetValidator(fakereti.ValidatorID); err == nil {
+		t.Fatalf("GetValidator of an invalid box returned %+v", validator)
+	}
+}
@@ -0,0 +1,229 @@
+package reti
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+
+	"github.com/algorandfoundation/reti/internal/lib/misc"
+)
+
+// EmbeddedContractVersion is the contract release (contracts/package.json) the embedded artifacts were built from.
+// Update whenever the artifacts are updated via update_contract_artifacts.sh
+const EmbeddedContractVersion = "1.5.1"
+
+// UnknownContractVersion is reported for deployed contracts matching neither the embedded artifacts nor any
+// known release
+const UnknownContractVersion = "unknown"
+
+const (
+	RegistryContractName = "ValidatorRegistry"
+	PoolContractName     = "StakingPool"
+)
+
+var contractArtifacts = map[string]string{
+	RegistryContractName: "artifacts/contracts/ValidatorRegistry.arc32.json",
+	PoolContractName:     "artifacts/contracts/StakingPool.arc32.json",
+}
+
+// Status of a deployed contract compared against the embedded artifacts (ContractVersion.Status)
+const (
+	ContractMatches  = "match"
+	ContractMismatch = "mismatch"
+	// ContractUnverified is a contract which couldn't be checked - the embedded artifacts couldn't be compiled (the
+	// node doesn't have the developer api enabled) and its hash isn't one of the known releases
+	ContractUnverified = "unverified"
+)
+
+// knownContractHashes (contracthashes_gen.go, generated by tools/contracthashes from update_contract_artifacts.sh)
+// maps the approval program hashes (see ProgramHash) of released contracts to their version.  It's only consulted
+// when the embedded artifacts can't be compiled or don't match.  Because the NFD registry id is substituted in at
+// deploy time, there are hashes for each network.
+
+// ContractVersion is the result of comparing a deployed application against the embedded contract artifacts
+type ContractVersion struct {
+	Contract string
+	AppID    uint64
+	// Hash is the program hash of the on-chain approval program - the same value 'goal app info' displays
+	Hash    string
+	Version string
+	// Status is ContractMatches, ContractMismatch or ContractUnverified - Matches is set if the deployed approval
+	// program is the one the embedded artifacts produce
+	Status  string
+	Matches bool
+}
+
+func (cv ContractVersion) String() string {
+	switch cv.Status {
+	case ContractMatches:
+		return fmt.Sprintf("%s app id:%d, version:%s", cv.Contract, cv.AppID, cv.Version)
+	case ContractUnverified:
+		return fmt.Sprintf("%s app id:%d, version:%s - UNVERIFIED (embedded contracts can't be compiled without the developer api, and the hash isn't a known release), approval hash:%s", cv.Contract, cv.AppID, cv.Version, cv.Hash)
+	}
+	return fmt.Sprintf("%s app id:%d, version:%s - MISMATCH with embedded version %s, approval hash:%s", cv.Contract, cv.AppID, cv.Version, EmbeddedContractVersion, cv.Hash)
+}
+
+// ContractVersions is the result of the most recent contract check - the registry and each of our validator's pools
+type ContractVersions struct {
+	Registry ContractVersion
+	Pools    []ContractVersion
+}
+
+// Mismatches returns every checked contract known not to match the embedded artifacts - not those unverified
+func (cvs ContractVersions) Mismatches() []ContractVersion {
+	var mismatches []ContractVersion
+	if cvs.Registry.AppID != 0 && cvs.Registry.Status == ContractMismatch {
+		mismatches = append(mismatches, cvs.Registry)
+	}
+	for _, pool := range cvs.Pools {
+		if pool.Status == ContractMismatch {
+			mismatches = append(mismatches, pool)
+		}
+	}
+	return mismatches
+}
+
+// ProgramHash returns the hash of a compiled TEAL program in the same form algod and goal report it
+func ProgramHash(program []byte) string {
+	return crypto.AddressFromProgram(program).String()
+}
+
+// ContractVersions returns the result of the last contract version check done by LoadState
+func (r *Reti) ContractVersions() ContractVersions {
+	r.RLock()
+	defer r.RUnlock()
+	return r.contractVersions
+}
+
+// checkContractVersions compares the approval programs of the registry and the specified pools against the embedded
+// artifacts.  Results are cached per application, so only pools not seen before are fetched.  Mismatches are
+// logged when first seen and fail the check only if StrictContractCheck is set - contracts which couldn't be
+// verified (see ContractUnverified) never do.
+func (r *Reti) checkContractVersions(ctx context.Context, pools []PoolInfo) error {
+	r.versionLock.Lock()
+	defer r.versionLock.Unlock()
+
+	if r.expectedHashes == nil {
+		hashes, err := r.embeddedContractHashes(ctx)
+		if err != nil {
+			misc.Warnf(r.Logger, "unable to determine hashes of embedded contracts, falling back to known contract list: %v", err)
+			hashes = map[string]string{}
+		}
+		r.expectedHashes = hashes
+	}
+	if r.checkedContracts == nil {
+		r.checkedContracts = map[uint64]ContractVersion{}
+	}
+
+	var versions ContractVersions
+	var err error
+	versions.Registry, err = r.checkContract(ctx, RegistryContractName, r.RetiAppId)
+	if err != nil {
+		return err
+	}
+	for _, pool := range pools {
+		poolVersion, err := r.checkContract(ctx, PoolContractName, pool.PoolAppId)
+		if err != nil {
+			return err
+		}
+		versions.Pools = append(versions.Pools, poolVersion)
+	}
+	r.Lock()
+	r.contractVersions = versions
+	r.Unlock()
+
+	promContractInfo.Reset()
+	for _, cv := range append([]ContractVersion{versions.Registry}, versions.Pools...) {
+		promContractInfo.WithLabelValues(cv.Contract, strconv.FormatUint(cv.AppID, 10), cv.Version, strconv.FormatBool(cv.Matches), cv.Status).Set(1)
+	}
+
+	if mismatches := versions.Mismatches(); r.StrictContractCheck && len(mismatches) > 0 {
+		var descs []string
+		for _, mismatch := range mismatches {
+			descs = append(descs, mismatch.String())
+		}
+		return fmt.Errorf("%w: %s", ErrContractMismatch, strings.Join(descs, ", "))
+	}
+	return nil
+}
+
+func (r *Reti) checkContract(ctx context.Context, contract string, appID uint64) (ContractVersion, error) {
+	if cv, found := r.checkedContracts[appID]; found {
+		return cv, nil
+	}
+	appInfo, err := r.chain.ApplicationByID(ctx, appID)
+	if err != nil {
+		return ContractVersion{}, fmt.Errorf("unable to fetch %s app id:%d for version check: %w", contract, appID, err)
+	}
+	cv := ContractVersion{
+		Contract: contract,
+		AppID:    appID,
+		Hash:     ProgramHash(appInfo.Params.ApprovalProgram),
+		Version:  UnknownContractVersion,
+	}
+	expected, compiled := r.expectedHashes[contract]
+	version, known := knownContractHashes[cv.Hash]
+	switch {
+	case compiled && expected == cv.Hash:
+		cv.Version, cv.Status, cv.Matches = EmbeddedContractVersion, ContractMatches, true
+	case known && version == EmbeddedContractVersion:
+		cv.Version, cv.Status, cv.Matches = version, ContractMatches, true
+	case known || compiled:
+		if known {
+			cv.Version = version
+		}
+		cv.Status = ContractMismatch
+		misc.Warnf(r.Logger, "deployed contract doesn't match this build: %s", cv.String())
+	default:
+		cv.Status = ContractUnverified
+		misc.Warnf(r.Logger, "unable to verify deployed contract: %s", cv.String())
+	}
+	r.checkedContracts[appID] = cv
+	return cv, nil
+}
+
+// embeddedContractHashes compiles the approval programs of the embedded artifacts, substituting the same template
+// values used when the registry was deployed, returning the program hash for each contract.
+func (r *Reti) embeddedContractHashes(ctx context.Context) (map[string]string, error) {
+	nfdRegistryID, err := r.GetNFDRegistryID()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch nfd registry id: %w", err)
+	}
+	hashes := map[string]string{}
+	for contract, fname := range contractArtifacts {
+		source, err := loadApprovalSource(fname)
+		if err != nil {
+			return nil, err
+		}
+		source = bytes.ReplaceAll(source, []byte("TMPL_nfdRegistryAppId"), []byte(strconv.FormatUint(nfdRegistryID, 10)))
+		program, err := r.chain.TealCompile(ctx, source)
+		if err != nil {
+			return nil, fmt.Errorf("unable to compile embedded %s approval program: %w", contract, err)
+		}
+		hashes[contract] = ProgramHash(program)
+	}
+	return hashes, nil
+}
+
+func loadApprovalSource(fname string) ([]byte, error) {
+	data, err := embeddedF.ReadFile(fname)
+	if err != nil {
+		return nil, err
+	}
+	var arc32 struct {
+		Source struct {
+			Approval string `json:"approval"`
+		} `json:"source"`
+	}
+	err = json.Unmarshal(data, &arc32)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(arc32.Source.Approval)
+}
@@ -0,0 +1,842 @@
+// arc32gen generates typed Go bindings for the methods of one or more ARC-32 application specs.
+//
+// For every contract a <Name>ABI type is emitted with a wrapper per ABI method which fills in the method and
+// (typed) arguments of a transaction.AddMethodCallParams, plus a <Method>Result decoder for non-void methods.
+// Tuples are emitted as ABI<Struct> types with decoders that return errors instead of panicking on unexpected
+// values.  ARC-32 specs don't name tuple fields, so if an ARC-56 spec exists alongside the ARC-32 file its
+// struct definitions are used for the struct and field names, and a Decode<Map>Box decoder is emitted for each of
+// its box maps.
+//
+// Usage (normally via go generate):
+//
+//	arc32gen -pkg reti -out contracts_gen.go ValidatorRegistry.arc32.json StakingPool.arc32.json
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+func main() {
+	var (
+		pkg = flag.String("pkg", "", "package name of the generated file")
+		out = flag.String("out", "", "file to write the generated code to")
+	)
+	flag.Parse()
+	if *pkg == "" || *out == "" || flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	gen := newGenerator(*pkg)
+	for _, specFile := range flag.Args() {
+		if err := gen.addSpec(specFile); err != nil {
+			log.Fatalf("%s: %v", specFile, err)
+		}
+	}
+	src, err := gen.generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// -- spec parsing
+
+type arc32Spec struct {
+	Contract struct {
+		Name    string      `json:"name"`
+		Methods []arcMethod `json:"methods"`
+	} `json:"contract"`
+}
+
+type arcMethod struct {
+	Name    string   `json:"name"`
+	Desc    string   `json:"desc"`
+	Args    []arcArg `json:"args"`
+	Returns arcArg   `json:"returns"`
+}
+
+type arcArg struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Struct string `json:"struct"`
+}
+
+type arc56Spec struct {
+	Structs map[string][]arc56Field `json:"structs"`
+	Methods []arcMethod             `json:"methods"`
+	State   struct {
+		Maps struct {
+			Box map[string]arc56Map `json:"box"`
+		} `json:"maps"`
+	} `json:"state"`
+}
+
+// arc56Map value type is either an ABI type string or the name of a struct
+type arc56Map struct {
+	KeyType   string `json:"keyType"`
+	ValueType string `json:"valueType"`
+	Prefix    string `json:"prefix"`
+}
+
+// arc56Field type is either an ABI type string or a nested (anonymous) struct definition
+type arc56Field struct {
+	Name string          `json:"name"`
+	Type json.RawMessage `json:"type"`
+}
+
+// -- ABI type model
+
+type abiKind int
+
+const (
+	kindUint abiKind = iota
+	kindBool
+	kindAddress
+	kindString
+	kindStaticArray
+	kindDynamicArray
+	kindTuple
+	kindTxn
+	kindReference
+)
+
+type abiType struct {
+	kind     abiKind
+	bits     int
+	length   int
+	elem     *abiType
+	children []*abiType
+	raw      string
+}
+
+func parseType(s string) (*abiType, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, "]") {
+		open := strings.LastIndex(s, "[")
+		if open < 0 {
+			return nil, fmt.Errorf("invalid array type %q", s)
+		}
+		elem, err := parseType(s[:open])
+		if err != nil {
+			return nil, err
+		}
+		lenStr := s[open+1 : len(s)-1]
+		if lenStr == "" {
+			return &abiType{kind: kindDynamicArray, elem: elem, raw: s}, nil
+		}
+		length, err := strconv.Atoi(lenStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid static array length in %q", s)
+		}
+		return &abiType{kind: kindStaticArray, elem: elem, length: length, raw: s}, nil
+	}
+	if strings.HasPrefix(s, "(") {
+		if !strings.HasSuffix(s, ")") {
+			return nil, fmt.Errorf("invalid tuple type %q", s)
+		}
+		t := &abiType{kind: kindTuple, raw: s}
+		for _, child := range splitTuple(s[1 : len(s)-1]) {
+			childType, err := parseType(child)
+			if err != nil {
+				return nil, err
+			}
+			t.children = append(t.children, childType)
+		}
+		return t, nil
+	}
+	switch s {
+	case "bool":
+		return &abiType{kind: kindBool, raw: s}, nil
+	case "byte":
+		return &abiType{kind: kindUint, bits: 8, raw: s}, nil
+	case "address":
+		return &abiType{kind: kindAddress, raw: s}, nil
+	case "string":
+		return &abiType{kind: kindString, raw: s}, nil
+	case "txn", "pay", "keyreg", "acfg", "axfer", "afrz", "appl":
+		return &abiType{kind: kindTxn, raw: s}, nil
+	case "account", "asset", "application":
+		return &abiType{kind: kindReference, raw: s}, nil
+	}
+	if strings.HasPrefix(s, "uint") {
+		bits, err := strconv.Atoi(strings.TrimPrefix(s, "uint"))
+		if err != nil || bits%8 != 0 || bits < 8 || bits > 512 {
+			return nil, fmt.Errorf("invalid uint type %q", s)
+		}
+		return &abiType{kind: kindUint, bits: bits, raw: s}, nil
+	}
+	return nil, fmt.Errorf("unsupported ABI type %q", s)
+}
+
+func splitTuple(s string) []string {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+	if s == "" {
+		return nil
+	}
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// -- generation
+
+type structDef struct {
+	name   string
+	sig    string
+	fields []structField
+}
+
+type structField struct {
+	name string
+	typ  *abiType
+}
+
+type contractDef struct {
+	name    string
+	methods []methodDef
+	boxMaps []boxMapDef
+}
+
+type boxMapDef struct {
+	name      string
+	valueType *abiType
+}
+
+type methodDef struct {
+	name      string
+	desc      string
+	signature string
+	args      []arcArg
+	argTypes  []*abiType
+	returns   *abiType
+}
+
+type generator struct {
+	pkg       string
+	sources   []string
+	contracts []contractDef
+	// named struct definitions (from ARC-56 specs) by tuple signature
+	namedBySig map[string]string
+	namedDefs  map[string][]arc56Field
+	structs    map[string]*structDef
+	// tuple signature -> struct name assigned to tuples without an explicit name
+	tupleNames map[string]string
+}
+
+func newGenerator(pkg string) *generator {
+	return &generator{
+		pkg:        pkg,
+		namedBySig: map[string]string{},
+		namedDefs:  map[string][]arc56Field{},
+		structs:    map[string]*structDef{},
+		tupleNames: map[string]string{},
+	}
+}
+
+func (g *generator) addSpec(specFile string) error {
+	data, err := os.ReadFile(specFile)
+	if err != nil {
+		return err
+	}
+	var spec arc32Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return err
+	}
+	if spec.Contract.Name == "" {
+		return fmt.Errorf("no contract name in spec")
+	}
+	g.sources = append(g.sources, filepath.Base(specFile))
+
+	// Struct names and box maps from the ARC-56 companion spec (if present)
+	var spec56 arc56Spec
+	methodStructs := map[string]map[string]string{}
+	arc56File := strings.TrimSuffix(specFile, ".arc32.json") + ".arc56.json"
+	if data, err := os.ReadFile(arc56File); err == nil {
+		if err := json.Unmarshal(data, &spec56); err != nil {
+			return fmt.Errorf("%s: %w", arc56File, err)
+		}
+		if err := g.addNamedStructs(spec56.Structs); err != nil {
+			return fmt.Errorf("%s: %w", arc56File, err)
+		}
+		for _, method := range spec56.Methods {
+			names := map[string]string{"": method.Returns.Struct}
+			for _, arg := range method.Args {
+				names[arg.Name] = arg.Struct
+			}
+			methodStructs[method.Name] = names
+		}
+	}
+
+	contract := contractDef{name: spec.Contract.Name}
+	for _, method := range spec.Contract.Methods {
+		def := methodDef{name: method.Name, desc: method.Desc, args: method.Args}
+		var argTypes []string
+		for _, arg := range method.Args {
+			argType, err := parseType(arg.Type)
+			if err != nil {
+				return fmt.Errorf("method %s, arg %s: %w", method.Name, arg.Name, err)
+			}
+			if argType.kind == kindTuple {
+				name := methodStructs[method.Name][arg.Name]
+				if name == "" {
+					name = exportedName(method.Name) + exportedName(arg.Name)
+				}
+				if err := g.registerTuple(argType, name); err != nil {
+					return err
+				}
+			} else if err := g.registerNested(argType, exportedName(method.Name)+exportedName(arg.Name)); err != nil {
+				return err
+			}
+			def.argTypes = append(def.argTypes, argType)
+			argTypes = append(argTypes, arg.Type)
+		}
+		if method.Returns.Type != "void" {
+			retType, err := parseType(method.Returns.Type)
+			if err != nil {
+				return fmt.Errorf("method %s, returns: %w", method.Name, err)
+			}
+			if retType.kind == kindTuple {
+				name := methodStructs[method.Name][""]
+				if name == "" {
+					name = exportedName(method.Name) + "Return"
+				}
+				if err := g.registerTuple(retType, name); err != nil {
+					return err
+				}
+			} else if err := g.registerNested(retType, exportedName(method.Name)+"Return"); err != nil {
+				return err
+			}
+			def.returns = retType
+		}
+		def.signature = fmt.Sprintf("%s(%s)%s", method.Name, strings.Join(argTypes, ","), method.Returns.Type)
+		contract.methods = append(contract.methods, def)
+	}
+
+	var mapNames []string
+	for name := range spec56.State.Maps.Box {
+		mapNames = append(mapNames, name)
+	}
+	sort.Strings(mapNames)
+	for _, name := range mapNames {
+		valueType, err := g.mapValueType(name, spec56.State.Maps.Box[name].ValueType)
+		if err != nil {
+			return fmt.Errorf("%s: box map %s: %w", arc56File, name, err)
+		}
+		contract.boxMaps = append(contract.boxMaps, boxMapDef{name: name, valueType: valueType})
+	}
+	g.contracts = append(g.contracts, contract)
+	return nil
+}
+
+// mapValueType resolves the value type of a box map - either a named struct or an ABI type
+func (g *generator) mapValueType(mapName string, valueType string) (*abiType, error) {
+	if fields, found := g.namedDefs[valueType]; found {
+		sig, err := arc56Signature(fields)
+		if err != nil {
+			return nil, err
+		}
+		t, err := parseType(sig)
+		if err != nil {
+			return nil, err
+		}
+		return t, g.registerTuple(t, valueType)
+	}
+	t, err := parseType(valueType)
+	if err != nil {
+		return nil, err
+	}
+	return t, g.registerNested(t, exportedName(mapName)+"Value")
+}
+
+// addNamedStructs records the tuple signature of every named ARC-56 struct so tuples can be matched to them
+func (g *generator) addNamedStructs(structs map[string][]arc56Field) error {
+	var names []string
+	for name := range structs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		sig, err := arc56Signature(structs[name])
+		if err != nil {
+			return fmt.Errorf("struct %s: %w", name, err)
+		}
+		if existing, found := g.namedDefs[name]; found {
+			existingSig, _ := arc56Signature(existing)
+			if existingSig != sig {
+				return fmt.Errorf("struct %s defined differently across specs: %s vs %s", name, existingSig, sig)
+			}
+			continue
+		}
+		g.namedDefs[name] = structs[name]
+		if _, found := g.namedBySig[sig]; !found {
+			g.namedBySig[sig] = name
+		}
+	}
+	return nil
+}
+
+func arc56Signature(fields []arc56Field) (string, error) {
+	var types []string
+	for _, field := range fields {
+		var typeStr string
+		if err := json.Unmarshal(field.Type, &typeStr); err == nil {
+			types = append(types, typeStr)
+			continue
+		}
+		var nested []arc56Field
+		if err := json.Unmarshal(field.Type, &nested); err != nil {
+			return "", fmt.Errorf("field %s: unknown type %s", field.Name, string(field.Type))
+		}
+		nestedSig, err := arc56Signature(nested)
+		if err != nil {
+			return "", err
+		}
+		types = append(types, nestedSig)
+	}
+	return "(" + strings.Join(types, ",") + ")", nil
+}
+
+// registerTuple assigns a struct name to tuple t (and any tuples nested within it).  Explicit names win, then
+// a named ARC-56 struct with the same signature, then the fallback name.
+func (g *generator) registerTuple(t *abiType, fallback string) error {
+	name := fallback
+	if existing, found := g.tupleNames[t.raw]; found {
+		name = existing
+	} else if named, found := g.namedBySig[t.raw]; found {
+		name = named
+	}
+	g.tupleNames[t.raw] = name
+	if existing, found := g.structs[name]; found {
+		if existing.sig != t.raw {
+			return fmt.Errorf("struct %s used for both %s and %s", name, existing.sig, t.raw)
+		}
+		return nil
+	}
+	def := &structDef{name: name, sig: t.raw}
+	g.structs[name] = def
+
+	var fieldNames []string
+	if fields, found := g.namedDefs[name]; found && len(fields) == len(t.children) {
+		for _, field := range fields {
+			fieldNames = append(fieldNames, exportedName(field.Name))
+		}
+	} else {
+		for i := range t.children {
+			fieldNames = append(fieldNames, fmt.Sprintf("Field%d", i))
+		}
+	}
+	for i, child := range t.children {
+		def.fields = append(def.fields, structField{name: fieldNames[i], typ: child})
+		if err := g.registerNested(child, name+fieldNames[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// registerNested registers any tuples contained within (possibly nested arrays of) t
+func (g *generator) registerNested(t *abiType, fallback string) error {
+	switch t.kind {
+	case kindTuple:
+		return g.registerTuple(t, fallback)
+	case kindStaticArray, kindDynamicArray:
+		return g.registerNested(t.elem, fallback)
+	}
+	return nil
+}
+
+func (g *generator) goType(t *abiType) string {
+	switch t.kind {
+	case kindUint:
+		switch {
+		case t.raw == "byte":
+			return "byte"
+		case t.bits <= 8:
+			return "uint8"
+		case t.bits <= 16:
+			return "uint16"
+		case t.bits <= 32:
+			return "uint32"
+		case t.bits <= 64:
+			return "uint64"
+		}
+		return "*big.Int"
+	case kindBool:
+		return "bool"
+	case kindAddress:
+		return "types.Address"
+	case kindString:
+		return "string"
+	case kindStaticArray:
+		return fmt.Sprintf("[%d]%s", t.length, g.goType(t.elem))
+	case kindDynamicArray:
+		return "[]" + g.goType(t.elem)
+	case kindTuple:
+		return "ABI" + g.tupleNames[t.raw]
+	case kindTxn:
+		return "transaction.TransactionWithSigner"
+	}
+	return "any"
+}
+
+// decoder returns an expression of type func(any) (T, error) converting an ABI decoded value to the Go type for t
+func (g *generator) decoder(t *abiType) string {
+	switch t.kind {
+	case kindUint:
+		switch {
+		case t.bits <= 8:
+			return "abiUint8"
+		case t.bits <= 16:
+			return "abiUint16"
+		case t.bits <= 32:
+			return "abiUint32"
+		case t.bits <= 64:
+			return "abiUint64"
+		}
+		return "abiBigInt"
+	case kindBool:
+		return "abiBool"
+	case kindAddress:
+		return "abiAddress"
+	case kindString:
+		return "abiString"
+	case kindDynamicArray:
+		return fmt.Sprintf("abiSliceOf(%s)", g.decoder(t.elem))
+	case kindStaticArray:
+		goType := g.goType(t)
+		return fmt.Sprintf(`func(v any) (%[1]s, error) {
+			var arr %[1]s
+			values, err := abiSliceOf(%[2]s)(v)
+			if err != nil {
+				return arr, err
+			}
+			if len(values) != len(arr) {
+				return arr, fmt.Errorf("expected %[3]d elements, got %%d", len(values))
+			}
+			copy(arr[:], values)
+			return arr, nil
+		}`, goType, g.decoder(t.elem), t.length)
+	case kindTuple:
+		return "decode" + g.goType(t)
+	}
+	return "abiAny"
+}
+
+// encoder returns an expression converting the Go value expr into a value the ABI encoder accepts
+func (g *generator) encoder(t *abiType, expr string) string {
+	switch t.kind {
+	case kindTuple:
+		return expr + ".abiValues()"
+	case kindStaticArray, kindDynamicArray:
+		if !g.containsTuple(t.elem) {
+			return expr
+		}
+		slice := expr
+		if t.kind == kindStaticArray {
+			slice = expr + "[:]"
+		}
+		return fmt.Sprintf("abiValuesOf(%s, func(v %s) any { return %s })", slice, g.goType(t.elem), g.encoder(t.elem, "v"))
+	}
+	return expr
+}
+
+func (g *generator) containsTuple(t *abiType) bool {
+	switch t.kind {
+	case kindTuple:
+		return true
+	case kindStaticArray, kindDynamicArray:
+		return g.containsTuple(t.elem)
+	}
+	return false
+}
+
+func (g *generator) generate() ([]byte, error) {
+	var b bytes.Buffer
+	p := func(format string, args ...any) { fmt.Fprintf(&b, format, args...) }
+
+	p("// Code generated by arc32gen from %s. DO NOT EDIT.\n\n", strings.Join(g.sources, ", "))
+	p("package %s\n\n", g.pkg)
+	p("import (\n\"errors\"\n\"fmt\"\n\"math/big\"\n\n")
+	p("\"github.com/algorand/go-algorand-sdk/v2/abi\"\n")
+	p("\"github.com/algorand/go-algorand-sdk/v2/transaction\"\n")
+	p("\"github.com/algorand/go-algorand-sdk/v2/types\"\n)\n\n")
+
+	// structs
+	var structNames []string
+	for name := range g.structs {
+		structNames = append(structNames, name)
+	}
+	sort.Strings(structNames)
+	for _, name := range structNames {
+		def := g.structs[name]
+		goName := "ABI" + name
+		p("// %s is the ABI tuple %s\n", goName, def.sig)
+		p("type %s struct {\n", goName)
+		for _, field := range def.fields {
+			p("%s %s\n", field.name, g.goType(field.typ))
+		}
+		p("}\n\n")
+
+		p("func decode%s(v any) (%s, error) {\n", goName, goName)
+		p("var s %s\n", goName)
+		p("values, ok := v.([]any)\n")
+		p("if !ok || len(values) != %d {\n", len(def.fields))
+		p("return s, fmt.Errorf(\"%s: expected %d element tuple, got %%T\", v)\n}\n", name, len(def.fields))
+		p("var err error\n")
+		for i, field := range def.fields {
+			p("if s.%s, err = %s(values[%d]); err != nil {\n", field.name, g.decoder(field.typ), i)
+			p("return s, fmt.Errorf(\"%s.%s: %%w\", err)\n}\n", name, field.name)
+		}
+		p("return s, nil\n}\n\n")
+
+		p("func (s %s) abiValues() []any {\nreturn []any{\n", goName)
+		for _, field := range def.fields {
+			p("%s,\n", g.encoder(field.typ, "s."+field.name))
+		}
+		p("}\n}\n\n")
+	}
+
+	// contracts
+	for _, contract := range g.contracts {
+		typeName := contract.name + "ABI"
+		p("// %s provides typed calls to the methods of the %s contract\n", typeName, contract.name)
+		p("type %s struct {\n", typeName)
+		for _, method := range contract.methods {
+			p("%s abi.Method\n", unexportedName(method.name))
+		}
+		for _, boxMap := range contract.boxMaps {
+			p("%sBox abi.Type\n", unexportedName(boxMap.name))
+		}
+		p("}\n\n")
+
+		p("// New%s binds the methods of contract, failing if any generated method signature doesn't match the contract.\n", typeName)
+		p("func New%s(contract *abi.Contract) (*%s, error) {\n", typeName, typeName)
+		p("var (\nc = &%s{}\nerr error\n)\n", typeName)
+		p("for _, bind := range []struct {\nmethod *abi.Method\nsignature string\n}{\n")
+		for _, method := range contract.methods {
+			p("{&c.%s, %q},\n", unexportedName(method.name), method.signature)
+		}
+		p("} {\n")
+		p("if *bind.method, err = abiBindMethod(contract, bind.signature); err != nil {\nreturn nil, err\n}\n}\n")
+		for _, boxMap := range contract.boxMaps {
+			p("if c.%sBox, err = abi.TypeOf(%q); err != nil {\nreturn nil, err\n}\n", unexportedName(boxMap.name), boxMap.valueType.raw)
+		}
+		p("return c, nil\n}\n\n")
+
+		for _, method := range contract.methods {
+			g.generateMethod(p, typeName, method)
+		}
+		for _, boxMap := range contract.boxMaps {
+			goName := exportedName(boxMap.name)
+			retType := g.goType(boxMap.valueType)
+			p("// Decode%sBox decodes the contents of a %s box, encoded as %s\n", goName, boxMap.name, boxMap.valueType.raw)
+			p("func (c *%s) Decode%sBox(value []byte) (%s, error) {\n", typeName, goName, retType)
+			p("var zero %s\n", retType)
+			// avm-abi panics decoding a static type from too few bytes, so a truncated box has to be caught first
+			p("if size, err := c.%sBox.ByteLen(); err == nil && len(value) < size {\n", unexportedName(boxMap.name))
+			p("return zero, fmt.Errorf(\"%s box of %%d bytes, expected %%d\", len(value), size)\n}\n", boxMap.name)
+			p("decoded, err := c.%sBox.Decode(value)\n", unexportedName(boxMap.name))
+			p("if err != nil {\nreturn zero, fmt.Errorf(\"%s box: %%w\", err)\n}\n", boxMap.name)
+			p("return %s(decoded)\n}\n\n", g.decoder(boxMap.valueType))
+
+			p("// Encode%sBox encodes value as the contents of a %s box - the inverse of Decode%sBox\n", goName, boxMap.name, goName)
+			p("func (c *%s) Encode%sBox(value %s) ([]byte, error) {\n", typeName, goName, retType)
+			p("return c.%sBox.Encode(%s)\n}\n\n", unexportedName(boxMap.name), g.encoder(boxMap.valueType, "value"))
+		}
+	}
+
+	b.WriteString(helpers)
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return b.Bytes(), fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+func (g *generator) generateMethod(p func(string, ...any), typeName string, method methodDef) {
+	goName := exportedName(method.name)
+	field := unexportedName(method.name)
+
+	var (
+		params []string
+		args   []string
+		used   = map[string]bool{"c": true, "params": true}
+	)
+	for i, arg := range method.args {
+		name := unexportedName(arg.Name)
+		if name == "" || used[name] || isKeyword(name) {
+			name = fmt.Sprintf("%sArg%d", name, i)
+		}
+		used[name] = true
+		params = append(params, fmt.Sprintf("%s %s", name, g.goType(method.argTypes[i])))
+		args = append(args, g.encoder(method.argTypes[i], name))
+	}
+
+	p("// %s sets the method and arguments of params for a call to %s\n", goName, method.signature)
+	if desc := firstLine(method.desc); desc != "" {
+		p("//\n// %s\n", desc)
+	}
+	p("func (c *%s) %s(params transaction.AddMethodCallParams", typeName, goName)
+	for _, param := range params {
+		p(", %s", param)
+	}
+	p(") transaction.AddMethodCallParams {\n")
+	p("params.Method = c.%s\n", field)
+	p("params.MethodArgs = []any{%s}\n", strings.Join(args, ", "))
+	p("return params\n}\n\n")
+
+	if method.returns == nil {
+		return
+	}
+	retType := g.goType(method.returns)
+	p("// %sResult decodes the return value of a %s call\n", goName, method.name)
+	p("func (c *%s) %sResult(result transaction.ABIMethodResult) (%s, error) {\n", typeName, goName, retType)
+	p("if err := abiCheckResult(result, c.%s); err != nil {\nvar zero %s\nreturn zero, err\n}\n", field, retType)
+	p("return %s(result.ReturnValue)\n}\n\n", g.decoder(method.returns))
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(line)
+}
+
+func exportedName(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func unexportedName(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+func isKeyword(s string) bool {
+	switch s {
+	case "break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough", "for", "func",
+		"go", "goto", "if", "import", "interface", "map", "package", "range", "return", "select", "struct",
+		"switch", "type", "var", "err", "abi", "types", "transaction", "fmt", "errors", "big":
+		return true
+	}
+	return false
+}
+
+const helpers = `
+// ErrABIMismatch is returned when a contract doesn't match the signatures the bindings were generated from
+var ErrABIMismatch = errors.New("contract abi doesn't match generated bindings")
+
+func abiBindMethod(contract *abi.Contract, signature string) (abi.Method, error) {
+	expected, err := abi.MethodFromSignature(signature)
+	if err != nil {
+		return abi.Method{}, err
+	}
+	method, err := contract.GetMethodByName(expected.Name)
+	if err != nil {
+		return abi.Method{}, fmt.Errorf("%w: %s: %w", ErrABIMismatch, contract.Name, err)
+	}
+	if method.GetSignature() != signature {
+		return abi.Method{}, fmt.Errorf("%w: %s method is %s, expected %s", ErrABIMismatch, contract.Name, method.GetSignature(), signature)
+	}
+	return method, nil
+}
+
+func abiCheckResult(result transaction.ABIMethodResult, method abi.Method) error {
+	if result.Method.GetSignature() != method.GetSignature() {
+		return fmt.Errorf("result is for method %s, not %s", result.Method.Name, method.Name)
+	}
+	if result.DecodeError != nil {
+		return fmt.Errorf("%s return value: %w", method.Name, result.DecodeError)
+	}
+	return nil
+}
+
+func abiValue[T any](v any) (T, error) {
+	value, ok := v.(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("expected %T value, got %T", zero, v)
+	}
+	return value, nil
+}
+
+func abiUint8(v any) (uint8, error)     { return abiValue[uint8](v) }
+func abiUint16(v any) (uint16, error)   { return abiValue[uint16](v) }
+func abiUint32(v any) (uint32, error)   { return abiValue[uint32](v) }
+func abiUint64(v any) (uint64, error)   { return abiValue[uint64](v) }
+func abiBigInt(v any) (*big.Int, error) { return abiValue[*big.Int](v) }
+func abiBool(v any) (bool, error)       { return abiValue[bool](v) }
+func abiString(v any) (string, error)   { return abiValue[string](v) }
+func abiAny(v any) (any, error)         { return v, nil }
+
+func abiAddress(v any) (types.Address, error) {
+	var addr types.Address
+	pk, ok := v.([]byte)
+	if !ok || len(pk) != len(addr) {
+		return addr, fmt.Errorf("expected 32 byte address, got %T", v)
+	}
+	copy(addr[:], pk)
+	return addr, nil
+}
+
+func abiSliceOf[T any](decode func(any) (T, error)) func(any) ([]T, error) {
+	return func(v any) ([]T, error) {
+		values, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expected array, got %T", v)
+		}
+		ret := make([]T, len(values))
+		for i, value := range values {
+			var err error
+			if ret[i], err = decode(value); err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		return ret, nil
+	}
+}
+
+func abiValuesOf[T any](items []T, encode func(T) any) []any {
+	values := make([]any, len(items))
+	for i, item := range items {
+		values[i] = encode(item)
+	}
+	return values
+}
+`
@@ -0,0 +1,153 @@
+// contracthashes generates the table of approval program hashes of released contracts, used to identify the version
+// of deployed contracts when the embedded artifacts can't be compiled (see knownContractHashes in the reti package).
+//
+// The approval program of each ARC-32 application spec is compiled by an algod with the developer api enabled, once
+// for every network - the NFD registry id being substituted in at deploy time, hashes differ per network.  Hashes
+// already in the output file (of earlier releases) are kept.
+//
+// Usage (from update_contract_artifacts.sh, after the artifacts of a release have been copied in):
+//
+//	contracthashes -version 1.5.1 -algod http://localhost:4001 -token aaa... -out contracthashes_gen.go \
+//	    artifacts/contracts/ValidatorRegistry.arc32.json artifacts/contracts/StakingPool.arc32.json
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+
+	"github.com/algorandfoundation/reti/internal/lib/algo"
+)
+
+// networks the contracts are released on - fnet has no NFD registry, so its programs are compiled with id 0
+var networks = []string{"mainnet", "testnet", "betanet", "fnet"}
+
+// knownHash is a line of the generated table
+type knownHash struct {
+	hash     string
+	version  string
+	contract string
+	network  string
+}
+
+var hashLine = regexp.MustCompile(`^\s*"([A-Z2-7]{58})":\s*"([^"]+)",\s*// (\S+) (\S+)`)
+
+func main() {
+	var (
+		version  = flag.String("version", "", "contract release the artifacts are from (contracts/package.json)")
+		algodURL = flag.String("algod", "http://localhost:4001", "url of an algod with the developer api enabled")
+		token    = flag.String("token", strings.Repeat("a", 64), "algod api token")
+		out      = flag.String("out", "", "file to write the generated code to")
+	)
+	flag.Parse()
+	if *version == "" || *out == "" || flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	client, err := algod.MakeClient(*algodURL, *token)
+	if err != nil {
+		log.Fatalf("unable to create algod client: %v", err)
+	}
+	hashes, err := readKnownHashes(*out)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, fname := range flag.Args() {
+		contract := strings.TrimSuffix(filepath.Base(fname), ".arc32.json")
+		source, err := loadApprovalSource(fname)
+		if err != nil {
+			log.Fatalf("unable to load approval program of %s: %v", fname, err)
+		}
+		for _, network := range networks {
+			nfdRegistryID := algo.GetNetworkDefaults(network).NFDRegistryID
+			program := bytes.ReplaceAll(source, []byte("TMPL_nfdRegistryAppId"), []byte(strconv.FormatUint(nfdRegistryID, 10)))
+			response, err := client.TealCompile(program).Do(context.Background())
+			if err != nil {
+				log.Fatalf("unable to compile %s approval program for %s: %v", contract, network, err)
+			}
+			hashes[response.Hash] = knownHash{hash: response.Hash, version: *version, contract: contract, network: network}
+		}
+	}
+	if err := writeKnownHashes(*out, hashes); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readKnownHashes returns the hashes in an earlier generated file, if there is one
+func readKnownHashes(fname string) (map[string]knownHash, error) {
+	hashes := map[string]knownHash{}
+	data, err := os.ReadFile(fname)
+	if os.IsNotExist(err) {
+		return hashes, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if match := hashLine.FindStringSubmatch(line); match != nil {
+			hashes[match[1]] = knownHash{hash: match[1], version: match[2], contract: match[3], network: match[4]}
+		}
+	}
+	return hashes, nil
+}
+
+func writeKnownHashes(fname string, hashes map[string]knownHash) error {
+	sorted := make([]knownHash, 0, len(hashes))
+	for _, known := range hashes {
+		sorted = append(sorted, known)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.version != b.version {
+			return a.version < b.version
+		}
+		if a.contract != b.contract {
+			return a.contract < b.contract
+		}
+		return a.network < b.network
+	})
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by contracthashes. DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package reti")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "// knownContractHashes maps the approval program hash of each released contract, on each network, to its release")
+	fmt.Fprintln(&buf, "var knownContractHashes = map[string]string{")
+	for _, known := range sorted {
+		fmt.Fprintf(&buf, "\t%q: %q, // %s %s\n", known.hash, known.version, known.contract, known.network)
+	}
+	fmt.Fprintln(&buf, "}")
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("unable to format generated code: %w", err)
+	}
+	return os.WriteFile(fname, src, 0o644)
+}
+
+func loadApprovalSource(fname string) ([]byte, error) {
+	data, err := os.ReadFile(fname)
+	if err != nil {
+		return nil, err
+	}
+	var arc32 struct {
+		Source struct {
+			Approval string `json:"approval"`
+		} `json:"source"`
+	}
+	if err := json.Unmarshal(data, &arc32); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(arc32.Source.Approval)
+}
@@ -1,15 +1,22 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/algorand/go-algorand-sdk/v2/crypto"
 	"github.com/algorand/go-algorand-sdk/v2/types"
 	"github.com/urfave/cli/v3"
 
 	"github.com/algorandfoundation/reti/internal/lib/algo"
+	"github.com/algorandfoundation/reti/internal/lib/misc"
 )
 
 func GetKeyCmdOpts() *cli.Command {
@@ -20,10 +27,11 @@ func GetKeyCmdOpts() *cli.Command {
 		Before:  checkConfigured,
 		Commands: []*cli.Command{
 			{
-				Name:    "list",
-				Aliases: []string{"l"},
-				Usage:   "List part keys on this node",
-				Action:  KeysList,
+				Name:     "list",
+				Aliases:  []string{"l"},
+				Usage:    "List part keys on this node",
+				Action:   KeysList,
+				Metadata: map[string]any{readCommand: true},
 				Flags: []cli.Flag{
 					&cli.BoolFlag{
 						Name:  "all",
@@ -32,40 +40,349 @@ func GetKeyCmdOpts() *cli.Command {
 					},
 				},
 			},
+			{
+				Name:   "generate",
+				Usage:  "Generate a participation key on this node for one of our pools",
+				Action: KeyGenerate,
+				Flags: []cli.Flag{
+					&cli.UintFlag{
+						Name:     "pool",
+						Usage:    "Pool id (the number in 'pool list')",
+						Required: true,
+					},
+					&cli.UintFlag{
+						Name:  "first",
+						Usage: "First valid round - defaults to the current round",
+					},
+					&cli.UintFlag{
+						Name:  "last",
+						Usage: "Last valid round - defaults to the key length of the daemon config (daemon.keys.lengthDays) after first",
+					},
+					&cli.UintFlag{
+						Name:  "dilution",
+						Usage: "Key dilution - defaults to the square root of the validity window",
+					},
+				},
+			},
+			{
+				Name:   "delete",
+				Usage:  "Delete a participation key of one of our pools from this node",
+				Action: KeyDelete,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "id",
+						Usage:    "Participation key id (from 'key list')",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:  "force",
+						Usage: "Delete the key even if its pool is online with it - the pool stops participating",
+					},
+				},
+			},
+			{
+				Name:   "activate",
+				Usage:  "Have the pool of a participation key go online with it",
+				Action: KeyActivate,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "id",
+						Usage:    "Participation key id (from 'key list')",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "rotate",
+				Usage:  "Replace the participation key of a pool - generating a key, going online with it once valid and deleting the pool's other keys once it's in effect",
+				Action: KeyRotate,
+				Flags: []cli.Flag{
+					&cli.UintFlag{
+						Name:     "pool",
+						Usage:    "Pool id (the number in 'pool list')",
+						Required: true,
+					},
+				},
+			},
 		},
 	}
 }
 
+// KeyListOutput is a participation key in the key list output
+type KeyListOutput struct {
+	ID                  string `json:"id" yaml:"id"`
+	Address             string `json:"address" yaml:"address"`
+	VoteFirstValid      uint64 `json:"voteFirstValid" yaml:"voteFirstValid"`
+	VoteLastValid       uint64 `json:"voteLastValid" yaml:"voteLastValid"`
+	EffectiveFirstValid uint64 `json:"effectiveFirstValid" yaml:"effectiveFirstValid"`
+	EffectiveLastValid  uint64 `json:"effectiveLastValid" yaml:"effectiveLastValid"`
+	VoteKeyDilution     uint64 `json:"voteKeyDilution" yaml:"voteKeyDilution"`
+	SelectionKey        string `json:"selectionKey" yaml:"selectionKey"`
+	StateProofKey       string `json:"stateProofKey" yaml:"stateProofKey"`
+	VoteKey             string `json:"voteKey" yaml:"voteKey"`
+	LastVote            uint64 `json:"lastVote" yaml:"lastVote"`
+	LastBlockProposal   uint64 `json:"lastBlockProposal" yaml:"lastBlockProposal"`
+}
+
+type KeyListOutputs []KeyListOutput
+
+func (k KeyListOutputs) csvRecords() [][]string {
+	records := [][]string{{"id", "address", "voteFirstValid", "voteLastValid", "effectiveFirstValid", "effectiveLastValid",
+		"voteKeyDilution", "selectionKey", "stateProofKey", "voteKey", "lastVote", "lastBlockProposal"}}
+	for _, key := range k {
+		records = append(records, []string{key.ID, key.Address, strconv.FormatUint(key.VoteFirstValid, 10),
+			strconv.FormatUint(key.VoteLastValid, 10), strconv.FormatUint(key.EffectiveFirstValid, 10),
+			strconv.FormatUint(key.EffectiveLastValid, 10), strconv.FormatUint(key.VoteKeyDilution, 10), key.SelectionKey,
+			key.StateProofKey, key.VoteKey, strconv.FormatUint(key.LastVote, 10), strconv.FormatUint(key.LastBlockProposal, 10)})
+	}
+	return records
+}
+
 func KeysList(ctx context.Context, command *cli.Command) error {
-	partKeys, err := algo.GetParticipationKeys(ctx, App.algoClient)
+	partKeys, err := algo.GetParticipationKeys(ctx, App.chain)
+	if err != nil {
+		return err
+	}
+	// the keys of the pools on this node (in pool order), or every key on the node
+	var accounts []string
+	if command.Bool("all") {
+		accounts = slices.Sorted(maps.Keys(partKeys))
+	} else {
+		localPools := App.retiClient.Info().LocalPools
+		for _, poolID := range slices.Sorted(maps.Keys(localPools)) {
+			accounts = append(accounts, crypto.GetApplicationAddress(localPools[poolID]).String())
+		}
+	}
+	output := KeyListOutputs{}
+	for _, account := range accounts {
+		for _, key := range partKeys[account] {
+			selkey, _ := types.EncodeAddress(key.Key.SelectionParticipationKey)
+			votekey, _ := types.EncodeAddress(key.Key.VoteParticipationKey)
+			output = append(output, KeyListOutput{
+				ID:                  key.Id,
+				Address:             key.Address,
+				VoteFirstValid:      key.Key.VoteFirstValid,
+				VoteLastValid:       key.Key.VoteLastValid,
+				EffectiveFirstValid: key.EffectiveFirstValid,
+				EffectiveLastValid:  key.EffectiveLastValid,
+				VoteKeyDilution:     key.Key.VoteKeyDilution,
+				SelectionKey:        selkey,
+				StateProofKey:       base64.StdEncoding.EncodeToString(key.Key.StateProofKey),
+				VoteKey:             votekey,
+				LastVote:            key.LastVote,
+				LastBlockProposal:   key.LastBlockProposal,
+			})
+		}
+	}
+	return printOutput(command, output, func(out io.Writer) {
+		for _, key := range output {
+			fmt.Fprintln(out, "id:", key.ID)
+			fmt.Fprintln(out, "Address:", key.Address)
+			fmt.Fprintln(out, "Vote First Valid:", key.VoteFirstValid)
+			fmt.Fprintln(out, "Vote Last Valid:", key.VoteLastValid)
+			fmt.Fprintln(out, "Effective First Valid:", key.EffectiveFirstValid)
+			fmt.Fprintln(out, "Effective Last Valid:", key.EffectiveLastValid)
+			fmt.Fprintln(out, "Vote Key Dilution:", key.VoteKeyDilution)
+			fmt.Fprintln(out, "Selection Participation Key:", key.SelectionKey)
+			fmt.Fprintln(out, "state Proof Key:", key.StateProofKey)
+			fmt.Fprintln(out, "Vote Participation Key:", key.VoteKey)
+			fmt.Fprintln(out, "Last Vote:", key.LastVote)
+			fmt.Fprintln(out, "Last Block Proposal:", key.LastBlockProposal)
+			fmt.Fprintln(out)
+		}
+	})
+}
+
+func KeyGenerate(ctx context.Context, command *cli.Command) error {
+	key, err := generatePoolKey(ctx, App.chain, command.Uint("pool"), command.Uint("first"), command.Uint("last"), command.Uint("dilution"))
+	if err != nil {
+		return err
+	}
+	misc.Infof(App.logger, "participation key:%s generated for pool %d, rounds %d - %d - 'key activate --id %s' to go online with it",
+		key.Id, command.Uint("pool"), key.Key.VoteFirstValid, key.Key.VoteLastValid, key.Id)
+	return nil
+}
+
+func KeyDelete(ctx context.Context, command *cli.Command) error {
+	key, poolID, err := getPoolKey(ctx, command.String("id"))
+	if err != nil {
+		return err
+	}
+	active, err := isActiveKey(ctx, key)
+	if err != nil {
+		return err
+	}
+	if active && !command.Bool("force") {
+		return fmt.Errorf("pool %d is online with key:%s - activate another key first, or use --force", poolID, key.Id)
+	}
+	if err = algo.DeleteParticipationKey(ctx, App.chain, App.logger, key.Id); err != nil {
+		return err
+	}
+	misc.Infof(App.logger, "participation key:%s of pool %d deleted", key.Id, poolID)
+	return nil
+}
+
+func KeyActivate(ctx context.Context, command *cli.Command) error {
+	key, poolID, err := getPoolKey(ctx, command.String("id"))
 	if err != nil {
 		return err
 	}
-	for _, poolAppID := range App.retiClient.Info().LocalPools {
-		addr := crypto.GetApplicationAddress(poolAppID)
-		for account, keys := range partKeys {
-			if !command.Bool("all") && addr.String() != account {
-				continue
-			}
-			for _, key := range keys {
-				selkey, _ := types.EncodeAddress(key.Key.SelectionParticipationKey)
-				stproofKey := base64.StdEncoding.EncodeToString(key.Key.StateProofKey)
-				votekey, _ := types.EncodeAddress(key.Key.VoteParticipationKey)
-				fmt.Println("id:", key.Id)
-				fmt.Println("Address:", key.Address)
-				fmt.Println("Vote First Valid:", key.Key.VoteFirstValid)
-				fmt.Println("Vote Last Valid:", key.Key.VoteLastValid)
-				fmt.Println("Effective First Valid:", key.EffectiveFirstValid)
-				fmt.Println("Effective Last Valid:", key.EffectiveLastValid)
-				fmt.Println("Vote Key Dilution:", key.Key.VoteKeyDilution)
-				fmt.Println("Selection Participation Key:", selkey)
-				fmt.Println("state Proof Key:", stproofKey)
-				fmt.Println("Vote Participation Key:", votekey)
-				fmt.Println("Last Vote:", key.LastVote)
-				fmt.Println("Last Block Proposal:", key.LastBlockProposal)
-				fmt.Println()
-			}
+	if err = activateKey(ctx, key); err != nil {
+		return err
+	}
+	misc.Infof(App.logger, "pool %d went online with participation key:%s", poolID, key.Id)
+	return nil
+}
+
+func KeyRotate(ctx context.Context, command *cli.Command) error {
+	key, err := rotatePoolKey(ctx, command.Uint("pool"))
+	if err != nil {
+		return err
+	}
+	misc.Infof(App.logger, "pool %d rotated to participation key:%s", command.Uint("pool"), key.Id)
+	return nil
+}
+
+// getPoolAppID returns the app id of one of our pools by its pool id
+func getPoolAppID(poolID uint64) (uint64, error) {
+	pools := App.retiClient.Info().Pools
+	if poolID == 0 || poolID > uint64(len(pools)) {
+		return 0, fmt.Errorf("pool num:%d not found for validator:%d", poolID, App.retiClient.Info().Config.ID)
+	}
+	return pools[poolID-1].PoolAppId, nil
+}
+
+// getPoolKey returns the participation key with the id (on this node), and the pool id it's for - refusing keys
+// not belonging to one of our pools
+func getPoolKey(ctx context.Context, id string) (algo.ParticipationKey, uint64, error) {
+	keys, err := App.chain.ParticipationKeys(ctx)
+	if err != nil {
+		return algo.ParticipationKey{}, 0, err
+	}
+	idx := slices.IndexFunc(keys, func(key algo.ParticipationKey) bool { return key.Id == id })
+	if idx == -1 {
+		return algo.ParticipationKey{}, 0, fmt.Errorf("participation key:%s not found on this node", id)
+	}
+	key := keys[idx]
+	for i, pool := range App.retiClient.Info().Pools {
+		if crypto.GetApplicationAddress(pool.PoolAppId).String() == key.Address {
+			return key, uint64(i + 1), nil
+		}
+	}
+	return algo.ParticipationKey{}, 0, fmt.Errorf("participation key:%s is for account:%s which isn't a pool of validator:%d",
+		id, key.Address, App.retiClient.Info().Config.ID)
+}
+
+// isActiveKey returns true if the key's account is online with it
+func isActiveKey(ctx context.Context, key algo.ParticipationKey) (bool, error) {
+	acctInfo, err := algo.GetBareAccount(ctx, App.chain, key.Address)
+	if err != nil {
+		return false, fmt.Errorf("account fetch error, account:%s, err:%w", key.Address, err)
+	}
+	return acctInfo.Status == OnlineStatus && bytes.Equal(acctInfo.Participation.SelectionParticipationKey, key.Key.SelectionParticipationKey), nil
+}
+
+// generatePoolKey generates a participation key on the node of chain (ie: App.chain for this node) for one of our
+// pools.  A first valid round of 0 is the current round, a last valid round of 0 the configured key length after it
+// and a dilution of 0 algod's default.
+func generatePoolKey(ctx context.Context, chain algo.Chain, poolID uint64, firstValid, lastValid, dilution uint64) (*algo.ParticipationKey, error) {
+	poolAppID, err := getPoolAppID(poolID)
+	if err != nil {
+		return nil, err
+	}
+	if firstValid == 0 {
+		status, err := App.chain.Status(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch node status: %w", err)
+		}
+		firstValid = status.LastRound
+	}
+	if lastValid == 0 {
+		blockTime, err := algo.CalcBlockTimes(ctx, App.chain, 20)
+		if err != nil {
+			return nil, err
 		}
+		keyDuration := time.Duration(App.config.Daemon.Keys.LengthDays) * 24 * time.Hour
+		lastValid = firstValid + uint64(keyDuration/blockTime)
+	}
+	if lastValid <= firstValid {
+		return nil, fmt.Errorf("last valid round:%d must be after first valid round:%d", lastValid, firstValid)
+	}
+	return algo.GenerateParticipationKey(ctx, chain, App.logger, crypto.GetApplicationAddress(poolAppID).String(), firstValid, lastValid, dilution)
+}
+
+// activateKey has the key's pool go online with it
+func activateKey(ctx context.Context, key algo.ParticipationKey) error {
+	status, err := App.chain.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to fetch node status: %w", err)
+	}
+	if key.Key.VoteLastValid <= status.LastRound {
+		return fmt.Errorf("participation key:%s expired at round %d", key.Id, key.Key.VoteLastValid)
+	}
+	for _, pool := range App.retiClient.Info().Pools {
+		if crypto.GetApplicationAddress(pool.PoolAppId).String() != key.Address {
+			continue
+		}
+		managerAddr, _ := types.DecodeAddress(App.retiClient.Info().Config.Manager)
+		err = App.retiClient.GoOnline(pool.PoolAppId, managerAddr, key.Key.VoteParticipationKey, key.Key.SelectionParticipationKey,
+			key.Key.StateProofKey, key.Key.VoteFirstValid, key.Key.VoteLastValid, key.Key.VoteKeyDilution)
+		if err != nil {
+			return fmt.Errorf("unable to go online with key:%s for account:%s [pool app id:%d], err:%w", key.Id, key.Address, pool.PoolAppId, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("participation key:%s isn't for a pool of validator:%d", key.Id, App.retiClient.Info().Config.ID)
+}
+
+// rotatePoolKey generates a new participation key (valid from now, for the configured key length) for one of our
+// pools, has the pool go online with it once it's valid and deletes the pool's other keys on this node once the
+// new key is in effect - which is 320 rounds after going online, so rotation takes a while.
+func rotatePoolKey(ctx context.Context, poolID uint64) (*algo.ParticipationKey, error) {
+	key, err := generatePoolKey(ctx, App.chain, poolID, 0, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err = waitForRound(ctx, key.Key.VoteFirstValid); err != nil {
+		return nil, err
+	}
+	if err = activateKey(ctx, *key); err != nil {
+		return nil, err
+	}
+	// the effective first round is only known once the key is registered
+	registered, _, err := getPoolKey(ctx, key.Id)
+	if err != nil {
+		return nil, err
+	}
+	key = &registered
+	misc.Infof(App.logger, "pool %d online with key:%s, waiting for it to take effect at round %d before removing the old keys", poolID, key.Id, key.EffectiveFirstValid)
+	if err = waitForRound(ctx, key.EffectiveFirstValid); err != nil {
+		return nil, err
+	}
+	keys, err := App.chain.ParticipationKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, oldKey := range keys {
+		if oldKey.Address != key.Address || oldKey.Id == key.Id {
+			continue
+		}
+		if err = algo.DeleteParticipationKey(ctx, App.chain, App.logger, oldKey.Id); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// waitForRound blocks until the round has been reached
+func waitForRound(ctx context.Context, round uint64) error {
+	status, err := App.chain.Status(ctx)
+	for err == nil && status.LastRound < round {
+		// StatusAfterBlock waits at most a minute, so wait a few rounds at a time
+		status, err = App.chain.StatusAfterBlock(ctx, min(round-1, status.LastRound+10))
+	}
+	if err != nil {
+		return fmt.Errorf("unable to wait for round %d: %w", round, err)
 	}
 	return nil
 }
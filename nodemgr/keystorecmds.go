@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
+
+	"github.com/algorandfoundation/reti/internal/lib/algo"
+	"github.com/algorandfoundation/reti/internal/lib/misc"
+)
+
+func GetKeystoreCmdOpts() *cli.Command {
+	return &cli.Command{
+		Name:     "keystore",
+		Usage:    "Manage the encrypted keystore (see --keystore) holding the owner/manager account keys",
+		Metadata: map[string]any{standaloneCommand: true},
+		Commands: []*cli.Command{
+			{
+				Name:     "add",
+				Usage:    "Add an account to the keystore, creating the keystore if it doesn't exist",
+				Action:   KeystoreAdd,
+				Metadata: map[string]any{standaloneCommand: true},
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "name",
+						Usage: "Descriptive name for the account (ie: owner, manager)",
+					},
+					&cli.StringFlag{
+						Name:  "mnemonicfile",
+						Usage: "File containing the 25-word mnemonic of the account.  Prompted for if not specified",
+					},
+				},
+			},
+			{
+				Name:     "list",
+				Usage:    "List the accounts in the keystore (doesn't require the passphrase)",
+				Action:   KeystoreList,
+				Metadata: map[string]any{standaloneCommand: true},
+			},
+			{
+				Name:     "remove",
+				Usage:    "Remove an account from the keystore",
+				Action:   KeystoreRemove,
+				Metadata: map[string]any{standaloneCommand: true},
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "address",
+						Usage:    "The address of the account to remove",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:     "export",
+				Usage:    "Display the mnemonic of an account in the keystore",
+				Action:   KeystoreExport,
+				Metadata: map[string]any{standaloneCommand: true},
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "address",
+						Usage:    "The address of the account to export",
+						Required: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func KeystoreAdd(ctx context.Context, command *cli.Command) error {
+	filename, err := keystoreFilename(command)
+	if err != nil {
+		return err
+	}
+	var ks *algo.Keystore
+	if _, err := os.Stat(filename); errors.Is(err, os.ErrNotExist) {
+		passphrase, err := readPassphrase(command.String("keystore-passfile"), "New keystore passphrase", true)
+		if err != nil {
+			return err
+		}
+		ks, err = algo.CreateKeystore(filename, passphrase)
+		if err != nil {
+			return err
+		}
+		misc.Infof(App.logger, "created keystore %s", filename)
+	} else {
+		ks, err = unlockKeystore(filename, command.String("keystore-passfile"))
+		if err != nil {
+			return err
+		}
+	}
+
+	var mnemonic []byte
+	if mnemonicFile := command.String("mnemonicfile"); mnemonicFile != "" {
+		mnemonic, err = os.ReadFile(mnemonicFile)
+	} else {
+		mnemonic, err = readSecret("Account mnemonic")
+	}
+	if err != nil {
+		return err
+	}
+	address, err := ks.AddMnemonic(command.String("name"), strings.Join(strings.Fields(string(mnemonic)), " "))
+	if err != nil {
+		return err
+	}
+	if err := ks.Save(); err != nil {
+		return err
+	}
+	misc.Infof(App.logger, "account:%s added to keystore %s", address, filename)
+	return nil
+}
+
+func KeystoreList(ctx context.Context, command *cli.Command) error {
+	filename, err := keystoreFilename(command)
+	if err != nil {
+		return err
+	}
+	ks, err := algo.OpenKeystore(filename)
+	if err != nil {
+		return err
+	}
+	out := new(bytes.Buffer)
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Name\tAddress\t")
+	for _, account := range ks.Accounts() {
+		fmt.Fprintf(tw, "%s\t%s\t\n", account.Name, account.Address)
+	}
+	tw.Flush()
+	fmt.Print(out.String())
+	return nil
+}
+
+func KeystoreRemove(ctx context.Context, command *cli.Command) error {
+	filename, err := keystoreFilename(command)
+	if err != nil {
+		return err
+	}
+	ks, err := unlockKeystore(filename, command.String("keystore-passfile"))
+	if err != nil {
+		return err
+	}
+	address := command.String("address")
+	if result, _ := yesNo(fmt.Sprintf("Permanently remove account %s from the keystore", address)); result != "y" {
+		return nil
+	}
+	if err := ks.Remove(address); err != nil {
+		return err
+	}
+	if err := ks.Save(); err != nil {
+		return err
+	}
+	misc.Infof(App.logger, "account:%s removed from keystore %s", address, filename)
+	return nil
+}
+
+func KeystoreExport(ctx context.Context, command *cli.Command) error {
+	filename, err := keystoreFilename(command)
+	if err != nil {
+		return err
+	}
+	ks, err := unlockKeystore(filename, command.String("keystore-passfile"))
+	if err != nil {
+		return err
+	}
+	if result, _ := yesNo("Display the account mnemonic in plaintext"); result != "y" {
+		return nil
+	}
+	mnemonic, err := ks.ExportMnemonic(command.String("address"))
+	if err != nil {
+		return err
+	}
+	fmt.Println(mnemonic)
+	return nil
+}
+
+func keystoreFilename(command *cli.Command) (string, error) {
+	filename := command.String("keystore")
+	if filename == "" {
+		return "", errors.New("the keystore file must be specified using either --keystore or RETI_KEYSTORE env var")
+	}
+	return filename, nil
+}
+
+// unlockKeystore opens and unlocks the keystore using the passphrase from passfile, or prompting for it if not set
+func unlockKeystore(filename string, passfile string) (*algo.Keystore, error) {
+	ks, err := algo.OpenKeystore(filename)
+	if err != nil {
+		return nil, err
+	}
+	passphrase, err := readPassphrase(passfile, fmt.Sprintf("Passphrase for keystore %s", filename), false)
+	if err != nil {
+		return nil, err
+	}
+	if err := ks.Unlock(passphrase); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// readPassphrase reads the passphrase from passfile (a passphrase or key file - trailing newlines are ignored) or,
+// if not set, prompts for it - twice if confirm is set.
+func readPassphrase(passfile string, prompt string, confirm bool) ([]byte, error) {
+	if passfile != "" {
+		passphrase, err := os.ReadFile(passfile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read passphrase file: %w", err)
+		}
+		return bytes.TrimRight(passphrase, "\r\n"), nil
+	}
+	passphrase, err := readSecret(prompt)
+	if err != nil {
+		return nil, err
+	}
+	if confirm {
+		confirmation, err := readSecret("Confirm " + strings.ToLower(prompt[:1]) + prompt[1:])
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(passphrase, confirmation) {
+			return nil, errors.New("passphrases don't match")
+		}
+	}
+	if len(passphrase) == 0 {
+		return nil, errors.New("passphrase can't be empty")
+	}
+	return passphrase, nil
+}
+
+// readSecret prompts for a value without echoing it - failing if not running interactively
+func readSecret(prompt string) ([]byte, error) {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil, fmt.Errorf("unable to prompt for %s - not running interactively", strings.ToLower(prompt))
+	}
+	fmt.Fprintf(os.Stderr, "%s: ", prompt)
+	secret, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	return secret, err
+}
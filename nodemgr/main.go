@@ -2,8 +2,11 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os"
+
+	"github.com/algorandfoundation/reti/internal/lib/algo"
 )
 
 var App *RetiApp
@@ -11,6 +14,10 @@ var App *RetiApp
 func main() {
 	App = initApp()
 	err := App.cliCmd.Run(context.Background(), os.Args)
+	if errors.Is(err, algo.ErrTxnsExported) {
+		slog.Info("Transactions not sent - sign them with 'txn sign' and send with 'txn submit'", "msg", err)
+		return
+	}
 	if err != nil {
 		slog.Error("Error in execution:", "msg", err)
 		os.Exit(1)
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats of read commands (--output).  table is the human readable default - the others have stable
+// schemas (the json/yaml tags of each command's output type) for scripts and monitoring.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+	outputCSV   = "csv"
+)
+
+var outputFormats = []string{outputTable, outputJSON, outputYAML, outputCSV}
+
+// csvOutput is implemented by command outputs which can be written as csv - returning the header and a record per row
+type csvOutput interface {
+	csvRecords() [][]string
+}
+
+// printOutput writes the output of a read command in the --output format - table calling the command's own
+// rendering
+func printOutput(command *cli.Command, output any, table func(out io.Writer)) error {
+	switch format := command.String("output"); format {
+	case outputTable:
+		table(os.Stdout)
+	case outputJSON:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	case outputYAML:
+		encoder := yaml.NewEncoder(os.Stdout)
+		encoder.SetIndent(2)
+		defer encoder.Close()
+		return encoder.Encode(output)
+	case outputCSV:
+		records, ok := output.(csvOutput)
+		if !ok {
+			return fmt.Errorf("csv output isn't supported by this command - use json or yaml")
+		}
+		writer := csv.NewWriter(os.Stdout)
+		return writer.WriteAll(records.csvRecords())
+	default:
+		return fmt.Errorf("unknown output format:%s", format)
+	}
+	return nil
+}
+
+func validateOutputFormat(format string) error {
+	if !slices.Contains(outputFormats, format) {
+		return fmt.Errorf("unknown output format:%s, must be one of %v", format, outputFormats)
+	}
+	return nil
+}
+
+// logOutput is where logs are written - stdout, unless a machine-readable output format is selected in which case
+// logs move to stderr so they can't corrupt it
+var logOutput = &switchableWriter{w: os.Stdout}
+
+type switchableWriter struct {
+	sync.Mutex
+	w io.Writer
+}
+
+func (s *switchableWriter) Write(p []byte) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.w.Write(p)
+}
+
+func (s *switchableWriter) set(w io.Writer) {
+	s.Lock()
+	defer s.Unlock()
+	s.w = w
+}
@@ -4,10 +4,11 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"math/big"
+	"slices"
 	"strconv"
-	"strings"
 	"text/tabwriter"
 	"time"
 
@@ -27,10 +28,11 @@ func GetPoolCmdOpts() *cli.Command {
 		Before:  checkConfigured,
 		Commands: []*cli.Command{
 			{
-				Name:    "list",
-				Aliases: []string{"l"},
-				Usage:   "List pools on this node",
-				Action:  PoolsList,
+				Name:     "list",
+				Aliases:  []string{"l"},
+				Usage:    "List pools on this node",
+				Action:   PoolsList,
+				Metadata: map[string]any{readCommand: true},
 				Flags: []cli.Flag{
 					&cli.BoolFlag{
 						Name:  "all",
@@ -46,9 +48,10 @@ func GetPoolCmdOpts() *cli.Command {
 				},
 			},
 			{
-				Name:   "ledger",
-				Usage:  "List detailed ledger for a specific pool",
-				Action: PoolLedger,
+				Name:     "ledger",
+				Usage:    "List detailed ledger for a specific pool",
+				Action:   PoolLedger,
+				Metadata: map[string]any{readCommand: true},
 				Flags: []cli.Flag{
 					&cli.UintFlag{
 						Name:     "pool",
@@ -105,6 +108,41 @@ func GetPoolCmdOpts() *cli.Command {
 				},
 				Action: PayoutPool,
 			},
+			{
+				Name:  "move",
+				Usage: "Move a pool to another node - generating its participation key on the new node first, so it's back online as soon as it's moved",
+				Flags: []cli.Flag{
+					&cli.UintFlag{
+						Name:     "pool",
+						Usage:    "Pool id (the number in 'pool list')",
+						Required: true,
+					},
+					&cli.UintFlag{
+						Name:     "to-node",
+						Usage:    "Node number to move the pool to",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "to-algod",
+						Usage: "algod url of the node the pool moves to - required unless it's this node",
+					},
+					&cli.StringFlag{
+						Name:  "to-algod-token-secret",
+						Usage: "Secret (env var or secrets provider key) holding the admin token of --to-algod",
+						Value: "RETI_TO_ALGOD_TOKEN",
+					},
+					&cli.StringFlag{
+						Name:  "from-algod",
+						Usage: "algod url of the node the pool moves from, to delete its old keys - defaults to this node if the pool is on it",
+					},
+					&cli.StringFlag{
+						Name:  "from-algod-token-secret",
+						Usage: "Secret (env var or secrets provider key) holding the admin token of --from-algod",
+						Value: "RETI_FROM_ALGOD_TOKEN",
+					},
+				},
+				Action: MovePool,
+			},
 			{
 				Name:  "offline",
 				Usage: "Have pool go offline - should only be used if 0 balance as the daemon will have it go online again if its a managed pool",
@@ -122,56 +160,108 @@ func GetPoolCmdOpts() *cli.Command {
 	}
 }
 
+// PoolListOutput is the pool list output - amounts are in microAlgo
+type PoolListOutput struct {
+	Node                 uint64          `json:"node" yaml:"node"`
+	CurrentRound         uint64          `json:"currentRound" yaml:"currentRound"`
+	Pools                []PoolListEntry `json:"pools" yaml:"pools"`
+	TotalStakers         uint64          `json:"totalStakers" yaml:"totalStakers"`
+	TotalStaked          uint64          `json:"totalStaked" yaml:"totalStaked"`
+	TotalRewardAvailable uint64          `json:"totalRewardAvailable" yaml:"totalRewardAvailable"`
+}
+
+type PoolListEntry struct {
+	Pool            int     `json:"pool" yaml:"pool"`
+	Node            int     `json:"node" yaml:"node"`
+	AppID           uint64  `json:"appId" yaml:"appId"`
+	Online          bool    `json:"online" yaml:"online"`
+	Stakers         uint64  `json:"stakers" yaml:"stakers"`
+	Staked          uint64  `json:"staked" yaml:"staked"`
+	RewardAvailable uint64  `json:"rewardAvailable" yaml:"rewardAvailable"`
+	APR             float64 `json:"apr" yaml:"apr"`
+	// LastVoteRound and LastProposalRound are 0 if unknown (ie: --offline)
+	LastVoteRound     uint64 `json:"lastVoteRound" yaml:"lastVoteRound"`
+	LastProposalRound uint64 `json:"lastProposalRound" yaml:"lastProposalRound"`
+	// KeyLastValid is the last round of the participation key the pool is online with - 0 if unknown
+	KeyLastValid uint64 `json:"keyLastValid" yaml:"keyLastValid"`
+}
+
+func (p PoolListOutput) csvRecords() [][]string {
+	records := [][]string{{"pool", "node", "appId", "online", "stakers", "staked", "rewardAvailable", "apr", "lastVoteRound", "lastProposalRound", "keyLastValid"}}
+	for _, pool := range p.Pools {
+		records = append(records, []string{strconv.Itoa(pool.Pool), strconv.Itoa(pool.Node), strconv.FormatUint(pool.AppID, 10),
+			strconv.FormatBool(pool.Online), strconv.FormatUint(pool.Stakers, 10), strconv.FormatUint(pool.Staked, 10),
+			strconv.FormatUint(pool.RewardAvailable, 10), strconv.FormatFloat(pool.APR, 'f', -1, 64),
+			strconv.FormatUint(pool.LastVoteRound, 10), strconv.FormatUint(pool.LastProposalRound, 10),
+			strconv.FormatUint(pool.KeyLastValid, 10)})
+	}
+	return records
+}
+
 func PoolsList(ctx context.Context, command *cli.Command) error {
-	// Display user-friendly version of pool list inside info using the TabWriter class, displaying
-	// final output using fmt.Print type statements
+	showAll := command.Bool("all")
+	output, err := getPoolList(ctx, showAll, command.Bool("offline"))
+	if err != nil {
+		return err
+	}
+	return printOutput(command, output, func(out io.Writer) {
+		printPoolList(out, output, showAll)
+	})
+}
+
+// getPoolList returns the pools of this node (or all pools if showAll) - without participation data if offlineAlgod
+func getPoolList(ctx context.Context, showAll bool, offlineAlgod bool) (PoolListOutput, error) {
 	var (
-		totalRewards uint64
-		showAll      = command.Bool("all")
-		offlineAlgod = command.Bool("offline")
-		info         = App.retiClient.Info()
-		partKeys     = algo.PartKeysByAddress{}
+		info     = App.retiClient.Info()
+		partKeys = algo.PartKeysByAddress{}
 	)
 
 	state, err := App.retiClient.GetValidatorState(App.retiClient.Info().Config.ID)
 	if err != nil {
-		return fmt.Errorf("failed to get validator state: %w", err)
+		return PoolListOutput{}, fmt.Errorf("failed to get validator state: %w", err)
 	}
 
 	// we just want the latest round so we can show last vote/proposal relative to current round
-	status, err := App.algoClient.Status().Do(ctx)
+	status, err := App.chain.Status(ctx)
 
+	if App.retiClient.NodeNum == 0 {
+		// read-only without a node of our own - every pool is someone else's, as are the keys on this algod
+		showAll, offlineAlgod = true, true
+	}
 	if !offlineAlgod {
-		partKeys, err = algo.GetParticipationKeys(ctx, App.algoClient)
+		partKeys, err = algo.GetParticipationKeys(ctx, App.chain)
 		if err != nil {
-			return err
+			return PoolListOutput{}, err
 		}
 	}
-	getParticipationData := func(account string, selectionPartKey []byte) (uint64, uint64) {
+	getParticipationData := func(account string, selectionPartKey []byte) (uint64, uint64, uint64) {
 		if keys, found := partKeys[account]; found {
 			for _, key := range keys {
 				if bytes.Compare(key.Key.SelectionParticipationKey, selectionPartKey) == 0 {
-					return key.LastVote, key.LastBlockProposal
+					return key.LastVote, key.LastBlockProposal, key.Key.VoteLastValid
 				}
 			}
 		}
-		return 0, 0
+		return 0, 0, 0
 	}
 
-	out := new(strings.Builder)
-	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
-	fmt.Fprintln(tw, "Viewing pools for our Node:", App.retiClient.NodeNum)
-	if !showAll {
-		fmt.Fprintln(tw, "Pool (O=Online)\tPool App id\t# stakers\tAmt Staked\tRwd Avail\tAPR %\tVote\tProp.\t")
-	} else {
-		fmt.Fprintln(tw, "Pool (O=Online)\tNode\tPool App id\t# stakers\tAmt Staked\tRwd Avail\tAPR %\tVote\tProp.\t")
+	var poolAppIds []uint64
+	for _, pool := range info.Pools {
+		poolAppIds = append(poolAppIds, pool.PoolAppId)
+	}
+	poolStates, err := App.retiClient.GetPoolStates(poolAppIds)
+	if err != nil {
+		return PoolListOutput{}, fmt.Errorf("failed to get pool states: %w", err)
+	}
 
+	output := PoolListOutput{
+		Node:         App.retiClient.NodeNum,
+		CurrentRound: status.LastRound,
+		Pools:        []PoolListEntry{},
+		TotalStakers: state.TotalStakers,
+		TotalStaked:  state.TotalAlgoStaked,
 	}
 	for i, pool := range info.Pools {
-		var (
-			onlineStr = " "
-			nodeStr   string
-		)
 		// find the pool in the node assignments (so we can show node num if necessary)
 		nodeNum := 0
 		for nodeIdx, nodeConfigs := range info.NodePoolAssignments.Nodes {
@@ -183,74 +273,89 @@ func PoolsList(ctx context.Context, command *cli.Command) error {
 			}
 		}
 		if nodeNum == 0 {
-			return fmt.Errorf("unable to determine node number for pool appid:%d", pool.PoolAppId)
+			return PoolListOutput{}, fmt.Errorf("unable to determine node number for pool appid:%d", pool.PoolAppId)
 		}
-		if uint64(nodeNum) == App.retiClient.NodeNum {
-			nodeStr = "*"
-		} else if !showAll {
+		if uint64(nodeNum) != App.retiClient.NodeNum && !showAll {
 			continue
-		} else {
-			nodeStr = strconv.Itoa(nodeNum)
 		}
-		acctInfo, err := algo.GetBareAccount(context.Background(), App.algoClient, crypto.GetApplicationAddress(pool.PoolAppId).String())
+		acctInfo, err := algo.GetBareAccount(context.Background(), App.chain, crypto.GetApplicationAddress(pool.PoolAppId).String())
 		if err != nil {
-			return fmt.Errorf("account fetch error, account:%s, err:%w", crypto.GetApplicationAddress(pool.PoolAppId).String(), err)
-		}
-		if acctInfo.Status == OnlineStatus {
-			onlineStr = "O"
+			return PoolListOutput{}, fmt.Errorf("account fetch error, account:%s, err:%w", crypto.GetApplicationAddress(pool.PoolAppId).String(), err)
 		}
 
 		rewardAvail := App.retiClient.PoolAvailableRewards(pool.PoolAppId, pool.TotalAlgoStaked)
-		apr, _ := App.retiClient.GetAvgApr(pool.PoolAppId)
-		totalRewards += rewardAvail
-
-		lastVote, lastProposal := getParticipationData(crypto.GetApplicationAddress(pool.PoolAppId).String(), acctInfo.Participation.SelectionParticipationKey)
-		var (
-			voteData string
-			partData string
-		)
-		if lastVote != 0 {
-			// round might get behind last vote/proposal so handle that as well.
-			if status.LastRound <= lastVote {
-				voteData = "latest"
-			} else {
-				voteData = fmt.Sprintf("-%d", status.LastRound-lastVote)
-			}
-		}
-		if lastProposal != 0 {
-			if status.LastRound <= lastProposal {
-				partData = "latest"
-			} else {
-				partData = fmt.Sprintf("-%d", status.LastRound-lastProposal)
-			}
-		}
-		floatApr, _, _ := new(big.Float).Parse(apr.String(), 10)
+		output.TotalRewardAvailable += rewardAvail
+
+		lastVote, lastProposal, keyLastValid := getParticipationData(crypto.GetApplicationAddress(pool.PoolAppId).String(), acctInfo.Participation.SelectionParticipationKey)
+		floatApr, _, _ := new(big.Float).Parse(poolStates[pool.PoolAppId].AvgApr.String(), 10)
 		floatApr.Quo(floatApr, big.NewFloat(100.0))
+		apr, _ := floatApr.Float64()
+
+		output.Pools = append(output.Pools, PoolListEntry{
+			Pool:              i + 1,
+			Node:              nodeNum,
+			AppID:             pool.PoolAppId,
+			Online:            acctInfo.Status == OnlineStatus,
+			Stakers:           uint64(pool.TotalStakers),
+			Staked:            pool.TotalAlgoStaked,
+			RewardAvailable:   rewardAvail,
+			APR:               apr,
+			LastVoteRound:     lastVote,
+			LastProposalRound: lastProposal,
+			KeyLastValid:      keyLastValid,
+		})
+	}
+	return output, nil
+}
 
+// printPoolList displays the user-friendly version of the pool list using the TabWriter class
+func printPoolList(out io.Writer, output PoolListOutput, showAll bool) {
+	// round might get behind last vote/proposal so handle that as well.
+	sinceRound := func(round uint64) string {
+		switch {
+		case round == 0:
+			return ""
+		case output.CurrentRound <= round:
+			return "latest"
+		default:
+			return fmt.Sprintf("-%d", output.CurrentRound-round)
+		}
+	}
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Viewing pools for our Node:", output.Node)
+	if !showAll {
+		fmt.Fprintln(tw, "Pool (O=Online)\tPool App id\t# stakers\tAmt Staked\tRwd Avail\tAPR %\tVote\tProp.\t")
+	} else {
+		fmt.Fprintln(tw, "Pool (O=Online)\tNode\tPool App id\t# stakers\tAmt Staked\tRwd Avail\tAPR %\tVote\tProp.\t")
+	}
+	for _, pool := range output.Pools {
+		onlineStr := " "
+		if pool.Online {
+			onlineStr = "O"
+		}
+		nodeStr := strconv.Itoa(pool.Node)
+		if uint64(pool.Node) == output.Node {
+			nodeStr = "*"
+		}
+		aprStr := strconv.FormatFloat(pool.APR, 'g', -1, 64)
 		if !showAll {
-			fmt.Fprintf(tw, "%d %s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t\n", i+1, onlineStr, pool.PoolAppId, pool.TotalStakers,
-				algo.FormattedAlgoAmount(pool.TotalAlgoStaked), algo.FormattedAlgoAmount(rewardAvail),
-				floatApr.String(),
-				voteData, partData,
-			)
+			fmt.Fprintf(tw, "%d %s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t\n", pool.Pool, onlineStr, pool.AppID, pool.Stakers,
+				algo.FormattedAlgoAmount(pool.Staked), algo.FormattedAlgoAmount(pool.RewardAvailable),
+				aprStr, sinceRound(pool.LastVoteRound), sinceRound(pool.LastProposalRound))
 		} else {
-			fmt.Fprintf(tw, "%d %s\t%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t\n", i+1, onlineStr, nodeStr, pool.PoolAppId, pool.TotalStakers,
-				algo.FormattedAlgoAmount(pool.TotalAlgoStaked), algo.FormattedAlgoAmount(rewardAvail),
-				floatApr.String(),
-				voteData, partData)
-
+			fmt.Fprintf(tw, "%d %s\t%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t\n", pool.Pool, onlineStr, nodeStr, pool.AppID, pool.Stakers,
+				algo.FormattedAlgoAmount(pool.Staked), algo.FormattedAlgoAmount(pool.RewardAvailable),
+				aprStr, sinceRound(pool.LastVoteRound), sinceRound(pool.LastProposalRound))
 		}
 	}
 	if !showAll {
-		fmt.Fprintf(tw, "TOTAL\t\t%d\t%s\t%s\t\n", state.TotalStakers, algo.FormattedAlgoAmount(state.TotalAlgoStaked),
-			algo.FormattedAlgoAmount(totalRewards))
+		fmt.Fprintf(tw, "TOTAL\t\t%d\t%s\t%s\t\n", output.TotalStakers, algo.FormattedAlgoAmount(output.TotalStaked),
+			algo.FormattedAlgoAmount(output.TotalRewardAvailable))
 	} else {
-		fmt.Fprintf(tw, "TOTAL\t\t\t%d\t%s\t%s\t\n", state.TotalStakers, algo.FormattedAlgoAmount(state.TotalAlgoStaked),
-			algo.FormattedAlgoAmount(totalRewards))
+		fmt.Fprintf(tw, "TOTAL\t\t\t%d\t%s\t%s\t\n", output.TotalStakers, algo.FormattedAlgoAmount(output.TotalStaked),
+			algo.FormattedAlgoAmount(output.TotalRewardAvailable))
 	}
 	tw.Flush()
-	fmt.Print(out.String())
-	return err
 }
 
 func PoolLedger(ctx context.Context, command *cli.Command) error {
@@ -259,14 +364,11 @@ func PoolLedger(ctx context.Context, command *cli.Command) error {
 	if command.Uint("validator") != 0 {
 		validatorId = command.Uint("validator")
 	}
-	config, err := App.retiClient.GetValidatorConfig(validatorId)
-	if err != nil {
-		return fmt.Errorf("get validator config err:%w", err)
-	}
-	pools, err := App.retiClient.GetValidatorPools(validatorId)
+	validator, err := App.retiClient.GetValidator(validatorId)
 	if err != nil {
-		return fmt.Errorf("unable to GetValidatorPools: %w", err)
+		return fmt.Errorf("unable to GetValidator: %w", err)
 	}
+	config, pools := validator.Config, validator.Pools
 
 	poolId := int(command.Uint("pool"))
 	if poolId == 0 {
@@ -275,16 +377,20 @@ func PoolLedger(ctx context.Context, command *cli.Command) error {
 	if poolId > len(pools) {
 		return fmt.Errorf("pool with id %d does not exist. See the pool list -all output for list", poolId)
 	}
-	params, _ := App.algoClient.SuggestedParams().Do(ctx)
+	params, _ := App.chain.SuggestedParams(ctx)
 
-	lastPayout, err := App.retiClient.GetLastPayout(pools[poolId-1].PoolAppId)
+	poolState, err := App.retiClient.GetPoolState(pools[poolId-1].PoolAppId)
+	if err != nil {
+		return fmt.Errorf("unable to GetPoolState: %w", err)
+	}
+	lastPayout := poolState.LastPayout
 	nextEpoch := lastPayout - (lastPayout % uint64(config.EpochRoundLength)) + uint64(config.EpochRoundLength)
 	adjustedEpoch := nextEpoch
 	if adjustedEpoch < uint64(params.FirstRoundValid) {
 		adjustedEpoch = uint64(params.FirstRoundValid) - (uint64(params.FirstRoundValid) % uint64(config.EpochRoundLength))
 	}
-	binRoundStart, _ := App.retiClient.GetBinRoundStart(pools[poolId-1].PoolAppId)
-	roundsPerDay, _ := App.retiClient.GetRoundsPerDay(pools[poolId-1].PoolAppId)
+	binRoundStart := poolState.BinRoundStart
+	roundsPerDay := poolState.RoundsPerDay
 
 	pctTimeInEpoch := func(stakerEntry uint64) int {
 		if adjustedEpoch == 0 {
@@ -308,55 +414,133 @@ func PoolLedger(ctx context.Context, command *cli.Command) error {
 		return fmt.Errorf("unable to GetLedgerForPool: %w", err)
 	}
 
-	rewardAvail := App.retiClient.PoolAvailableRewards(pools[poolId-1].PoolAppId, pools[poolId-1].TotalAlgoStaked)
-
-	out := new(strings.Builder)
-	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
-	fmt.Fprintln(tw, "Account\tStaked\tTotal Rewarded\tRwd Tokens\tPct\tEntry Round\t")
+	output := PoolLedgerOutput{
+		Pool:                    poolId,
+		AppID:                   pools[poolId-1].PoolAppId,
+		Stakers:                 []PoolLedgerEntry{},
+		RewardAvailable:         App.retiClient.PoolAvailableRewards(pools[poolId-1].PoolAppId, pools[poolId-1].TotalAlgoStaked),
+		LastEpochStart:          lastPayout - (lastPayout % uint64(config.EpochRoundLength)),
+		LastPayoutRound:         lastPayout,
+		CurrentRound:            uint64(params.FirstRoundValid),
+		NextPayoutRound:         nextEpoch,
+		NextPossiblePayoutRound: adjustedEpoch,
+		RoundsPerDay:            roundsPerDay,
+		EndOfDayRound:           binRoundStart + roundsPerDay,
+	}
 	for _, stakerData := range ledger {
 		if stakerData.Account == types.ZeroAddress {
 			continue
 		}
-		var stakerName = stakerData.Account.String()
+		entry := PoolLedgerEntry{
+			Account:            stakerData.Account.String(),
+			Staked:             stakerData.Balance,
+			TotalRewarded:      stakerData.TotalRewarded,
+			RewardTokenBalance: stakerData.RewardTokenBalance,
+			PctTimeInEpoch:     pctTimeInEpoch(stakerData.EntryRound),
+			EntryRound:         stakerData.EntryRound,
+		}
 		if command.Bool("nfd") {
 			if nfds, err := App.nfdOnChain.FindByAddress(context.Background(), stakerData.Account.String()); err == nil {
 				nfdInfo, err := App.nfdOnChain.GetNFD(context.Background(), nfds[0], false)
 				if err == nil {
-					stakerName = nfdInfo.Internal["name"]
+					entry.NFD = nfdInfo.Internal["name"]
 				}
 			}
-
 		}
-		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%d\t\n", stakerName, algo.FormattedAlgoAmount(stakerData.Balance), algo.FormattedAlgoAmount(stakerData.TotalRewarded),
-			stakerData.RewardTokenBalance, pctTimeInEpoch(stakerData.EntryRound), stakerData.EntryRound)
+		output.Stakers = append(output.Stakers, entry)
 	}
-	apr, _ := App.retiClient.GetAvgApr(pools[poolId-1].PoolAppId)
-	fmt.Fprintf(tw, "Reward Avail: %s\t\n", algo.FormattedAlgoAmount(rewardAvail))
-	stakeAccum, _ := App.retiClient.GetStakeAccum(pools[poolId-1].PoolAppId)
+	stakeAccum := new(big.Int).Set(poolState.StakeAccum)
 	stakeAccum.Div(stakeAccum, new(big.Int).SetUint64(roundsPerDay))
-	stakeAccum.Div(stakeAccum, big.NewInt(1e6))
-	fmt.Fprintf(tw, "Avg Stake: %s\t\n", stakeAccum.String())
-	floatApr, _, _ := new(big.Float).Parse(apr.String(), 10)
+	output.AvgStake = stakeAccum.Uint64()
+	floatApr, _, _ := new(big.Float).Parse(poolState.AvgApr.String(), 10)
 	floatApr.Quo(floatApr, big.NewFloat(100.0))
-	fmt.Fprintf(tw, "APR %%: %s\t\n", floatApr.String())
-	fmt.Fprintf(tw, "Last Epoch Start: %d\t\n", lastPayout-(lastPayout%uint64(config.EpochRoundLength)))
-	fmt.Fprintf(tw, "Last Payout: %d\t\n", lastPayout-(lastPayout%uint64(config.EpochRoundLength)))
-	fmt.Fprintf(tw, "Current round: %d\t\n", params.FirstRoundValid)
-	fmt.Fprintf(tw, "Next Planned Payout: %d\t\n", nextEpoch)
-	if adjustedEpoch != nextEpoch {
-		fmt.Fprintf(tw, "Next possible payout: %d\t\n", adjustedEpoch)
-	}
-	blockTime, _ := algo.CalcBlockTimes(ctx, App.algoClient, 10)
-	fmt.Fprintf(tw, "in approx: %s\t\n", (time.Duration(adjustedEpoch-uint64(params.FirstRoundValid)) * blockTime).Round(time.Second))
-	if nextEpoch < uint64(params.FirstRoundValid) {
-		fmt.Fprintf(tw, "Missed payout by: %d\t\n", uint64(params.FirstRoundValid)-nextEpoch)
-	}
-	fmt.Fprintf(tw, "Rounds Per Day: %d\t\n", roundsPerDay)
-	fmt.Fprintf(tw, "End Of Day block: %d\t\n", binRoundStart+roundsPerDay)
-	fmt.Fprintf(tw, "in approx: %s\t\n", (time.Duration(binRoundStart+roundsPerDay-uint64(params.FirstRoundValid)) * blockTime).Round(time.Second))
+	output.APR, _ = floatApr.Float64()
+	if nextEpoch < output.CurrentRound {
+		output.MissedPayoutBy = output.CurrentRound - nextEpoch
+	}
+	blockTime, _ := algo.CalcBlockTimes(ctx, App.chain, 10)
+	now := time.Now().UTC().Truncate(time.Second)
+	output.NextPayoutEstimate = now.Add((time.Duration(adjustedEpoch-output.CurrentRound) * blockTime).Round(time.Second))
+	output.EndOfDayEstimate = now.Add((time.Duration(output.EndOfDayRound-output.CurrentRound) * blockTime).Round(time.Second))
+
+	return printOutput(command, output, func(out io.Writer) {
+		printPoolLedger(out, output)
+	})
+}
+
+// PoolLedgerOutput is the pool ledger output - amounts are in microAlgo
+type PoolLedgerOutput struct {
+	Pool            int               `json:"pool" yaml:"pool"`
+	AppID           uint64            `json:"appId" yaml:"appId"`
+	Stakers         []PoolLedgerEntry `json:"stakers" yaml:"stakers"`
+	RewardAvailable uint64            `json:"rewardAvailable" yaml:"rewardAvailable"`
+	// AvgStake is the average stake over the current day
+	AvgStake        uint64  `json:"avgStake" yaml:"avgStake"`
+	APR             float64 `json:"apr" yaml:"apr"`
+	LastEpochStart  uint64  `json:"lastEpochStart" yaml:"lastEpochStart"`
+	LastPayoutRound uint64  `json:"lastPayoutRound" yaml:"lastPayoutRound"`
+	CurrentRound    uint64  `json:"currentRound" yaml:"currentRound"`
+	NextPayoutRound uint64  `json:"nextPayoutRound" yaml:"nextPayoutRound"`
+	// NextPossiblePayoutRound differs from NextPayoutRound if the planned payout was missed
+	NextPossiblePayoutRound uint64    `json:"nextPossiblePayoutRound" yaml:"nextPossiblePayoutRound"`
+	NextPayoutEstimate      time.Time `json:"nextPayoutEstimate" yaml:"nextPayoutEstimate"`
+	MissedPayoutBy          uint64    `json:"missedPayoutBy" yaml:"missedPayoutBy"`
+	RoundsPerDay            uint64    `json:"roundsPerDay" yaml:"roundsPerDay"`
+	EndOfDayRound           uint64    `json:"endOfDayRound" yaml:"endOfDayRound"`
+	EndOfDayEstimate        time.Time `json:"endOfDayEstimate" yaml:"endOfDayEstimate"`
+}
+
+type PoolLedgerEntry struct {
+	Account string `json:"account" yaml:"account"`
+	// NFD is the account's NFD name, if --nfd is specified and it has one
+	NFD                string `json:"nfd,omitempty" yaml:"nfd,omitempty"`
+	Staked             uint64 `json:"staked" yaml:"staked"`
+	TotalRewarded      uint64 `json:"totalRewarded" yaml:"totalRewarded"`
+	RewardTokenBalance uint64 `json:"rewardTokenBalance" yaml:"rewardTokenBalance"`
+	PctTimeInEpoch     int    `json:"pctTimeInEpoch" yaml:"pctTimeInEpoch"`
+	EntryRound         uint64 `json:"entryRound" yaml:"entryRound"`
+}
+
+func (p PoolLedgerOutput) csvRecords() [][]string {
+	records := [][]string{{"account", "nfd", "staked", "totalRewarded", "rewardTokenBalance", "pctTimeInEpoch", "entryRound"}}
+	for _, staker := range p.Stakers {
+		records = append(records, []string{staker.Account, staker.NFD, strconv.FormatUint(staker.Staked, 10),
+			strconv.FormatUint(staker.TotalRewarded, 10), strconv.FormatUint(staker.RewardTokenBalance, 10),
+			strconv.Itoa(staker.PctTimeInEpoch), strconv.FormatUint(staker.EntryRound, 10)})
+	}
+	return records
+}
+
+func printPoolLedger(out io.Writer, output PoolLedgerOutput) {
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Account\tStaked\tTotal Rewarded\tRwd Tokens\tPct\tEntry Round\t")
+	for _, staker := range output.Stakers {
+		stakerName := staker.Account
+		if staker.NFD != "" {
+			stakerName = staker.NFD
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%d\t\n", stakerName, algo.FormattedAlgoAmount(staker.Staked), algo.FormattedAlgoAmount(staker.TotalRewarded),
+			staker.RewardTokenBalance, staker.PctTimeInEpoch, staker.EntryRound)
+	}
+	fmt.Fprintf(tw, "Reward Avail: %s\t\n", algo.FormattedAlgoAmount(output.RewardAvailable))
+	fmt.Fprintf(tw, "Avg Stake: %d\t\n", output.AvgStake/1e6)
+	fmt.Fprintf(tw, "APR %%: %s\t\n", strconv.FormatFloat(output.APR, 'g', -1, 64))
+	fmt.Fprintf(tw, "Last Epoch Start: %d\t\n", output.LastEpochStart)
+	fmt.Fprintf(tw, "Last Payout: %d\t\n", output.LastPayoutRound)
+	fmt.Fprintf(tw, "Current round: %d\t\n", output.CurrentRound)
+	fmt.Fprintf(tw, "Next Planned Payout: %d\t\n", output.NextPayoutRound)
+	if output.NextPossiblePayoutRound != output.NextPayoutRound {
+		fmt.Fprintf(tw, "Next possible payout: %d\t\n", output.NextPossiblePayoutRound)
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	fmt.Fprintf(tw, "in approx: %s\t\n", output.NextPayoutEstimate.Sub(now))
+	if output.MissedPayoutBy != 0 {
+		fmt.Fprintf(tw, "Missed payout by: %d\t\n", output.MissedPayoutBy)
+	}
+	fmt.Fprintf(tw, "Rounds Per Day: %d\t\n", output.RoundsPerDay)
+	fmt.Fprintf(tw, "End Of Day block: %d\t\n", output.EndOfDayRound)
+	fmt.Fprintf(tw, "in approx: %s\t\n", output.EndOfDayEstimate.Sub(now))
 	tw.Flush()
-	slog.Info(out.String())
-	return nil
 }
 
 func PoolAdd(ctx context.Context, command *cli.Command) error {
@@ -410,6 +594,105 @@ func ClaimPool(ctx context.Context, command *cli.Command) error {
 	return nil
 }
 
+// MovePool moves a pool to another node with minimal downtime: the new node's participation key is generated
+// before the pool is reassigned (which takes it offline), the pool goes online with it straight after and the old
+// node's keys for it are only deleted once the new key is in effect - 320 rounds after going online.
+func MovePool(ctx context.Context, command *cli.Command) error {
+	var (
+		info   = App.retiClient.Info()
+		poolID = command.Uint("pool")
+		toNode = command.Uint("to-node")
+	)
+	poolAppID, err := getPoolAppID(poolID)
+	if err != nil {
+		return err
+	}
+	if toNode == 0 || toNode > uint64(len(info.NodePoolAssignments.Nodes)) {
+		return fmt.Errorf("node num:%d not valid, must be 1 - %d", toNode, len(info.NodePoolAssignments.Nodes))
+	}
+	var fromNode uint64
+	for nodeIdx, nodeConfigs := range info.NodePoolAssignments.Nodes {
+		if slices.Contains(nodeConfigs.PoolAppIds, poolAppID) {
+			fromNode = uint64(nodeIdx + 1)
+		}
+	}
+	if fromNode == toNode {
+		return fmt.Errorf("pool %d is already on node %d", poolID, toNode)
+	}
+	if numPools := len(info.NodePoolAssignments.Nodes[toNode-1].PoolAppIds); numPools >= info.Config.PoolsPerNode {
+		return fmt.Errorf("node %d already has %d pools - the validator allows at most %d pools per node", toNode, numPools, info.Config.PoolsPerNode)
+	}
+
+	network := command.String("network")
+	toChain := App.chain
+	if toNode != App.retiClient.NodeNum {
+		if command.String("to-algod") == "" {
+			return fmt.Errorf("--to-algod is required to generate the participation key on node %d", toNode)
+		}
+		toChain, err = App.newNodeChain(ctx, network, command.String("to-algod"), command.String("to-algod-token-secret"))
+		if err != nil {
+			return err
+		}
+	}
+	var fromChain algo.Chain
+	switch {
+	case command.String("from-algod") != "":
+		fromChain, err = App.newNodeChain(ctx, network, command.String("from-algod"), command.String("from-algod-token-secret"))
+		if err != nil {
+			return err
+		}
+	case fromNode == App.retiClient.NodeNum:
+		fromChain = App.chain
+	}
+
+	misc.Infof(App.logger, "generating participation key for pool %d on node %d", poolID, toNode)
+	key, err := generatePoolKey(ctx, toChain, poolID, 0, 0, 0)
+	if err != nil {
+		return err
+	}
+	err = App.retiClient.MovePoolToNode(poolAppID, toNode)
+	if err != nil {
+		return fmt.Errorf("error in call to MovePoolToNode, err:%w", err)
+	}
+	misc.Infof(App.logger, "pool %d moved from node %d to node %d", poolID, fromNode, toNode)
+	if err = activateKey(ctx, *key); err != nil {
+		return err
+	}
+	misc.Infof(App.logger, "pool %d online with participation key:%s on node %d", poolID, key.Id, toNode)
+
+	if fromChain == nil {
+		misc.Warnf(App.logger, "the old keys of pool %d on node %d weren't deleted (no --from-algod) - its daemon deletes them once they expire", poolID, fromNode)
+	} else {
+		// the effective first round is only known once the key is registered
+		newKeys, err := algo.GetParticipationKeys(ctx, toChain)
+		if err != nil {
+			return err
+		}
+		idx := slices.IndexFunc(newKeys[key.Address], func(newKey algo.ParticipationKey) bool { return newKey.Id == key.Id })
+		if idx == -1 {
+			return fmt.Errorf("participation key:%s not found on node %d", key.Id, toNode)
+		}
+		effectiveRound := newKeys[key.Address][idx].EffectiveFirstValid
+		misc.Infof(App.logger, "waiting for key:%s to take effect at round %d before removing the old keys of pool %d on node %d", key.Id, effectiveRound, poolID, fromNode)
+		if err = waitForRound(ctx, effectiveRound); err != nil {
+			return err
+		}
+		oldKeys, err := algo.GetParticipationKeys(ctx, fromChain)
+		if err != nil {
+			return err
+		}
+		for _, oldKey := range oldKeys[key.Address] {
+			if oldKey.Id == key.Id {
+				continue
+			}
+			if err = algo.DeleteParticipationKey(ctx, fromChain, App.logger, oldKey.Id); err != nil {
+				return err
+			}
+		}
+	}
+	return App.retiClient.LoadState(ctx)
+}
+
 func PayoutPool(ctx context.Context, command *cli.Command) error {
 	var info = App.retiClient.Info()
 	poolID := command.Uint("pool")
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/algorandfoundation/reti/internal/lib/misc"
+)
+
+func GetSecretsCmdOpts() *cli.Command {
+	fileFlags := []cli.Flag{
+		&cli.StringFlag{
+			Name:     "file",
+			Usage:    "The encrypted secrets file (used by the encfile secrets provider)",
+			Sources:  cli.EnvVars("RETI_SECRETS_ENCFILE"),
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:    "passfile",
+			Usage:   "File containing the secrets file passphrase.  Prompted for if not specified",
+			Sources: cli.EnvVars("RETI_SECRETS_ENCFILE_PASSFILE"),
+		},
+	}
+	return &cli.Command{
+		Name:     "secrets",
+		Usage:    "Manage the encrypted secrets file (ie: ALGO_ALGOD_TOKEN, ALGO_ALGOD_HEADERS or xxx_MNEMONIC values)",
+		Metadata: map[string]any{standaloneCommand: true},
+		Commands: []*cli.Command{
+			{
+				Name:     "set",
+				Usage:    "Set a secret, creating the secrets file if it doesn't exist",
+				Action:   SecretsSet,
+				Metadata: map[string]any{standaloneCommand: true},
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:     "key",
+						Usage:    "The secret's key - ie: ALGO_ALGOD_TOKEN",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "valuefile",
+						Usage: "File containing the secret's value.  Prompted for if not specified",
+					},
+				}, fileFlags...),
+			},
+			{
+				Name:     "list",
+				Usage:    "List the keys of the secrets in the file (values are never displayed)",
+				Action:   SecretsList,
+				Metadata: map[string]any{standaloneCommand: true},
+				Flags:    fileFlags,
+			},
+			{
+				Name:     "remove",
+				Usage:    "Remove a secret",
+				Action:   SecretsRemove,
+				Metadata: map[string]any{standaloneCommand: true},
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:     "key",
+						Usage:    "The secret's key",
+						Required: true,
+					},
+				}, fileFlags...),
+			},
+		},
+	}
+}
+
+func SecretsSet(ctx context.Context, command *cli.Command) error {
+	filename := command.String("file")
+	var (
+		passphrase []byte
+		secrets    = map[string]string{}
+		err        error
+	)
+	if _, statErr := os.Stat(filename); errors.Is(statErr, os.ErrNotExist) {
+		passphrase, err = readPassphrase(command.String("passfile"), "New secrets file passphrase", true)
+		if err != nil {
+			return err
+		}
+	} else {
+		passphrase, secrets, err = readSecretsFile(command)
+		if err != nil {
+			return err
+		}
+	}
+	var value []byte
+	if valueFile := command.String("valuefile"); valueFile != "" {
+		value, err = os.ReadFile(valueFile)
+	} else {
+		value, err = readSecret(fmt.Sprintf("Value for %s", command.String("key")))
+	}
+	if err != nil {
+		return err
+	}
+	secrets[command.String("key")] = strings.TrimRight(string(value), "\r\n")
+	if err := misc.WriteEncryptedSecrets(filename, passphrase, secrets); err != nil {
+		return err
+	}
+	misc.Infof(App.logger, "secret:%s set in %s", command.String("key"), filename)
+	return nil
+}
+
+func SecretsList(ctx context.Context, command *cli.Command) error {
+	_, secrets, err := readSecretsFile(command)
+	if err != nil {
+		return err
+	}
+	var keys []string
+	for key := range secrets {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		fmt.Println(key)
+	}
+	return nil
+}
+
+func SecretsRemove(ctx context.Context, command *cli.Command) error {
+	passphrase, secrets, err := readSecretsFile(command)
+	if err != nil {
+		return err
+	}
+	key := command.String("key")
+	if _, found := secrets[key]; !found {
+		return fmt.Errorf("secret:%s not found in %s", key, command.String("file"))
+	}
+	delete(secrets, key)
+	if err := misc.WriteEncryptedSecrets(command.String("file"), passphrase, secrets); err != nil {
+		return err
+	}
+	misc.Infof(App.logger, "secret:%s removed from %s", key, command.String("file"))
+	return nil
+}
+
+// readSecretsFile decrypts the secrets file, returning the passphrase used so it can be re-written
+func readSecretsFile(command *cli.Command) ([]byte, map[string]string, error) {
+	filename := command.String("file")
+	passphrase, err := readPassphrase(command.String("passfile"), fmt.Sprintf("Passphrase for secrets file %s", filename), false)
+	if err != nil {
+		return nil, nil, err
+	}
+	secrets, err := misc.ReadEncryptedSecrets(filename, passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	return passphrase, secrets, nil
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/algorandfoundation/reti/internal/lib/algo"
+	"github.com/algorandfoundation/reti/internal/lib/misc"
+	"github.com/algorandfoundation/reti/internal/lib/reti"
+)
+
+// defaultSignerMethods are the staking pool methods the daemon calls as the manager
+var defaultSignerMethods = []string{"goOnline", "goOffline", "epochBalanceUpdate", "gas", "updateAlgodVer"}
+
+// SignerPolicySpec is the yaml policy file of the signing service.  Algo amounts are in microAlgo.
+type SignerPolicySpec struct {
+	// AppIDs are the staking pool app ids which may be called (and paid - ie: the go online fee)
+	AppIDs []uint64 `yaml:"appIds"`
+	// Methods are the staking pool method names which may be called - defaulting to those the daemon uses
+	Methods    []string `yaml:"methods,omitempty"`
+	MaxFee     uint64   `yaml:"maxFee,omitempty"`
+	MaxPayment uint64   `yaml:"maxPayment,omitempty"`
+}
+
+func GetSignerCmdOpts() *cli.Command {
+	return &cli.Command{
+		Name:     "signer",
+		Usage:    "Run a signing service for the daemon (see --remote-signer), signing only what its policy allows",
+		Action:   runSigner,
+		Metadata: map[string]any{offlineCommand: true},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "listen",
+				Usage:    "Address to listen on - unix:///path/to/socket (recommended - only the daemon's user should have access) or a loopback 127.0.0.1:port (requiring a token)",
+				Sources:  cli.EnvVars("RETI_SIGNER_LISTEN"),
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "token-secret",
+				Usage: "Secret (env var or secrets provider key) holding the bearer token clients must send (see --remote-signer-token-secret) - required on tcp, optional on a unix socket",
+				Value: "RETI_SIGNER_TOKEN",
+			},
+			&cli.StringFlag{
+				Name:     "policy",
+				Usage:    "Policy file (yaml) - appIds (pool app ids), methods, maxFee and maxPayment (in microAlgo)",
+				Sources:  cli.EnvVars("RETI_SIGNER_POLICY"),
+				Required: true,
+			},
+		},
+	}
+}
+
+// LoadSignerPolicy reads the signing service policy file, resolving the method names to selectors
+func LoadSignerPolicy(filename string) (*algo.SignPolicy, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	spec := SignerPolicySpec{
+		Methods:    defaultSignerMethods,
+		MaxFee:     250_000,
+		MaxPayment: 2_000_000,
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("invalid signer policy %s: %w", filename, err)
+	}
+	if len(spec.AppIDs) == 0 {
+		return nil, fmt.Errorf("signer policy %s must specify the pool appIds which can be called", filename)
+	}
+	selectors, err := reti.PoolMethodSelectors(spec.Methods)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signer policy %s: %w", filename, err)
+	}
+	return &algo.SignPolicy{
+		AppIDs:     spec.AppIDs,
+		Selectors:  selectors,
+		MaxFee:     spec.MaxFee,
+		MaxPayment: spec.MaxPayment,
+	}, nil
+}
+
+func runSigner(ctx context.Context, command *cli.Command) error {
+	policy, err := LoadSignerPolicy(command.String("policy"))
+	if err != nil {
+		return err
+	}
+	token := misc.GetSecret(command.String("token-secret"))
+	listener, err := signerListener(command.String("listen"), token != "")
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{
+		Handler:           algo.NewSignerHandler(App.logger, App.signer, policy, token),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errc := make(chan error, 1)
+	go func() {
+		misc.Infof(App.logger, "signer listening on %s, allowing app ids:%v, max fee:%s, max payment:%s", command.String("listen"),
+			policy.AppIDs, algo.FormattedAlgoAmount(policy.MaxFee), algo.FormattedAlgoAmount(policy.MaxPayment))
+		errc <- srv.Serve(listener)
+	}()
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
+		errc <- fmt.Errorf("%s", <-c)
+	}()
+
+	err = <-errc
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	misc.Infof(App.logger, "exiting (%v)", err)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	return srv.Shutdown(ctx)
+}
+
+// signerListener listens on a unix socket (replacing any stale socket, and only accessible by the current user) or
+// a loopback tcp address - which any local user can connect to, so requires the clients to authenticate (hasToken).
+// The socket is created in a private directory and only moved into place once it's restricted to the current user,
+// so it's never accessible to others whatever the umask.
+func signerListener(address string, hasToken bool) (net.Listener, error) {
+	socketPath, isUnix := strings.CutPrefix(address, "unix://")
+	if !isUnix {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid listen address:%s: %w", address, err)
+		}
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return nil, fmt.Errorf("listen address:%s isn't a loopback address - use a unix socket or 127.0.0.1:port", address)
+		}
+		if !hasToken {
+			return nil, fmt.Errorf("listening on tcp address:%s requires a token (see --token-secret) - or use a unix socket", address)
+		}
+		return net.Listen("tcp", address)
+	}
+	if err := os.Remove(socketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	privateDir, err := os.MkdirTemp(filepath.Dir(socketPath), ".signer-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(privateDir)
+	privatePath := filepath.Join(privateDir, "socket")
+	listener, err := net.Listen("unix", privatePath)
+	if err != nil {
+		return nil, err
+	}
+	// the socket is unlinked at its final path when closed instead
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	if err = os.Chmod(privatePath, 0o600); err == nil {
+		err = os.Rename(privatePath, socketPath)
+	}
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return &unixSocketListener{Listener: listener, path: socketPath}, nil
+}
+
+// unixSocketListener removes the socket (which was moved into place after listening) when closed
+type unixSocketListener struct {
+	net.Listener
+	path string
+}
+
+func (l *unixSocketListener) Close() error {
+	err := l.Listener.Close()
+	os.Remove(l.path)
+	return err
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSignerListener(t *testing.T) {
+	if _, err := signerListener("127.0.0.1:0", false); err == nil {
+		t.Fatal("tcp listener without a token was allowed")
+	}
+	if _, err := signerListener("0.0.0.0:0", true); err == nil {
+		t.Fatal("non-loopback listener was allowed")
+	}
+	listener, err := signerListener("127.0.0.1:0", true)
+	if err != nil {
+		t.Fatalf("tcp listener with a token: %v", err)
+	}
+	listener.Close()
+
+	socketPath := filepath.Join(t.TempDir(), "signer.sock")
+	if err := os.WriteFile(socketPath, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// the stale socket is replaced, by one only the current user can access
+	listener, err = signerListener("unix://"+socketPath, false)
+	if err != nil {
+		t.Fatalf("unix listener: %v", err)
+	}
+	info, err := os.Stat(socketPath)
+	if err != nil || info.Mode()&os.ModeSocket == 0 || info.Mode().Perm() != 0o600 {
+		t.Fatalf("socket mode %v, err:%v", info.Mode(), err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(socketPath)); len(entries) != 1 {
+		t.Fatalf("expected only the socket left in its directory, found %d entries", len(entries))
+	}
+	listener.Close()
+	if _, err := os.Stat(socketPath); !os.IsNotExist(err) {
+		t.Fatalf("socket wasn't removed on close, err:%v", err)
+	}
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+	"github.com/urfave/cli/v3"
+
+	"github.com/algorandfoundation/reti/internal/lib/algo"
+	"github.com/algorandfoundation/reti/internal/lib/misc"
+	"github.com/algorandfoundation/reti/internal/lib/reti"
+)
+
+func GetStakerCmdOpts() *cli.Command {
+	accountFlag := &cli.StringFlag{
+		Name:     "account",
+		Usage:    "The staker address - its key must be in the keystore (or env mnemonics)",
+		Required: true,
+	}
+	validatorFlag := &cli.UintFlag{
+		Name:  "validator",
+		Usage: "Validator id - defaults to our validator",
+	}
+	poolFlag := &cli.UintFlag{
+		Name:     "pool",
+		Usage:    "Pool id (the number in 'pool list')",
+		Required: true,
+	}
+	return &cli.Command{
+		Name:  "staker",
+		Usage: "Stake in, unstake from and claim reward tokens from validator pools - as a staker with a local key",
+		Commands: []*cli.Command{
+			{
+				Name:   "add",
+				Usage:  "Add stake to a validator - the validator picks the pool",
+				Action: StakerAdd,
+				Flags: []cli.Flag{
+					accountFlag,
+					validatorFlag,
+					&cli.StringFlag{
+						Name:     "amount",
+						Usage:    "ALGO amount to stake, ie: 1000 or 1000.5 - first time stakers also pay the staker MBR",
+						Required: true,
+					},
+					&cli.UintFlag{
+						Name:  "gating-asset",
+						Usage: "Asset id held by the staker which meets the validator's entry gating (if gated by asset)",
+					},
+				},
+			},
+			{
+				Name:   "remove",
+				Usage:  "Remove stake from a pool",
+				Action: StakerRemove,
+				Flags: []cli.Flag{
+					accountFlag,
+					validatorFlag,
+					poolFlag,
+					&cli.StringFlag{
+						Name:  "amount",
+						Usage: "ALGO amount to unstake - all of it if not specified",
+					},
+				},
+			},
+			{
+				Name:   "claim",
+				Usage:  "Claim the reward tokens earned in a pool",
+				Action: StakerClaim,
+				Flags: []cli.Flag{
+					accountFlag,
+					validatorFlag,
+					poolFlag,
+				},
+			},
+			{
+				Name:     "info",
+				Usage:    "Display an account's stake in every pool it's in, from the pool ledgers",
+				Action:   StakerInfo,
+				Metadata: map[string]any{readCommand: true},
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "account",
+						Usage:    "The staker address",
+						Required: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// getStakerAccount returns the --account staker address, which must have a local key
+func getStakerAccount(command *cli.Command) (types.Address, error) {
+	staker, err := types.DecodeAddress(command.String("account"))
+	if err != nil {
+		return types.Address{}, fmt.Errorf("invalid account:%s, err:%w", command.String("account"), err)
+	}
+	if !App.signer.HasAccount(staker.String()) {
+		return types.Address{}, fmt.Errorf("no local key for staker account:%s", staker)
+	}
+	return staker, nil
+}
+
+// getStakerValidator returns the --validator validator id, or our validator's
+func getStakerValidator(command *cli.Command) (uint64, error) {
+	validatorID := command.Uint("validator")
+	if validatorID == 0 {
+		validatorID = App.retiValidatorID
+	}
+	if validatorID == 0 {
+		return 0, errors.New("validator not configured - specify --validator")
+	}
+	return validatorID, nil
+}
+
+// getStakerPoolKey returns the key of the --pool pool of the --validator validator
+func getStakerPoolKey(command *cli.Command) (reti.ValidatorPoolKey, error) {
+	validatorID, err := getStakerValidator(command)
+	if err != nil {
+		return reti.ValidatorPoolKey{}, err
+	}
+	pools, err := App.retiClient.GetValidatorPools(validatorID)
+	if err != nil {
+		return reti.ValidatorPoolKey{}, fmt.Errorf("unable to get pools of validator:%d, err:%w", validatorID, err)
+	}
+	poolID := command.Uint("pool")
+	if poolID == 0 || poolID > uint64(len(pools)) {
+		return reti.ValidatorPoolKey{}, fmt.Errorf("pool num:%d not found for validator:%d", poolID, validatorID)
+	}
+	return reti.ValidatorPoolKey{ID: validatorID, PoolId: poolID, PoolAppId: pools[poolID-1].PoolAppId}, nil
+}
+
+func StakerAdd(ctx context.Context, command *cli.Command) error {
+	staker, err := getStakerAccount(command)
+	if err != nil {
+		return err
+	}
+	validatorID, err := getStakerValidator(command)
+	if err != nil {
+		return err
+	}
+	amount, err := algo.ParseAlgoAmount(command.String("amount"))
+	if err != nil {
+		return err
+	}
+	poolKey, err := App.retiClient.AddStake(validatorID, staker, amount, command.Uint("gating-asset"))
+	if err != nil {
+		return fmt.Errorf("unable to add stake, err:%w", err)
+	}
+	misc.Infof(App.logger, "%s ALGO staked by %s in pool %d [app id:%d] of validator:%d", algo.FormattedAlgoAmount(amount),
+		staker, poolKey.PoolId, poolKey.PoolAppId, validatorID)
+	return nil
+}
+
+func StakerRemove(ctx context.Context, command *cli.Command) error {
+	staker, err := getStakerAccount(command)
+	if err != nil {
+		return err
+	}
+	poolKey, err := getStakerPoolKey(command)
+	if err != nil {
+		return err
+	}
+	var amount uint64
+	if command.String("amount") != "" {
+		amount, err = algo.ParseAlgoAmount(command.String("amount"))
+		if err != nil {
+			return err
+		}
+		if amount == 0 {
+			return errors.New("amount must be more than 0 - leave it out to unstake everything")
+		}
+	}
+	err = App.retiClient.RemoveStake(poolKey, staker, staker, amount)
+	if err != nil {
+		return fmt.Errorf("unable to remove stake, err:%w", err)
+	}
+	if amount == 0 {
+		misc.Infof(App.logger, "all stake of %s removed from pool %d [app id:%d] of validator:%d", staker, poolKey.PoolId, poolKey.PoolAppId, poolKey.ID)
+	} else {
+		misc.Infof(App.logger, "%s ALGO of %s removed from pool %d [app id:%d] of validator:%d", algo.FormattedAlgoAmount(amount),
+			staker, poolKey.PoolId, poolKey.PoolAppId, poolKey.ID)
+	}
+	return nil
+}
+
+func StakerClaim(ctx context.Context, command *cli.Command) error {
+	staker, err := getStakerAccount(command)
+	if err != nil {
+		return err
+	}
+	poolKey, err := getStakerPoolKey(command)
+	if err != nil {
+		return err
+	}
+	err = App.retiClient.ClaimTokens(poolKey, staker)
+	if err != nil {
+		return fmt.Errorf("unable to claim reward tokens, err:%w", err)
+	}
+	misc.Infof(App.logger, "reward tokens of %s claimed from pool %d [app id:%d] of validator:%d", staker, poolKey.PoolId, poolKey.PoolAppId, poolKey.ID)
+	return nil
+}
+
+// StakerInfoOutput is the stake of an account in a pool, from the pool's ledger - amounts are in microAlgo (or reward
+// token base units)
+type StakerInfoOutput struct {
+	ValidatorID        uint64 `json:"validatorId" yaml:"validatorId"`
+	Pool               uint64 `json:"pool" yaml:"pool"`
+	AppID              uint64 `json:"appId" yaml:"appId"`
+	Balance            uint64 `json:"balance" yaml:"balance"`
+	TotalRewarded      uint64 `json:"totalRewarded" yaml:"totalRewarded"`
+	RewardTokenBalance uint64 `json:"rewardTokenBalance" yaml:"rewardTokenBalance"`
+	EntryRound         uint64 `json:"entryRound" yaml:"entryRound"`
+}
+
+type StakerInfoOutputs []StakerInfoOutput
+
+func (s StakerInfoOutputs) csvRecords() [][]string {
+	records := [][]string{{"validatorId", "pool", "appId", "balance", "totalRewarded", "rewardTokenBalance", "entryRound"}}
+	for _, pool := range s {
+		records = append(records, []string{strconv.FormatUint(pool.ValidatorID, 10), strconv.FormatUint(pool.Pool, 10),
+			strconv.FormatUint(pool.AppID, 10), strconv.FormatUint(pool.Balance, 10), strconv.FormatUint(pool.TotalRewarded, 10),
+			strconv.FormatUint(pool.RewardTokenBalance, 10), strconv.FormatUint(pool.EntryRound, 10)})
+	}
+	return records
+}
+
+func StakerInfo(ctx context.Context, command *cli.Command) error {
+	staker, err := types.DecodeAddress(command.String("account"))
+	if err != nil {
+		return fmt.Errorf("invalid account:%s, err:%w", command.String("account"), err)
+	}
+	poolKeys, err := App.retiClient.GetStakedPoolsForAccount(staker)
+	if err != nil {
+		return err
+	}
+	output := StakerInfoOutputs{}
+	for _, key := range poolKeys {
+		ledger, err := App.retiClient.GetLedgerForPool(key.PoolAppId)
+		if err != nil {
+			return fmt.Errorf("unable to get ledger for pool app id:%d, err:%w", key.PoolAppId, err)
+		}
+		for _, stakedInfo := range ledger {
+			if stakedInfo.Account != staker {
+				continue
+			}
+			output = append(output, StakerInfoOutput{
+				ValidatorID:        key.ID,
+				Pool:               key.PoolId,
+				AppID:              key.PoolAppId,
+				Balance:            stakedInfo.Balance,
+				TotalRewarded:      stakedInfo.TotalRewarded,
+				RewardTokenBalance: stakedInfo.RewardTokenBalance,
+				EntryRound:         stakedInfo.EntryRound,
+			})
+		}
+	}
+	return printOutput(command, output, func(out io.Writer) {
+		if len(output) == 0 {
+			fmt.Fprintf(out, "%s isn't staked in any pools\n", staker)
+			return
+		}
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(tw, "Validator ID\tPool ID\tApp ID\tBalance\tTotal Rewarded\tReward Tokens\tEntry Round\t")
+		for _, pool := range output {
+			fmt.Fprintf(tw, "%d\t%d\t%d\t%s\t%s\t%d\t%d\t\n", pool.ValidatorID, pool.Pool, pool.AppID,
+				algo.FormattedAlgoAmount(pool.Balance), algo.FormattedAlgoAmount(pool.TotalRewarded), pool.RewardTokenBalance,
+				pool.EntryRound)
+		}
+		tw.Flush()
+	})
+}
@@ -23,8 +23,7 @@ func GetDaemonCmdOpts() *cli.Command {
 		Flags: []cli.Flag{
 			&cli.IntFlag{
 				Name:     "port",
-				Usage:    "port to expose prometheus /metrics and /ready endpoint",
-				Value:    6260,
+				Usage:    "port to expose prometheus /metrics and /ready endpoint (default daemon.port of the config file, or 6260)",
 				Required: false,
 			},
 		},
@@ -50,7 +49,11 @@ func runAsDaemon(ctx context.Context, cmd *cli.Command) error {
 	}()
 	ctx, cancel := context.WithCancel(context.Background())
 
-	daemon := newDaemon(int(cmd.Int("port")))
+	port := App.config.Daemon.Port
+	if cmd.IsSet("port") {
+		port = int(cmd.Int("port"))
+	}
+	daemon := newDaemon(port, App.config.Daemon)
 	daemon.start(ctx, &wg, cancel)
 
 	select {
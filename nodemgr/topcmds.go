@@ -0,0 +1,381 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
+
+	"github.com/algorandfoundation/reti/internal/lib/algo"
+)
+
+// topMaxActions is how many of the daemon's latest actions top shows
+const topMaxActions = 10
+
+func GetTopCmdOpts() *cli.Command {
+	return &cli.Command{
+		Name:   "top",
+		Usage:  "Full-screen live view of this node's pools and the daemon's latest actions, refreshed every round",
+		Before: checkConfigured,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "daemon",
+				Usage: "URL of the daemon's HTTP server, for its latest actions - defaults to this host on the daemon port of the config",
+			},
+		},
+		Action:   Top,
+		Metadata: map[string]any{readCommand: true},
+	}
+}
+
+// topSnapshot is the data shown by top as of a round
+type topSnapshot struct {
+	pools      PoolListOutput
+	nextEpoch  uint64
+	actions    []DaemonAction
+	actionsErr error
+	err        error
+}
+
+// topView is the state of the top screen - only touched by the Top loop
+type topView struct {
+	topSnapshot
+	blockTime time.Duration
+	daemonURL string
+	// selected is the index (in pools) of the pool manual actions apply to
+	selected int
+	// prompt is the confirmation question shown while confirmed awaits a y/n answer
+	prompt    string
+	confirmed func()
+	// busy is set while a manual action runs, status is the outcome of the latest one
+	busy   bool
+	status string
+}
+
+func Top(ctx context.Context, command *cli.Command) error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return errors.New("top needs a terminal")
+	}
+	daemonURL := command.String("daemon")
+	if daemonURL == "" {
+		daemonURL = fmt.Sprintf("http://localhost:%d", App.config.Daemon.Port)
+	}
+	blockTime, err := algo.CalcBlockTimes(ctx, App.chain, 20)
+	if err != nil {
+		return err
+	}
+
+	oldState, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return fmt.Errorf("unable to put terminal in raw mode: %w", err)
+	}
+	// logs would be drawn over the screen - the outcome of manual actions is shown in the status line instead
+	logOutput.set(io.Discard)
+	// alternate screen, cursor hidden
+	fmt.Print("\x1b[?1049h\x1b[?25l")
+	defer func() {
+		fmt.Print("\x1b[?25h\x1b[?1049l")
+		term.Restore(int(os.Stdin.Fd()), oldState)
+		logOutput.set(os.Stdout)
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		snapshots = make(chan topSnapshot)
+		keys      = make(chan string)
+		results   = make(chan string, 1)
+		view      = &topView{blockTime: blockTime, daemonURL: daemonURL, status: "loading..."}
+	)
+	go watchRounds(ctx, daemonURL, snapshots)
+	go readKeys(ctx, keys)
+
+	for {
+		view.render(os.Stdout)
+		select {
+		case <-ctx.Done():
+			return nil
+		case snapshot := <-snapshots:
+			view.topSnapshot = snapshot
+			view.selected = min(view.selected, max(len(snapshot.pools.Pools)-1, 0))
+			if view.status == "loading..." {
+				view.status = ""
+			}
+		case result := <-results:
+			view.busy = false
+			view.status = result
+		case key := <-keys:
+			if quit := view.handleKey(ctx, key, results); quit {
+				return nil
+			}
+		}
+	}
+}
+
+// watchRounds sends a new snapshot every round until ctx is done
+func watchRounds(ctx context.Context, daemonURL string, snapshots chan<- topSnapshot) {
+	for {
+		snapshot := getTopSnapshot(ctx, daemonURL)
+		select {
+		case <-ctx.Done():
+			return
+		case snapshots <- snapshot:
+		}
+		if snapshot.err == nil {
+			_, snapshot.err = App.chain.StatusAfterBlock(ctx, snapshot.pools.CurrentRound)
+		}
+		if snapshot.err != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+		}
+	}
+}
+
+func getTopSnapshot(ctx context.Context, daemonURL string) topSnapshot {
+	var snapshot topSnapshot
+	snapshot.pools, snapshot.err = getPoolList(ctx, false, false)
+	if snapshot.err != nil {
+		return snapshot
+	}
+	snapshot.nextEpoch = nextEpoch(snapshot.pools.CurrentRound, uint64(App.retiClient.Info().Config.EpochRoundLength))
+	snapshot.actions, snapshot.actionsErr = getDaemonActions(ctx, daemonURL)
+	return snapshot
+}
+
+// getDaemonActions fetches the latest actions of the daemon from its /actions endpoint
+func getDaemonActions(ctx context.Context, daemonURL string) ([]DaemonAction, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(daemonURL, "/")+"/actions", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %s", resp.Status)
+	}
+	var actions daemonActionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&actions); err != nil {
+		return nil, err
+	}
+	return actions.Actions, nil
+}
+
+// readKeys sends the keys pressed until ctx is done - arrow keys as "up"/"down"
+func readKeys(ctx context.Context, keys chan<- string) {
+	buf := make([]byte, 16)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return
+		}
+		var key string
+		switch input := string(buf[:n]); input {
+		case "\x1b[A", "k":
+			key = "up"
+		case "\x1b[B", "j":
+			key = "down"
+		case "\x03":
+			key = "q"
+		default:
+			key = strings.ToLower(input)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case keys <- key:
+		}
+	}
+}
+
+// handleKey acts on a key press - returning true if top should exit
+func (v *topView) handleKey(ctx context.Context, key string, results chan<- string) bool {
+	if v.prompt != "" {
+		if key == "y" {
+			v.confirmed()
+		}
+		v.prompt, v.confirmed = "", nil
+		return false
+	}
+	switch key {
+	case "q", "\x1b":
+		return true
+	case "up":
+		v.selected = max(v.selected-1, 0)
+	case "down":
+		v.selected = min(v.selected+1, max(len(v.pools.Pools)-1, 0))
+	case "p", "r":
+		if len(v.pools.Pools) == 0 {
+			return false
+		}
+		if App.readOnly {
+			v.status = "actions aren't available in read-only mode"
+			return false
+		}
+		if v.busy {
+			v.status = "wait for the running action to finish"
+			return false
+		}
+		pool := v.pools.Pools[v.selected]
+		if key == "p" {
+			v.prompt = fmt.Sprintf("Send epoch payout for pool %d [app id:%d]? [y/N]", pool.Pool, pool.AppID)
+			v.confirmed = func() {
+				v.run(results, fmt.Sprintf("sending payout for pool %d...", pool.Pool), func() string {
+					signerAddr, _ := types.DecodeAddress(App.retiClient.Info().Config.Manager)
+					if err := App.retiClient.EpochBalanceUpdate(pool.Pool, pool.AppID, signerAddr); err != nil {
+						return fmt.Sprintf("payout for pool %d failed: %v", pool.Pool, err)
+					}
+					return fmt.Sprintf("payout sent for pool %d", pool.Pool)
+				})
+			}
+		} else {
+			v.prompt = fmt.Sprintf("Generate a new participation key for pool %d [app id:%d] and go online with it? [y/N]", pool.Pool, pool.AppID)
+			v.confirmed = func() {
+				v.run(results, fmt.Sprintf("rotating participation key of pool %d (key generation, then 320 rounds until it takes effect)...", pool.Pool), func() string {
+					key, err := rotatePoolKey(ctx, uint64(pool.Pool))
+					if err != nil {
+						return fmt.Sprintf("key rotation for pool %d failed: %v", pool.Pool, err)
+					}
+					return fmt.Sprintf("pool %d online with new participation key:%s", pool.Pool, key.Id)
+				})
+			}
+		}
+	}
+	return false
+}
+
+// run performs a manual action in the background, sending its outcome to results
+func (v *topView) run(results chan<- string, status string, action func() string) {
+	v.busy = true
+	v.status = status
+	go func() {
+		results <- action()
+	}()
+}
+
+func (v *topView) render(out io.Writer) {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		width, height = 120, 40
+	}
+	var screen bytes.Buffer
+	info := App.retiClient.Info()
+	fmt.Fprintf(&screen, "Réti validator %d, node %d - round %d, avg block time %v\n", info.Config.ID, App.retiClient.NodeNum,
+		v.pools.CurrentRound, v.blockTime.Round(10*time.Millisecond))
+	if v.nextEpoch != 0 {
+		fmt.Fprintf(&screen, "Next epoch payout at round %d (in %s)\n", v.nextEpoch, v.countdown(v.nextEpoch))
+	}
+	if v.err != nil {
+		fmt.Fprintf(&screen, "refresh failed, retrying: %v\n", v.err)
+	}
+	fmt.Fprintln(&screen)
+
+	tw := tabwriter.NewWriter(&screen, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "  Pool (O=Online)\tPool App id\t# stakers\tAmt Staked\tRwd Avail\tAPR %\tVote\tProp.\tKey expires\t")
+	for i, pool := range v.pools.Pools {
+		selected, online := " ", " "
+		if i == v.selected {
+			selected = ">"
+		}
+		if pool.Online {
+			online = "O"
+		}
+		fmt.Fprintf(tw, "%s %d %s\t%d\t%d\t%s\t%s\t%.2f\t%s\t%s\t%s\t\n", selected, pool.Pool, online, pool.AppID, pool.Stakers,
+			algo.FormattedAlgoAmount(pool.Staked), algo.FormattedAlgoAmount(pool.RewardAvailable), pool.APR,
+			v.sinceRound(pool.LastVoteRound), v.sinceRound(pool.LastProposalRound), v.keyExpiry(pool.KeyLastValid))
+	}
+	fmt.Fprintf(tw, "TOTAL\t\t%d\t%s\t%s\t\n", v.pools.TotalStakers, algo.FormattedAlgoAmount(v.pools.TotalStaked),
+		algo.FormattedAlgoAmount(v.pools.TotalRewardAvailable))
+	tw.Flush()
+
+	fmt.Fprintln(&screen)
+	fmt.Fprintf(&screen, "Latest daemon actions (%s)\n", v.daemonURL)
+	if v.actionsErr != nil {
+		fmt.Fprintf(&screen, "  daemon not reachable: %v\n", v.actionsErr)
+	} else if len(v.actions) == 0 {
+		fmt.Fprintln(&screen, "  none yet")
+	}
+	actions := v.actions[max(len(v.actions)-topMaxActions, 0):]
+	for _, action := range slices.Backward(actions) {
+		fmt.Fprintf(&screen, "  %s  %-8s %s\n", action.Time.Local().Format(time.DateTime), action.Kind, action.Message)
+	}
+
+	fmt.Fprintln(&screen)
+	switch {
+	case v.prompt != "":
+		fmt.Fprintln(&screen, v.prompt)
+	default:
+		fmt.Fprintln(&screen, "up/down select pool   p payout   r rotate key   q quit")
+	}
+	if v.status != "" {
+		fmt.Fprintln(&screen, v.status)
+	}
+
+	// clear and redraw - lines cut to the terminal width (wrapping would scroll the screen), \r\n as the terminal
+	// is raw
+	lines := strings.Split(strings.TrimSuffix(screen.String(), "\n"), "\n")
+	lines = lines[:min(len(lines), height)]
+	for i, line := range lines {
+		if runes := []rune(line); len(runes) > width {
+			lines[i] = string(runes[:width])
+		}
+	}
+	fmt.Fprint(out, "\x1b[H\x1b[2J"+strings.Join(lines, "\r\n"))
+}
+
+func (v *topView) sinceRound(round uint64) string {
+	switch {
+	case round == 0:
+		return ""
+	case v.pools.CurrentRound <= round:
+		return "latest"
+	default:
+		return fmt.Sprintf("-%d", v.pools.CurrentRound-round)
+	}
+}
+
+func (v *topView) keyExpiry(lastValid uint64) string {
+	switch {
+	case lastValid == 0:
+		return "no key"
+	case lastValid <= v.pools.CurrentRound:
+		return "EXPIRED"
+	default:
+		return v.countdown(lastValid)
+	}
+}
+
+// countdown is the approximate time until round, at the average block time
+func (v *topView) countdown(round uint64) string {
+	if round <= v.pools.CurrentRound {
+		return "now"
+	}
+	remaining := time.Duration(round-v.pools.CurrentRound) * v.blockTime
+	switch {
+	case remaining >= 24*time.Hour:
+		return fmt.Sprintf("%dd %dh", remaining/(24*time.Hour), remaining%(24*time.Hour)/time.Hour)
+	case remaining >= time.Hour:
+		return fmt.Sprintf("%dh %dm", remaining/time.Hour, remaining%time.Hour/time.Minute)
+	default:
+		return remaining.Round(time.Second).String()
+	}
+}
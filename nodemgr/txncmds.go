@@ -0,0 +1,333 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/algorand/go-algorand-sdk/v2/abi"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+	"github.com/urfave/cli/v3"
+
+	"github.com/algorandfoundation/reti/internal/lib/algo"
+	"github.com/algorandfoundation/reti/internal/lib/misc"
+	"github.com/algorandfoundation/reti/internal/lib/reti"
+)
+
+// offlineCommand is the Metadata key marking commands which don't need a connection to algod (or any validator
+// state) - so they can be run on an air-gapped machine
+const offlineCommand = "offline"
+
+// standaloneCommand is the Metadata key marking commands which manage local files themselves (ie: the keystore) so
+// don't need algod, secret providers or a signer
+const standaloneCommand = "standalone"
+
+// readCommand is the Metadata key marking commands which only read - the only commands allowed in read-only mode
+const readCommand = "read"
+
+func GetTxnCmdOpts() *cli.Command {
+	return &cli.Command{
+		Name:  "txn",
+		Usage: "Sign and submit transactions exported via --export-unsigned",
+		Commands: []*cli.Command{
+			{
+				Name:     "sign",
+				Usage:    "Sign the exported transactions for every sender with local keys present.  Doesn't need network access",
+				Action:   TxnSign,
+				Metadata: map[string]any{offlineCommand: true},
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "in",
+						Usage:    "The transaction file to sign",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "out",
+						Usage: "The file to write the signed transactions to - defaults to overwriting the input file",
+					},
+					&cli.StringSliceFlag{
+						Name:  "authaddr",
+						Usage: "sender=authaddr - the auth address of a rekeyed sender, as it can't be looked up offline",
+					},
+					&cli.BoolFlag{
+						Name:  "yes",
+						Usage: "Sign without prompting for confirmation of the transactions",
+					},
+				},
+			},
+			{
+				Name:     "merge",
+				Usage:    "Combine the (partial multisig) signatures from files signed on different machines",
+				Action:   TxnMerge,
+				Metadata: map[string]any{offlineCommand: true},
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:     "in",
+						Usage:    "The signed transaction files to merge (specify multiple times)",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:     "out",
+						Usage:    "The file to write the merged transactions to",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "submit",
+				Usage:  "Verify the signed transactions form a complete, still valid, group and send them",
+				Action: TxnSubmit,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "in",
+						Usage:    "The signed transaction file to submit",
+						Required: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// exportUnsignedFlags returns the flags added to owner-signed commands allowing the transactions to be exported
+// for signing on another machine rather than requiring the owner's mnemonic to be present.
+func exportUnsignedFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "export-unsigned",
+			Usage: "Write the unsigned transactions to the specified file rather than signing and sending them.  Sign with 'txn sign' and send with 'txn submit'",
+			Action: func(ctx context.Context, cmd *cli.Command, filename string) error {
+				format := cmd.String("export-format")
+				if format != algo.TxnFormatJSON && format != algo.TxnFormatMsgpack {
+					return fmt.Errorf("unknown export format:%s, must be %s or %s", format, algo.TxnFormatJSON, algo.TxnFormatMsgpack)
+				}
+				validRounds := cmd.Uint("export-validity")
+				if validRounds == 0 || validRounds > algo.MaxTxnValidityRounds {
+					return fmt.Errorf("invalid export validity:%d, must be from 1 to %d rounds", validRounds, algo.MaxTxnValidityRounds)
+				}
+				App.retiClient.ExportUnsigned(filename, format, validRounds)
+				App.exportingUnsigned = true
+				return nil
+			},
+		},
+		&cli.StringFlag{
+			Name:  "export-format",
+			Usage: "Format of the exported transactions: json (array of [signtype, base64 txn] pairs) or msgpack (as used by goal clerk)",
+			Value: algo.TxnFormatJSON,
+		},
+		&cli.UintFlag{
+			Name:  "export-validity",
+			Usage: "Rounds the exported transactions are valid for, to be signed and submitted within - at most 1000 (the protocol max)",
+			Value: algo.MaxTxnValidityRounds,
+		},
+	}
+}
+
+func TxnSign(ctx context.Context, command *cli.Command) error {
+	txnFile, err := algo.ReadTxnFile(command.String("in"))
+	if err != nil {
+		return err
+	}
+	for _, pair := range command.StringSlice("authaddr") {
+		sender, authAddr, found := strings.Cut(pair, "=")
+		if !found {
+			return fmt.Errorf("invalid authaddr:%s, must be sender=authaddr", pair)
+		}
+		if authSigner, ok := App.signer.(*algo.AuthAddrSigner); ok {
+			authSigner.SetAuthAddr(sender, authAddr)
+		}
+	}
+	// the file may have come from anywhere - so show what's being signed
+	for i, fileTxn := range txnFile.Txns {
+		fmt.Printf("txn %d: %s", i, describeTxn(fileTxn.Txn))
+	}
+	if !command.Bool("yes") {
+		if result, _ := yesNo("Sign the above transactions"); result != "y" {
+			return nil
+		}
+	}
+	numSigned, err := txnFile.Sign(ctx, App.signer)
+	if err != nil {
+		return err
+	}
+	outFile := command.String("out")
+	if outFile == "" {
+		outFile = command.String("in")
+	}
+	if err := txnFile.Write(outFile); err != nil {
+		return err
+	}
+	misc.Infof(App.logger, "signed %d of %d transactions, written to %s", numSigned, len(txnFile.Txns), outFile)
+	for _, idx := range txnFile.Unsigned() {
+		misc.Warnf(App.logger, "txn %d still needs to be signed by %s", idx, txnFile.Txns[idx].Txn.Sender)
+	}
+	return nil
+}
+
+func TxnMerge(ctx context.Context, command *cli.Command) error {
+	inFiles := command.StringSlice("in")
+	if len(inFiles) < 2 {
+		return fmt.Errorf("at least two files must be specified to merge")
+	}
+	txnFile, err := algo.ReadTxnFile(inFiles[0])
+	if err != nil {
+		return err
+	}
+	for _, inFile := range inFiles[1:] {
+		other, err := algo.ReadTxnFile(inFile)
+		if err != nil {
+			return err
+		}
+		if err := txnFile.Merge(other); err != nil {
+			return fmt.Errorf("unable to merge %s: %w", inFile, err)
+		}
+	}
+	if err := txnFile.Write(command.String("out")); err != nil {
+		return err
+	}
+	misc.Infof(App.logger, "merged %d files, written to %s", len(inFiles), command.String("out"))
+	for _, idx := range txnFile.Unsigned() {
+		misc.Warnf(App.logger, "txn %d still needs to be signed by %s", idx, txnFile.Txns[idx].Txn.Sender)
+	}
+	return nil
+}
+
+func TxnSubmit(ctx context.Context, command *cli.Command) error {
+	txnFile, err := algo.ReadTxnFile(command.String("in"))
+	if err != nil {
+		return err
+	}
+	status, err := App.chain.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if err := txnFile.Verify(status.LastRound + 1); err != nil {
+		return fmt.Errorf("transactions in %s can't be submitted: %w", command.String("in"), err)
+	}
+	txid, err := App.chain.SendRawTransaction(ctx, txnFile.SignedGroup())
+	if err != nil {
+		return fmt.Errorf("failed to send transactions: %w", err)
+	}
+	resp, err := App.chain.WaitForConfirmation(ctx, txid, 4)
+	if err != nil {
+		return fmt.Errorf("failure waiting for confirmation of txid:%s: %w", txid, err)
+	}
+	misc.Infof(App.logger, "%d transaction(s) confirmed in round %d, txid:%s", len(txnFile.Txns), resp.ConfirmedRound, txid)
+	return nil
+}
+
+// isMarkedCommand returns whether the command line invokes a command whose Metadata sets key (ie: offlineCommand)
+func isMarkedCommand(cmd *cli.Command, key string) bool {
+	for _, arg := range cmd.Args().Slice() {
+		sub := cmd.Command(arg)
+		if sub == nil {
+			break
+		}
+		cmd = sub
+	}
+	marked, _ := cmd.Metadata[key].(bool)
+	return marked
+}
+
+// describeTxn returns a description of the transaction - the fields which matter when deciding whether to sign it
+func describeTxn(txn types.Transaction) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s from %s, fee:%s, valid rounds %d - %d", txn.Type, txn.Sender, algo.FormattedAlgoAmount(uint64(txn.Fee)), txn.FirstValid, txn.LastValid)
+	if txn.Group != (types.Digest{}) {
+		fmt.Fprintf(&sb, ", group:%s", base64.StdEncoding.EncodeToString(txn.Group[:]))
+	}
+	sb.WriteString("\n")
+	switch txn.Type {
+	case types.PaymentTx:
+		fmt.Fprintf(&sb, "  pay %s to %s\n", algo.FormattedAlgoAmount(uint64(txn.Amount)), txn.Receiver)
+	case types.AssetTransferTx:
+		fmt.Fprintf(&sb, "  transfer %d of asset %d to %s\n", txn.AssetAmount, txn.XferAsset, txn.AssetReceiver)
+	case types.KeyRegistrationTx:
+		fmt.Fprintf(&sb, "  register participation key valid rounds %d - %d (offline if empty), nonparticipating:%v\n", txn.VoteFirst, txn.VoteLast, txn.Nonparticipation)
+	case types.ApplicationCallTx:
+		fmt.Fprintf(&sb, "  app id:%d, on completion:%d, %s", txn.ApplicationID, txn.OnCompletion, describeMethodCall(txn))
+	}
+	if !txn.CloseRemainderTo.IsZero() {
+		fmt.Fprintf(&sb, "  WARNING: closes the sender's account to %s\n", txn.CloseRemainderTo)
+	}
+	if !txn.AssetCloseTo.IsZero() {
+		fmt.Fprintf(&sb, "  WARNING: closes the sender's asset holding to %s\n", txn.AssetCloseTo)
+	}
+	if !txn.RekeyTo.IsZero() {
+		fmt.Fprintf(&sb, "  WARNING: rekeys the sender to %s\n", txn.RekeyTo)
+	}
+	return sb.String()
+}
+
+// describeMethodCall returns the ABI method (of the reti contracts) the app call calls, with its decoded arguments
+func describeMethodCall(txn types.Transaction) string {
+	if len(txn.ApplicationArgs) == 0 || len(txn.ApplicationArgs[0]) != 4 {
+		return "not an ABI method call\n"
+	}
+	method, contract, err := reti.EmbeddedMethod(txn.ApplicationArgs[0])
+	if err != nil {
+		return fmt.Sprintf("unknown method (selector:%x)\n", txn.ApplicationArgs[0])
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s method %s\n", contract, method.GetSignature())
+	appArg := 1
+	for _, arg := range method.Args {
+		if arg.IsTransactionArg() {
+			continue
+		}
+		if appArg >= len(txn.ApplicationArgs) || appArg == 15 {
+			// more than 15 arguments are packed in a tuple - which none of the reti methods need
+			break
+		}
+		raw := txn.ApplicationArgs[appArg]
+		appArg++
+		fmt.Fprintf(&sb, "    %s: %s\n", arg.Name, describeMethodArg(txn, arg, raw))
+	}
+	return sb.String()
+}
+
+func describeMethodArg(txn types.Transaction, arg abi.Arg, raw []byte) string {
+	if arg.IsReferenceArg() {
+		if len(raw) != 1 {
+			return fmt.Sprintf("invalid %s reference", arg.Type)
+		}
+		idx := int(raw[0])
+		switch {
+		case arg.Type == abi.AccountReferenceType && idx == 0:
+			return txn.Sender.String()
+		case arg.Type == abi.AccountReferenceType && idx <= len(txn.Accounts):
+			return txn.Accounts[idx-1].String()
+		case arg.Type == abi.ApplicationReferenceType && idx == 0:
+			return strconv.FormatUint(uint64(txn.ApplicationID), 10)
+		case arg.Type == abi.ApplicationReferenceType && idx <= len(txn.ForeignApps):
+			return strconv.FormatUint(uint64(txn.ForeignApps[idx-1]), 10)
+		case arg.Type == abi.AssetReferenceType && idx < len(txn.ForeignAssets):
+			return strconv.FormatUint(uint64(txn.ForeignAssets[idx]), 10)
+		}
+		return fmt.Sprintf("invalid %s reference:%d", arg.Type, idx)
+	}
+	argType, err := arg.GetTypeObject()
+	if err != nil {
+		return fmt.Sprintf("unknown type:%s", arg.Type)
+	}
+	value, err := argType.Decode(raw)
+	if err != nil {
+		return fmt.Sprintf("invalid %s: %v", arg.Type, err)
+	}
+	switch {
+	case arg.Type == "address":
+		var addr types.Address
+		copy(addr[:], raw)
+		return addr.String()
+	case strings.HasPrefix(arg.Type, "byte["):
+		// byte arrays decode as a slice of each byte
+		var buf []byte
+		for _, b := range value.([]any) {
+			buf = append(buf, b.(byte))
+		}
+		return base64.StdEncoding.EncodeToString(buf)
+	}
+	return fmt.Sprint(value)
+}
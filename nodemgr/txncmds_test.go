@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/algorand/go-algorand-sdk/v2/abi"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func TestDescribeTxn(t *testing.T) {
+	sender, staker := crypto.GenerateAccount().Address, crypto.GenerateAccount().Address
+	header := types.Header{Sender: sender, Fee: 2000, FirstValid: 100, LastValid: 1100}
+
+	removeStake, err := abi.MethodFromSignature("removeStake(address,uint64)void")
+	if err != nil {
+		t.Fatal(err)
+	}
+	goOnline, err := abi.MethodFromSignature("goOnline(pay,byte[],byte[],byte[],uint64,uint64,uint64)void")
+	if err != nil {
+		t.Fatal(err)
+	}
+	uint64Arg := func(value uint64) []byte { return binary.BigEndian.AppendUint64(nil, value) }
+	bytesArg := func(value []byte) []byte {
+		return append(binary.BigEndian.AppendUint16(nil, uint16(len(value))), value...)
+	}
+	appCall := func(args ...[]byte) types.Transaction {
+		return types.Transaction{
+			Type:   types.ApplicationCallTx,
+			Header: header,
+			ApplicationFields: types.ApplicationFields{ApplicationCallTxnFields: types.ApplicationCallTxnFields{
+				ApplicationID:   2000,
+				ApplicationArgs: args,
+			}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		txn      types.Transaction
+		contains []string
+	}{
+		{
+			name: "payment",
+			txn: types.Transaction{Type: types.PaymentTx, Header: header,
+				PaymentTxnFields: types.PaymentTxnFields{Receiver: staker, Amount: 1_500_000}},
+			contains: []string{"pay from " + sender.String(), "fee:0.002", "valid rounds 100 - 1100", "pay 1.5 to " + staker.String()},
+		},
+		{
+			name: "rekey",
+			txn: types.Transaction{Type: types.PaymentTx, Header: types.Header{Sender: sender, RekeyTo: staker},
+				PaymentTxnFields: types.PaymentTxnFields{Receiver: staker, CloseRemainderTo: staker}},
+			contains: []string{"WARNING: rekeys the sender to " + staker.String(), "WARNING: closes the sender's account to " + staker.String()},
+		},
+		{
+			name:     "method call",
+			txn:      appCall(removeStake.GetSelector(), staker[:], uint64Arg(5_000_000)),
+			contains: []string{"app id:2000", "StakingPool method removeStake(address,uint64)void", "staker: " + staker.String(), ": 5000000"},
+		},
+		{
+			name:     "byte arguments",
+			txn:      appCall(goOnline.GetSelector(), bytesArg([]byte{1, 2, 3}), bytesArg(nil), bytesArg(nil), uint64Arg(1), uint64Arg(2), uint64Arg(3)),
+			contains: []string{"goOnline(pay,byte[],byte[],byte[],uint64,uint64,uint64)void", base64.StdEncoding.EncodeToString([]byte{1, 2, 3})},
+		},
+		{
+			name:     "unknown method",
+			txn:      appCall([]byte{1, 2, 3, 4}),
+			contains: []string{"unknown method (selector:01020304)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc := describeTxn(tt.txn)
+			for _, want := range tt.contains {
+				if !strings.Contains(desc, want) {
+					t.Fatalf("description doesn't contain %q:\n%s", want, desc)
+				}
+			}
+		})
+	}
+}
@@ -1,15 +1,19 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/algorand/go-algorand-sdk/v2/types"
 	"github.com/mailgun/holster/v4/syncutil"
@@ -31,11 +35,18 @@ func GetValidatorCmdOpts() *cli.Command {
 				Name:   "init",
 				Usage:  "Initialize self as validator - creating or resetting configuration - should only be done ONCE, EVER !",
 				Action: InitValidator,
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:  "config",
+						Usage: "Create the validator without prompting, using the settings in the specified yaml or json file",
+					},
+				}, exportUnsignedFlags()...),
 			},
 			{
-				Name:   "info",
-				Usage:  "Display info about the validator from the chain",
-				Action: DisplayValidatorInfo,
+				Name:     "info",
+				Usage:    "Display info about the validator from the chain",
+				Action:   DisplayValidatorInfo,
+				Metadata: map[string]any{readCommand: true},
 				Flags: []cli.Flag{
 					&cli.UintFlag{
 						Name:  "validator",
@@ -44,9 +55,10 @@ func GetValidatorCmdOpts() *cli.Command {
 				},
 			},
 			{
-				Name:   "state",
-				Usage:  "Display info about the validator's current state from the chain",
-				Action: DisplayValidatorState,
+				Name:     "state",
+				Usage:    "Display info about the validator's current state from the chain",
+				Action:   DisplayValidatorState,
+				Metadata: map[string]any{readCommand: true},
 				Flags: []cli.Flag{
 					&cli.UintFlag{
 						Name:  "validator",
@@ -54,6 +66,105 @@ func GetValidatorCmdOpts() *cli.Command {
 					},
 				},
 			},
+			{
+				Name:     "constraints",
+				Usage:    "Display the protocol constraints of the registry contract (limits every validator is held to)",
+				Action:   DisplayProtocolConstraints,
+				Metadata: map[string]any{readCommand: true},
+			},
+			{
+				Name:     "list",
+				Usage:    "List every validator - filtered and sorted, ie: to find one to move stake to",
+				Action:   ListValidators,
+				Metadata: map[string]any{readCommand: true},
+				Flags: []cli.Flag{
+					&cli.FloatFlag{
+						Name:  "min-commission",
+						Usage: "Only validators with at least this commission (%)",
+					},
+					&cli.FloatFlag{
+						Name:  "max-commission",
+						Usage: "Only validators with at most this commission (%)",
+					},
+					&cli.UintFlag{
+						Name:  "gating-type",
+						Usage: "Only validators with this entry gating type (0 for none)",
+					},
+					&cli.StringFlag{
+						Name:  "reward-token",
+						Usage: "Only validators with a reward token (any), without one (none), or rewarding this asset id",
+					},
+					&cli.StringFlag{
+						Name:  "sunset",
+						Usage: "Only validators with this sunset status - none, sunsetting (a date is set) or sunset (past it)",
+					},
+					&cli.StringFlag{
+						Name:  "min-free",
+						Usage: "Only validators whose pools can take at least this many more ALGO",
+					},
+					&cli.StringFlag{
+						Name:  "nfd",
+						Usage: "Only validators whose NFD name contains this",
+					},
+					&cli.StringFlag{
+						Name:  "sort",
+						Usage: "Sort by id, stake, stakers or apr (largest first)",
+						Value: "id",
+					},
+					&cli.DurationFlag{
+						Name:  "cache",
+						Usage: "Use the validators fetched (and cached) within this long - 0 to fetch them without caching",
+						Value: 10 * time.Minute,
+					},
+					&cli.BoolFlag{
+						Name:  "refresh",
+						Usage: "Fetch the validators now, updating the cache",
+					},
+				},
+			},
+			{
+				Name:     "capacity",
+				Usage:    "Display the headroom of the validator, its nodes and pools, and when pools are projected to fill",
+				Before:   checkConfigured,
+				Action:   DisplayValidatorCapacity,
+				Metadata: map[string]any{readCommand: true},
+				Flags: []cli.Flag{
+					&cli.DurationFlag{
+						Name:  "window",
+						Usage: "How far back to look at stake inflow for projections (0 for no projections)",
+						Value: 30 * 24 * time.Hour,
+					},
+				},
+			},
+			{
+				Name:     "plan",
+				Usage:    "Show the changes needed to make the validator match the settings in a yaml or json file",
+				Action:   PlanValidatorSpec,
+				Metadata: map[string]any{readCommand: true},
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "config",
+						Usage:    "The yaml or json file with the desired validator settings (same format as init --config)",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "apply",
+				Usage:  "Submit the changes needed to make the validator match the settings in a yaml or json file",
+				Action: ApplyValidatorSpec,
+				Flags: append([]cli.Flag{
+					&cli.StringFlag{
+						Name:     "config",
+						Usage:    "The yaml or json file with the desired validator settings (same format as init --config)",
+						Required: true,
+					},
+					&cli.BoolFlag{
+						Name:  "yes",
+						Usage: "Apply the changes without prompting for confirmation",
+					},
+				}, exportUnsignedFlags()...),
+			},
 			{
 				Name:  "change",
 				Usage: "Change configuration parameters of validator",
@@ -61,27 +172,86 @@ func GetValidatorCmdOpts() *cli.Command {
 					{
 						Name:  "manager",
 						Usage: "Change the manager address",
-						Flags: []cli.Flag{
+						Flags: append([]cli.Flag{
 							&cli.StringFlag{
 								Name:     "address",
 								Usage:    "The algorand address to be the new manager address.",
 								Required: true,
 							},
-						},
+						}, exportUnsignedFlags()...),
 						Action: ChangeManager,
 					},
 					{
 						Name:  "commission",
 						Usage: "Change the commission address",
-						Flags: []cli.Flag{
+						Flags: append([]cli.Flag{
 							&cli.StringFlag{
 								Name:     "address",
 								Usage:    "The algorand address to send commissions to.",
 								Required: true,
 							},
-						},
+						}, exportUnsignedFlags()...),
 						Action: ChangeCommission,
 					},
+					{
+						Name:  "nfd",
+						Usage: "Change the NFD associated with the validator",
+						Flags: append([]cli.Flag{
+							&cli.StringFlag{
+								Name:     "name",
+								Usage:    "The NFD name (ie: myvalidator.algo) to associate with the validator.  Must be owned by the validator owner.",
+								Required: true,
+							},
+							&cli.BoolFlag{
+								Name:  "linkpools",
+								Usage: "Also verify each pool's account in the NFD.  The pool addresses must already be added (unverified) to the NFD.",
+							},
+						}, exportUnsignedFlags()...),
+						Action: ChangeNFD,
+					},
+					{
+						Name:  "rewardtoken",
+						Usage: "Change the reward token amount paid per epoch and the entry gating settings.  Unspecified values are left as-is",
+						Flags: append([]cli.Flag{
+							&cli.UintFlag{
+								Name:  "perpayout",
+								Usage: "Amount of the reward token (in base units) paid out each epoch",
+							},
+							&cli.UintFlag{
+								Name:  "gatingtype",
+								Usage: "Entry gating type: 0=none, 1=assets created by address, 2=specific asset ids, 3=assets created by nfd addresses, 4=segment of nfd",
+							},
+							&cli.StringFlag{
+								Name:  "gatingaddress",
+								Usage: "The creator address for gating type 1",
+							},
+							&cli.UintSliceFlag{
+								Name:  "gatingassets",
+								Usage: "Up to 4 asset ids for gating type 2, or the nfd app id for gating types 3 and 4",
+							},
+							&cli.UintFlag{
+								Name:  "gatingminbalance",
+								Usage: "Minimum balance (in base units) of the gating asset stakers must hold",
+							},
+						}, exportUnsignedFlags()...),
+						Action: ChangeRewardInfo,
+					},
+					{
+						Name:  "sunset",
+						Usage: "Set or clear the time the validator sunsets, and optionally the validator stakers should move to",
+						Flags: append([]cli.Flag{
+							&cli.StringFlag{
+								Name:     "on",
+								Usage:    "Date/time to sunset the validator (RFC3339 or YYYY-MM-DD), or 'none' to clear sunsetting",
+								Required: true,
+							},
+							&cli.UintFlag{
+								Name:  "to",
+								Usage: "The validator id stakers are moving to (if known)",
+							},
+						}, exportUnsignedFlags()...),
+						Action: ChangeSunset,
+					},
 				},
 			},
 			{
@@ -94,12 +264,14 @@ func GetValidatorCmdOpts() *cli.Command {
 						Required: true,
 					},
 				},
-				Action: DisplayStakerData,
+				Action:   DisplayStakerData,
+				Metadata: map[string]any{readCommand: true},
 			},
 			{
-				Name:   "exportAllStakers",
-				Usage:  "Exports info about ALL stakers to a .csv file",
-				Action: exportAllStakers,
+				Name:     "exportAllStakers",
+				Usage:    "Exports info about ALL stakers to a .csv file",
+				Action:   exportAllStakers,
+				Metadata: map[string]any{readCommand: true},
 			},
 			{
 				Name:   "refundStakers",
@@ -109,13 +281,13 @@ func GetValidatorCmdOpts() *cli.Command {
 			{
 				Name:  "emptyTokenRewards",
 				Usage: "Return available token rewards in pool 1 to specified account.  Typicaly used when sunsetting validator",
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					&cli.StringFlag{
 						Name:     "account",
 						Usage:    "The address to send the excess reward tokens to",
 						Required: true,
 					},
-				},
+				}, exportUnsignedFlags()...),
 				Action: emptyTokenRewards,
 			},
 		},
@@ -123,6 +295,12 @@ func GetValidatorCmdOpts() *cli.Command {
 }
 
 func InitValidator(ctx context.Context, cmd *cli.Command) error {
+	if cmd.String("config") != "" {
+		return DefineValidatorFromFile(ctx, cmd.String("config"))
+	}
+	if App.exportingUnsigned {
+		return fmt.Errorf("exporting unsigned transactions requires the validator be defined via --config")
+	}
 	if App.retiClient.IsConfigured() {
 		result, _ := yesNo("A validator configuration already appears to exist, do you REALLY want to add an entirely new validator configuration")
 		if result != "y" {
@@ -137,6 +315,68 @@ func InitValidator(ctx context.Context, cmd *cli.Command) error {
 	return DefineValidator()
 }
 
+// ValidatorInfoOutput is the validator info output - amounts are in microAlgo (or reward token base units)
+type ValidatorInfoOutput struct {
+	ID                         uint64   `json:"id" yaml:"id"`
+	Owner                      string   `json:"owner" yaml:"owner"`
+	Manager                    string   `json:"manager" yaml:"manager"`
+	NFDForInfo                 uint64   `json:"nfdForInfo" yaml:"nfdForInfo"`
+	EntryGatingType            uint8    `json:"entryGatingType" yaml:"entryGatingType"`
+	EntryGatingAddress         string   `json:"entryGatingAddress" yaml:"entryGatingAddress"`
+	EntryGatingAssets          []uint64 `json:"entryGatingAssets" yaml:"entryGatingAssets"`
+	GatingAssetMinBalance      uint64   `json:"gatingAssetMinBalance" yaml:"gatingAssetMinBalance"`
+	RewardTokenID              uint64   `json:"rewardTokenId" yaml:"rewardTokenId"`
+	RewardPerPayout            uint64   `json:"rewardPerPayout" yaml:"rewardPerPayout"`
+	EpochRoundLength           int      `json:"epochRoundLength" yaml:"epochRoundLength"`
+	PercentToValidator         int      `json:"percentToValidator" yaml:"percentToValidator"`
+	ValidatorCommissionAddress string   `json:"validatorCommissionAddress" yaml:"validatorCommissionAddress"`
+	MinEntryStake              uint64   `json:"minEntryStake" yaml:"minEntryStake"`
+	MaxAlgoPerPool             uint64   `json:"maxAlgoPerPool" yaml:"maxAlgoPerPool"`
+	PoolsPerNode               int      `json:"poolsPerNode" yaml:"poolsPerNode"`
+	SunsettingOn               uint64   `json:"sunsettingOn" yaml:"sunsettingOn"`
+	SunsettingTo               uint64   `json:"sunsettingTo" yaml:"sunsettingTo"`
+
+	AmtConsideredSaturated uint64 `json:"amtConsideredSaturated" yaml:"amtConsideredSaturated"`
+	MaxAlgoPerValidator    uint64 `json:"maxAlgoPerValidator" yaml:"maxAlgoPerValidator"`
+
+	RegistryContract ContractVersionOutput `json:"registryContract" yaml:"registryContract"`
+	// PoolContracts are only checked for our own validator
+	PoolContracts []ContractVersionOutput `json:"poolContracts,omitempty" yaml:"poolContracts,omitempty"`
+}
+
+type ContractVersionOutput struct {
+	AppID   uint64 `json:"appId" yaml:"appId"`
+	Version string `json:"version" yaml:"version"`
+	Hash    string `json:"hash" yaml:"hash"`
+	Status  string `json:"status" yaml:"status"`
+	Matches bool   `json:"matches" yaml:"matches"`
+}
+
+func contractVersionOutput(cv reti.ContractVersion) ContractVersionOutput {
+	return ContractVersionOutput{AppID: cv.AppID, Version: cv.Version, Hash: cv.Hash, Status: cv.Status, Matches: cv.Matches}
+}
+
+func (v ValidatorInfoOutput) csvRecords() [][]string {
+	var gatingAssets []string
+	for _, asset := range v.EntryGatingAssets {
+		gatingAssets = append(gatingAssets, strconv.FormatUint(asset, 10))
+	}
+	return [][]string{
+		{"id", "owner", "manager", "nfdForInfo", "entryGatingType", "entryGatingAddress", "entryGatingAssets",
+			"gatingAssetMinBalance", "rewardTokenId", "rewardPerPayout", "epochRoundLength", "percentToValidator",
+			"validatorCommissionAddress", "minEntryStake", "maxAlgoPerPool", "poolsPerNode", "sunsettingOn", "sunsettingTo",
+			"amtConsideredSaturated", "maxAlgoPerValidator", "registryContractVersion"},
+		{strconv.FormatUint(v.ID, 10), v.Owner, v.Manager, strconv.FormatUint(v.NFDForInfo, 10),
+			strconv.Itoa(int(v.EntryGatingType)), v.EntryGatingAddress, strings.Join(gatingAssets, ";"),
+			strconv.FormatUint(v.GatingAssetMinBalance, 10), strconv.FormatUint(v.RewardTokenID, 10),
+			strconv.FormatUint(v.RewardPerPayout, 10), strconv.Itoa(v.EpochRoundLength), strconv.Itoa(v.PercentToValidator),
+			v.ValidatorCommissionAddress, strconv.FormatUint(v.MinEntryStake, 10), strconv.FormatUint(v.MaxAlgoPerPool, 10),
+			strconv.Itoa(v.PoolsPerNode), strconv.FormatUint(v.SunsettingOn, 10), strconv.FormatUint(v.SunsettingTo, 10),
+			strconv.FormatUint(v.AmtConsideredSaturated, 10), strconv.FormatUint(v.MaxAlgoPerValidator, 10),
+			v.RegistryContract.Version},
+	}
+}
+
 func DisplayValidatorInfo(ctx context.Context, command *cli.Command) error {
 	var validatorId = App.retiValidatorID
 
@@ -152,14 +392,67 @@ func DisplayValidatorInfo(ctx context.Context, command *cli.Command) error {
 	if err != nil {
 		return fmt.Errorf("get validator config err:%w", err)
 	}
-	fmt.Println(config.String())
 	constraints, err := App.retiClient.GetProtocolConstraints()
 	if err != nil {
 		return err
 	}
-	fmt.Printf("Amt when saturated: %s\n", algo.FormattedAlgoAmount(constraints.AmtConsideredSaturated))
-	fmt.Printf("Max Algo per Validator: %s\n", algo.FormattedAlgoAmount(constraints.MaxAlgoPerValidator))
-	return nil
+	versions := App.retiClient.ContractVersions()
+	output := ValidatorInfoOutput{
+		ID:                         config.ID,
+		Owner:                      config.Owner,
+		Manager:                    config.Manager,
+		NFDForInfo:                 config.NFDForInfo,
+		EntryGatingType:            config.EntryGatingType,
+		EntryGatingAddress:         config.EntryGatingAddress,
+		EntryGatingAssets:          config.EntryGatingAssets,
+		GatingAssetMinBalance:      config.GatingAssetMinBalance,
+		RewardTokenID:              config.RewardTokenId,
+		RewardPerPayout:            config.RewardPerPayout,
+		EpochRoundLength:           config.EpochRoundLength,
+		PercentToValidator:         config.PercentToValidator,
+		ValidatorCommissionAddress: config.ValidatorCommissionAddress,
+		MinEntryStake:              config.MinEntryStake,
+		MaxAlgoPerPool:             config.MaxAlgoPerPool,
+		PoolsPerNode:               config.PoolsPerNode,
+		SunsettingOn:               config.SunsettingOn,
+		SunsettingTo:               config.SunsettingTo,
+		AmtConsideredSaturated:     constraints.AmtConsideredSaturated,
+		MaxAlgoPerValidator:        constraints.MaxAlgoPerValidator,
+		RegistryContract:           contractVersionOutput(versions.Registry),
+	}
+	if validatorId == App.retiValidatorID {
+		for _, pool := range versions.Pools {
+			output.PoolContracts = append(output.PoolContracts, contractVersionOutput(pool))
+		}
+	}
+	return printOutput(command, output, func(out io.Writer) {
+		fmt.Fprintln(out, config.String())
+		fmt.Fprintf(out, "Amt when saturated: %s\n", algo.FormattedAlgoAmount(constraints.AmtConsideredSaturated))
+		fmt.Fprintf(out, "Max Algo per Validator: %s\n", algo.FormattedAlgoAmount(constraints.MaxAlgoPerValidator))
+		fmt.Fprintf(out, "Contract version: %s\n", versions.Registry.String())
+		if validatorId == App.retiValidatorID {
+			for _, pool := range versions.Pools {
+				fmt.Fprintf(out, "Pool contract version: %s\n", pool.String())
+			}
+		}
+	})
+}
+
+// ValidatorStateOutput is the validator state output - amounts are in microAlgo (or reward token base units)
+type ValidatorStateOutput struct {
+	ID                  uint64 `json:"id" yaml:"id"`
+	NumPools            int    `json:"numPools" yaml:"numPools"`
+	TotalStakers        uint64 `json:"totalStakers" yaml:"totalStakers"`
+	TotalStaked         uint64 `json:"totalStaked" yaml:"totalStaked"`
+	RewardTokenHeldBack uint64 `json:"rewardTokenHeldBack" yaml:"rewardTokenHeldBack"`
+}
+
+func (v ValidatorStateOutput) csvRecords() [][]string {
+	return [][]string{
+		{"id", "numPools", "totalStakers", "totalStaked", "rewardTokenHeldBack"},
+		{strconv.FormatUint(v.ID, 10), strconv.Itoa(v.NumPools), strconv.FormatUint(v.TotalStakers, 10),
+			strconv.FormatUint(v.TotalStaked, 10), strconv.FormatUint(v.RewardTokenHeldBack, 10)},
+	}
 }
 
 func DisplayValidatorState(ctx context.Context, command *cli.Command) error {
@@ -178,8 +471,272 @@ func DisplayValidatorState(ctx context.Context, command *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	slog.Info(state.String())
-	return nil
+	output := ValidatorStateOutput{
+		ID:                  validatorId,
+		NumPools:            state.NumPools,
+		TotalStakers:        state.TotalStakers,
+		TotalStaked:         state.TotalAlgoStaked,
+		RewardTokenHeldBack: state.RewardTokenHeldBack,
+	}
+	return printOutput(command, output, func(out io.Writer) {
+		fmt.Fprintln(out, state.String())
+	})
+}
+
+func (c CapacityOutput) csvRecords() [][]string {
+	records := [][]string{{"pool", "node", "appId", "staked", "maxStake", "stakers", "maxStakers", "fillPct", "inflowPerDay", "projectedFull"}}
+	for _, pool := range c.Pools {
+		var projectedFull string
+		if pool.ProjectedFull != nil {
+			projectedFull = pool.ProjectedFull.Format(time.RFC3339)
+		}
+		records = append(records, []string{strconv.Itoa(pool.Pool), strconv.Itoa(pool.Node), strconv.FormatUint(pool.AppID, 10),
+			strconv.FormatUint(pool.Staked, 10), strconv.FormatUint(pool.MaxStake, 10), strconv.FormatUint(pool.Stakers, 10),
+			strconv.FormatUint(pool.MaxStakers, 10), strconv.FormatFloat(pool.FillPct, 'f', 2, 64),
+			strconv.FormatUint(pool.InflowPerDay, 10), projectedFull})
+	}
+	return records
+}
+
+func DisplayValidatorCapacity(ctx context.Context, command *cli.Command) error {
+	output, err := getCapacity(ctx, command.Duration("window"))
+	if err != nil {
+		return err
+	}
+	return printOutput(command, output, func(out io.Writer) {
+		printCapacity(out, output, command.Duration("window") > 0)
+	})
+}
+
+func printCapacity(out io.Writer, output CapacityOutput, projected bool) {
+	projection := func(when *time.Time) string {
+		switch {
+		case !projected:
+			return ""
+		case when == nil:
+			return "no inflow"
+		case when.Before(time.Now()):
+			return "now"
+		default:
+			return when.Format(time.DateOnly)
+		}
+	}
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Pool\tNode\tPool App id\tAmt Staked\tMax Stake\t# stakers\tMax stakers\tFull %\tInflow/day\tFull by\t")
+	for _, pool := range output.Pools {
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%s\t%s\t%d\t%d\t%.1f\t%s\t%s\t\n", pool.Pool, pool.Node, pool.AppID,
+			algo.FormattedAlgoAmount(pool.Staked), algo.FormattedAlgoAmount(pool.MaxStake), pool.Stakers, pool.MaxStakers,
+			pool.FillPct, algo.FormattedAlgoAmount(pool.InflowPerDay), projection(pool.ProjectedFull))
+	}
+	tw.Flush()
+	fmt.Fprintln(out)
+
+	tw = tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Node\t# pools\tFree pool slots\tAmt Staked\tHeadroom\t")
+	for _, node := range output.Nodes {
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%s\t%s\t\n", node.Node, node.Pools, node.FreeSlots,
+			algo.FormattedAlgoAmount(node.Staked), algo.FormattedAlgoAmount(node.Headroom))
+	}
+	tw.Flush()
+	fmt.Fprintln(out)
+
+	fmt.Fprintf(out, "Validator total staked: %s\n", algo.FormattedAlgoAmount(output.TotalStaked))
+	fmt.Fprintf(out, "Saturation at: %s (headroom %s)\n", algo.FormattedAlgoAmount(output.SaturationStake),
+		algo.FormattedAlgoAmount(output.SaturationStake-min(output.TotalStaked, output.SaturationStake)))
+	fmt.Fprintf(out, "Max stake: %s (headroom %s)\n", algo.FormattedAlgoAmount(output.MaxStake),
+		algo.FormattedAlgoAmount(output.MaxStake-min(output.TotalStaked, output.MaxStake)))
+	fmt.Fprintf(out, "Free pool slots (all nodes): %d\n", output.FreePoolSlots)
+	if projected {
+		fmt.Fprintf(out, "Inflow per day: %s, saturated by: %s\n", algo.FormattedAlgoAmount(output.InflowPerDay), projection(output.ProjectedSaturated))
+	}
+}
+
+// ConstraintsOutput is the protocol constraints output - amounts are in microAlgo, percentages with four decimals
+// (ie: 50000 = 5%)
+type ConstraintsOutput struct {
+	EpochPayoutRoundsMin           uint64 `json:"epochPayoutRoundsMin" yaml:"epochPayoutRoundsMin"`
+	EpochPayoutRoundsMax           uint64 `json:"epochPayoutRoundsMax" yaml:"epochPayoutRoundsMax"`
+	MinPctToValidatorWFourDecimals uint64 `json:"minPctToValidatorWFourDecimals" yaml:"minPctToValidatorWFourDecimals"`
+	MaxPctToValidatorWFourDecimals uint64 `json:"maxPctToValidatorWFourDecimals" yaml:"maxPctToValidatorWFourDecimals"`
+	MinEntryStake                  uint64 `json:"minEntryStake" yaml:"minEntryStake"`
+	MaxAlgoPerPool                 uint64 `json:"maxAlgoPerPool" yaml:"maxAlgoPerPool"`
+	MaxAlgoPerValidator            uint64 `json:"maxAlgoPerValidator" yaml:"maxAlgoPerValidator"`
+	AmtConsideredSaturated         uint64 `json:"amtConsideredSaturated" yaml:"amtConsideredSaturated"`
+	MaxNodes                       uint64 `json:"maxNodes" yaml:"maxNodes"`
+	MaxPoolsPerNode                uint64 `json:"maxPoolsPerNode" yaml:"maxPoolsPerNode"`
+	MaxStakersPerPool              uint64 `json:"maxStakersPerPool" yaml:"maxStakersPerPool"`
+}
+
+func (c ConstraintsOutput) csvRecords() [][]string {
+	return [][]string{
+		{"epochPayoutRoundsMin", "epochPayoutRoundsMax", "minPctToValidatorWFourDecimals", "maxPctToValidatorWFourDecimals",
+			"minEntryStake", "maxAlgoPerPool", "maxAlgoPerValidator", "amtConsideredSaturated", "maxNodes", "maxPoolsPerNode",
+			"maxStakersPerPool"},
+		{strconv.FormatUint(c.EpochPayoutRoundsMin, 10), strconv.FormatUint(c.EpochPayoutRoundsMax, 10),
+			strconv.FormatUint(c.MinPctToValidatorWFourDecimals, 10), strconv.FormatUint(c.MaxPctToValidatorWFourDecimals, 10),
+			strconv.FormatUint(c.MinEntryStake, 10), strconv.FormatUint(c.MaxAlgoPerPool, 10),
+			strconv.FormatUint(c.MaxAlgoPerValidator, 10), strconv.FormatUint(c.AmtConsideredSaturated, 10),
+			strconv.FormatUint(c.MaxNodes, 10), strconv.FormatUint(c.MaxPoolsPerNode, 10), strconv.FormatUint(c.MaxStakersPerPool, 10)},
+	}
+}
+
+func DisplayProtocolConstraints(ctx context.Context, command *cli.Command) error {
+	constraints, err := App.retiClient.GetProtocolConstraints()
+	if err != nil {
+		return fmt.Errorf("unable to get protocol constraints: %w", err)
+	}
+	output := ConstraintsOutput{
+		EpochPayoutRoundsMin:           constraints.EpochPayoutRoundsMin,
+		EpochPayoutRoundsMax:           constraints.EpochPayoutRoundsMax,
+		MinPctToValidatorWFourDecimals: constraints.MinPctToValidatorWFourDecimals,
+		MaxPctToValidatorWFourDecimals: constraints.MaxPctToValidatorWFourDecimals,
+		MinEntryStake:                  constraints.MinEntryStake,
+		MaxAlgoPerPool:                 constraints.MaxAlgoPerPool,
+		MaxAlgoPerValidator:            constraints.MaxAlgoPerValidator,
+		AmtConsideredSaturated:         constraints.AmtConsideredSaturated,
+		MaxNodes:                       constraints.MaxNodes,
+		MaxPoolsPerNode:                constraints.MaxPoolsPerNode,
+		MaxStakersPerPool:              constraints.MaxStakersPerPool,
+	}
+	return printOutput(command, output, func(out io.Writer) {
+		fmt.Fprintf(out, "Epoch length: %d - %d rounds\n", output.EpochPayoutRoundsMin, output.EpochPayoutRoundsMax)
+		fmt.Fprintf(out, "Commission: %.4f%% - %.4f%%\n", float64(output.MinPctToValidatorWFourDecimals)/10000,
+			float64(output.MaxPctToValidatorWFourDecimals)/10000)
+		fmt.Fprintf(out, "Min Entry Stake: %s\n", algo.FormattedAlgoAmount(output.MinEntryStake))
+		fmt.Fprintf(out, "Max Algo per Pool: %s\n", algo.FormattedAlgoAmount(output.MaxAlgoPerPool))
+		fmt.Fprintf(out, "Max Algo per Validator: %s\n", algo.FormattedAlgoAmount(output.MaxAlgoPerValidator))
+		fmt.Fprintf(out, "Amt when saturated: %s\n", algo.FormattedAlgoAmount(output.AmtConsideredSaturated))
+		fmt.Fprintf(out, "Max Nodes: %d\n", output.MaxNodes)
+		fmt.Fprintf(out, "Max Pools per Node: %d\n", output.MaxPoolsPerNode)
+		fmt.Fprintf(out, "Max Stakers per Pool: %d\n", output.MaxStakersPerPool)
+	})
+}
+
+type ValidatorListOutput []ValidatorListEntry
+
+func (v ValidatorListOutput) csvRecords() [][]string {
+	records := [][]string{{"id", "owner", "nfd", "nfdAppId", "commission", "entryGatingType", "rewardTokenId", "sunsettingOn",
+		"sunsettingTo", "pools", "stakers", "staked", "maxStake", "freeCapacity", "apr"}}
+	for _, validator := range v {
+		records = append(records, []string{strconv.FormatUint(validator.ID, 10), validator.Owner, validator.NFD,
+			strconv.FormatUint(validator.NFDAppID, 10), strconv.FormatFloat(validator.Commission, 'f', -1, 64),
+			strconv.Itoa(int(validator.EntryGatingType)), strconv.FormatUint(validator.RewardTokenID, 10),
+			strconv.FormatUint(validator.SunsettingOn, 10), strconv.FormatUint(validator.SunsettingTo, 10),
+			strconv.Itoa(validator.Pools), strconv.FormatUint(validator.Stakers, 10), strconv.FormatUint(validator.Staked, 10),
+			strconv.FormatUint(validator.MaxStake, 10), strconv.FormatUint(validator.FreeCapacity, 10),
+			strconv.FormatFloat(validator.APR, 'f', -1, 64)})
+	}
+	return records
+}
+
+func ListValidators(ctx context.Context, command *cli.Command) error {
+	filter, err := validatorFilter(command)
+	if err != nil {
+		return err
+	}
+	sortBy := command.String("sort")
+	if !slices.Contains(validatorSorts, sortBy) {
+		return fmt.Errorf("unknown sort:%s, must be one of %v", sortBy, validatorSorts)
+	}
+	var cacheFile string
+	if command.Duration("cache") > 0 {
+		cacheFile = validatorCacheFile(command.String("network"))
+	}
+	maxAge := command.Duration("cache")
+	if command.Bool("refresh") {
+		maxAge = 0
+	}
+	directory, err := getValidatorDirectory(ctx, cacheFile, maxAge)
+	if err != nil {
+		return err
+	}
+	output := ValidatorListOutput{}
+	for _, validator := range directory.Validators {
+		if filter(validator) {
+			output = append(output, validator)
+		}
+	}
+	// largest first, other than by id
+	slices.SortStableFunc(output, func(a, b ValidatorListEntry) int {
+		switch sortBy {
+		case "stake":
+			return cmp.Compare(b.Staked, a.Staked)
+		case "stakers":
+			return cmp.Compare(b.Stakers, a.Stakers)
+		case "apr":
+			return cmp.Compare(b.APR, a.APR)
+		default:
+			return cmp.Compare(a.ID, b.ID)
+		}
+	})
+	return printOutput(command, output, func(out io.Writer) {
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(tw, "ID\tName\tCommission %\tGating\tReward Token\tSunset\tPools\t# stakers\tAmt Staked\tFree\tAPR %\t")
+		for _, validator := range output {
+			name := validator.NFD
+			if name == "" {
+				name = validator.Owner
+			}
+			sunset := validator.SunsetStatus()
+			if sunset != sunsetNone {
+				sunset = fmt.Sprintf("%s %s", sunset, time.Unix(int64(validator.SunsettingOn), 0).Format(time.DateOnly))
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%d\t%d\t%s\t%d\t%d\t%s\t%s\t%s\t\n", validator.ID, name,
+				strconv.FormatFloat(validator.Commission, 'g', -1, 64), validator.EntryGatingType, validator.RewardTokenID, sunset,
+				validator.Pools, validator.Stakers, algo.FormattedAlgoAmount(validator.Staked),
+				algo.FormattedAlgoAmount(validator.FreeCapacity), strconv.FormatFloat(validator.APR, 'f', 2, 64))
+		}
+		tw.Flush()
+		fmt.Fprintf(out, "%d of %d validators, as of %s\n", len(output), len(directory.Validators), directory.Fetched.Local().Format(time.DateTime))
+		if len(directory.Skipped) > 0 {
+			fmt.Fprintf(out, "%d validators couldn't be loaded and are missing: %v\n", len(directory.Skipped), directory.Skipped)
+		}
+	})
+}
+
+// validatorFilter returns the filter of validators matching the filter flags of validator list
+func validatorFilter(command *cli.Command) (func(ValidatorListEntry) bool, error) {
+	var (
+		minCommission = command.Float("min-commission")
+		maxCommission = command.Float("max-commission")
+		gatingType    = command.Uint("gating-type")
+		rewardToken   = command.String("reward-token")
+		sunset        = command.String("sunset")
+		nfd           = strings.ToLower(command.String("nfd"))
+		minFree       uint64
+		rewardTokenID uint64
+		err           error
+	)
+	if command.String("min-free") != "" {
+		minFree, err = algo.ParseAlgoAmount(command.String("min-free"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if sunset != "" && !slices.Contains(sunsetStatuses, sunset) {
+		return nil, fmt.Errorf("unknown sunset status:%s, must be one of %v", sunset, sunsetStatuses)
+	}
+	if rewardToken != "" && rewardToken != "any" && rewardToken != "none" {
+		rewardTokenID, err = strconv.ParseUint(rewardToken, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reward token:%s - must be any, none or an asset id", rewardToken)
+		}
+	}
+	return func(v ValidatorListEntry) bool {
+		switch {
+		case command.IsSet("min-commission") && v.Commission < minCommission,
+			command.IsSet("max-commission") && v.Commission > maxCommission,
+			command.IsSet("gating-type") && uint64(v.EntryGatingType) != gatingType,
+			rewardToken == "any" && v.RewardTokenID == 0,
+			rewardToken == "none" && v.RewardTokenID != 0,
+			rewardTokenID != 0 && v.RewardTokenID != rewardTokenID,
+			sunset != "" && v.SunsetStatus() != sunset,
+			v.FreeCapacity < minFree,
+			nfd != "" && !strings.Contains(strings.ToLower(v.NFD), nfd):
+			return false
+		}
+		return true
+	}, nil
 }
 
 func ChangeManager(ctx context.Context, command *cli.Command) error {
@@ -188,11 +745,10 @@ func ChangeManager(ctx context.Context, command *cli.Command) error {
 	}
 	var info = App.retiClient.Info()
 
-	signer, err := App.signer.FindFirstSigner([]string{info.Config.Owner, info.Config.Manager})
+	signerAddr, err := getOwnerSigner(info)
 	if err != nil {
-		return fmt.Errorf("neither owner or manager address for your validator has local keys present")
+		return err
 	}
-	signerAddr, _ := types.DecodeAddress(signer)
 
 	managerAddress, err := types.DecodeAddress(command.String("address"))
 	if err != nil {
@@ -212,11 +768,10 @@ func ChangeCommission(ctx context.Context, command *cli.Command) error {
 	}
 	var info = App.retiClient.Info()
 
-	signer, err := App.signer.FindFirstSigner([]string{info.Config.Owner, info.Config.Manager})
+	signerAddr, err := getOwnerSigner(info)
 	if err != nil {
-		return fmt.Errorf("neither owner or manager address for your validator has local keys present")
+		return err
 	}
-	signerAddr, _ := types.DecodeAddress(signer)
 
 	commissionAddress, err := types.DecodeAddress(command.String("address"))
 	if err != nil {
@@ -230,6 +785,309 @@ func ChangeCommission(ctx context.Context, command *cli.Command) error {
 	return App.retiClient.LoadState(ctx)
 }
 
+func ChangeNFD(ctx context.Context, command *cli.Command) error {
+	if !App.retiClient.IsConfigured() {
+		return fmt.Errorf("validator not configured")
+	}
+	var info = App.retiClient.Info()
+
+	ownerAddr, err := getOwnerSigner(info)
+	if err != nil {
+		return err
+	}
+	nfdName := command.String("name")
+	if err := IsNFDNameValid(nfdName); err != nil {
+		return err
+	}
+	nfd, _, err := App.nfdApi.NfdApi.NfdGetNFD(ctx, nfdName, nil)
+	if err != nil {
+		return fmt.Errorf("unable to fetch nfd:%s, err:%w", nfdName, err)
+	}
+	if nfd.Owner != info.Config.Owner {
+		return fmt.Errorf("nfd owner:%s is not the validator owner:%s", nfd.Owner, info.Config.Owner)
+	}
+	newConfig := info.Config
+	newConfig.NFDForInfo = uint64(nfd.AppID)
+	if err := validateConfigChange(&newConfig); err != nil {
+		return err
+	}
+
+	if result, _ := yesNo(fmt.Sprintf("Change the validator NFD to %s (app id:%d)", nfd.Name, nfd.AppID)); result != "y" {
+		return nil
+	}
+	err = App.retiClient.ChangeValidatorNFD(info.Config.ID, ownerAddr, uint64(nfd.AppID), nfd.Name)
+	if err != nil {
+		return err
+	}
+	misc.Infof(App.logger, "validator NFD changed to %s", nfd.Name)
+
+	if command.Bool("linkpools") {
+		signer, err := App.signer.FindFirstSigner([]string{info.Config.Owner, info.Config.Manager})
+		if err != nil {
+			return fmt.Errorf("neither owner or manager address for your validator has local keys present")
+		}
+		signerAddr, _ := types.DecodeAddress(signer)
+		for i, pool := range info.Pools {
+			err = App.retiClient.LinkPoolToNFD(pool.PoolAppId, signerAddr, uint64(nfd.AppID), nfd.Name)
+			if err != nil {
+				return fmt.Errorf("unable to link pool %d to nfd, err:%w", i+1, err)
+			}
+			misc.Infof(App.logger, "pool %d account linked to %s", i+1, nfd.Name)
+		}
+	}
+	return App.retiClient.LoadState(ctx)
+}
+
+func ChangeRewardInfo(ctx context.Context, command *cli.Command) error {
+	if !App.retiClient.IsConfigured() {
+		return fmt.Errorf("validator not configured")
+	}
+	var info = App.retiClient.Info()
+
+	ownerAddr, err := getOwnerSigner(info)
+	if err != nil {
+		return err
+	}
+
+	newConfig := info.Config
+	if command.IsSet("perpayout") {
+		newConfig.RewardPerPayout = command.Uint("perpayout")
+	}
+	if command.IsSet("gatingtype") {
+		newConfig.EntryGatingType = uint8(command.Uint("gatingtype"))
+	}
+	if command.IsSet("gatingaddress") {
+		newConfig.EntryGatingAddress = command.String("gatingaddress")
+	}
+	if command.IsSet("gatingassets") {
+		newConfig.EntryGatingAssets = command.UintSlice("gatingassets")
+	}
+	if command.IsSet("gatingminbalance") {
+		newConfig.GatingAssetMinBalance = command.Uint("gatingminbalance")
+	}
+	if err := validateConfigChange(&newConfig); err != nil {
+		return err
+	}
+
+	fmt.Printf("Reward per payout: %d\n", newConfig.RewardPerPayout)
+	fmt.Printf("Entry gating type: %d, address: %s, assets: %v, min balance: %d\n", newConfig.EntryGatingType,
+		newConfig.EntryGatingAddress, newConfig.EntryGatingAssets, newConfig.GatingAssetMinBalance)
+	if result, _ := yesNo("Change the validator reward and gating settings to the above"); result != "y" {
+		return nil
+	}
+	err = App.retiClient.ChangeValidatorRewardInfo(&newConfig, ownerAddr)
+	if err != nil {
+		return err
+	}
+	return App.retiClient.LoadState(ctx)
+}
+
+func ChangeSunset(ctx context.Context, command *cli.Command) error {
+	if !App.retiClient.IsConfigured() {
+		return fmt.Errorf("validator not configured")
+	}
+	var info = App.retiClient.Info()
+
+	ownerAddr, err := getOwnerSigner(info)
+	if err != nil {
+		return err
+	}
+
+	newConfig := info.Config
+	newConfig.SunsettingOn, newConfig.SunsettingTo = 0, 0
+	if onStr := command.String("on"); onStr != "none" {
+		sunsetTime, err := parseSunsetTime(onStr)
+		if err != nil {
+			return err
+		}
+		if !sunsetTime.After(time.Now()) {
+			return fmt.Errorf("sunset time:%s must be in the future", sunsetTime.Format(time.RFC3339))
+		}
+		newConfig.SunsettingOn = uint64(sunsetTime.Unix())
+		newConfig.SunsettingTo = command.Uint("to")
+	}
+	if newConfig.SunsettingTo != 0 {
+		numValidators, err := App.retiClient.GetNumValidators()
+		if err != nil {
+			return err
+		}
+		if newConfig.SunsettingTo > numValidators {
+			return fmt.Errorf("validator id:%d to sunset to doesn't exist", newConfig.SunsettingTo)
+		}
+	}
+	if err := validateConfigChange(&newConfig); err != nil {
+		return err
+	}
+
+	prompt := "Clear the validator sunset"
+	if newConfig.SunsettingOn != 0 {
+		prompt = fmt.Sprintf("Sunset the validator on %s", time.Unix(int64(newConfig.SunsettingOn), 0).Format(time.RFC3339))
+		if newConfig.SunsettingTo != 0 {
+			prompt += fmt.Sprintf(", moving to validator %d", newConfig.SunsettingTo)
+		}
+	}
+	if result, _ := yesNo(prompt); result != "y" {
+		return nil
+	}
+	err = App.retiClient.ChangeValidatorSunsetInfo(info.Config.ID, ownerAddr, newConfig.SunsettingOn, newConfig.SunsettingTo)
+	if err != nil {
+		return err
+	}
+	return App.retiClient.LoadState(ctx)
+}
+
+func PlanValidatorSpec(ctx context.Context, command *cli.Command) error {
+	plan, err := loadValidatorPlan(ctx, command.String("config"))
+	if err != nil {
+		return err
+	}
+	fmt.Print(plan.String())
+	return nil
+}
+
+func ApplyValidatorSpec(ctx context.Context, command *cli.Command) error {
+	plan, err := loadValidatorPlan(ctx, command.String("config"))
+	if err != nil {
+		return err
+	}
+	fmt.Print(plan.String())
+	if !plan.HasChanges() {
+		return nil
+	}
+
+	// verify keys are present for every account that has to sign before submitting anything, so a missing key
+	// doesn't leave the changes partially applied.  When exporting, only the first change is exported - apply
+	// can be run again once it's been submitted.
+	var ownerAddr types.Address
+	for _, step := range plan.Steps {
+		switch step.Role {
+		case ownerRole:
+			ownerAddr, err = getOwnerSigner(App.retiClient.Info())
+			if err != nil {
+				return err
+			}
+		case managerRole:
+			if !App.exportingUnsigned && !App.signer.HasAccount(plan.Result.Manager) {
+				return fmt.Errorf("manager address:%s for your validator doesn't have local keys present", plan.Result.Manager)
+			}
+		}
+	}
+
+	if !command.Bool("yes") {
+		if result, _ := yesNo("Apply the above changes"); result != "y" {
+			return nil
+		}
+	}
+	var reloaded bool
+	for i, step := range plan.Steps {
+		if step.Role == managerRole && !reloaded {
+			// owner changes (possibly the manager itself) have to be loaded before the manager signs
+			if err := App.retiClient.LoadState(ctx); err != nil {
+				return err
+			}
+			reloaded = true
+		}
+		if err := step.apply(ctx, ownerAddr); err != nil {
+			if errors.Is(err, algo.ErrTxnsExported) {
+				return err
+			}
+			return fmt.Errorf("change %d (%s) failed, err:%w", i+1, step.Desc, err)
+		}
+		misc.Infof(App.logger, "applied change %d: %s", i+1, step.Desc)
+	}
+	return App.retiClient.LoadState(ctx)
+}
+
+// loadValidatorPlan loads a ValidatorSpec file and plans the changes needed to make our validator match it
+func loadValidatorPlan(ctx context.Context, filename string) (*ValidatorPlan, error) {
+	if !App.retiClient.IsConfigured() {
+		return nil, fmt.Errorf("validator not configured")
+	}
+	spec, err := LoadValidatorSpec(filename)
+	if err != nil {
+		return nil, err
+	}
+	desired, err := spec.Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	info := App.retiClient.Info()
+	desired.ID = info.Config.ID
+	plan, err := PlanValidator(desired, spec.NFD, spec.NodePools, info)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateConfigChange(&plan.Result); err != nil {
+		return nil, fmt.Errorf("applying %s would leave the validator with an invalid config: %w", filename, err)
+	}
+	return plan, nil
+}
+
+// getOwnerSigner returns the validator owner address, verifying its keys are available to sign with - unless the
+// transactions are being exported to be signed elsewhere
+func getOwnerSigner(info reti.ValidatorInfo) (types.Address, error) {
+	if !App.exportingUnsigned && !App.signer.HasAccount(info.Config.Owner) {
+		return types.ZeroAddress, fmt.Errorf("owner address for your validator doesn't have local keys present")
+	}
+	return types.DecodeAddress(info.Config.Owner)
+}
+
+// validateConfigChange validates the validator configuration as it would be after a change
+func validateConfigChange(config *reti.ValidatorConfig) error {
+	constraints, err := App.retiClient.GetProtocolConstraints()
+	if err != nil {
+		return err
+	}
+	return config.Validate(constraints)
+}
+
+func parseSunsetTime(value string) (time.Time, error) {
+	if sunsetTime, err := time.Parse(time.RFC3339, value); err == nil {
+		return sunsetTime, nil
+	}
+	sunsetTime, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid sunset time:%s, must be RFC3339 or YYYY-MM-DD", value)
+	}
+	return sunsetTime, nil
+}
+
+// DefineValidatorFromFile creates a new validator using the settings in a ValidatorSpec file, without any prompts
+func DefineValidatorFromFile(ctx context.Context, filename string) error {
+	spec, err := LoadValidatorSpec(filename)
+	if err != nil {
+		return err
+	}
+	config, err := spec.Config(ctx)
+	if err != nil {
+		return err
+	}
+	if !App.exportingUnsigned && !App.signer.HasAccount(config.Owner) {
+		return fmt.Errorf("the mnemonics aren't available for owner account:%s", config.Owner)
+	}
+	if !App.exportingUnsigned && !App.signer.HasAccount(config.Manager) {
+		return fmt.Errorf("the mnemonics aren't available for manager account:%s", config.Manager)
+	}
+	if err := validateConfigChange(&config); err != nil {
+		return fmt.Errorf("invalid validator config in %s: %w", filename, err)
+	}
+	ownerAddr, _ := types.DecodeAddress(config.Owner)
+	mbrs, err := App.retiClient.GetMbrAmounts(ownerAddr)
+	if err != nil {
+		return err
+	}
+	misc.Infof(App.logger, "adding validator will cost %s (MBR) plus a %s fee", algo.FormattedAlgoAmount(mbrs.AddValidatorMbr), algo.FormattedAlgoAmount(10e6))
+
+	info := &reti.ValidatorInfo{Config: config}
+	validatorId, err := App.retiClient.AddValidator(info, spec.NFD)
+	if err != nil {
+		return err
+	}
+	info.Config.ID = validatorId
+	slog.Info("New Validator added, your Validator id is:", "id", info.Config.ID)
+	return App.retiClient.LoadState(ctx)
+}
+
 func DefineValidator() error {
 	var (
 		err      error
@@ -308,6 +1166,23 @@ func DefineValidator() error {
 	return App.retiClient.LoadState(context.Background())
 }
 
+// StakerPoolOutput is a pool of the staker in the validator stakerData output
+type StakerPoolOutput struct {
+	ValidatorID uint64 `json:"validatorId" yaml:"validatorId"`
+	Pool        uint64 `json:"pool" yaml:"pool"`
+	AppID       uint64 `json:"appId" yaml:"appId"`
+}
+
+type StakerPoolOutputs []StakerPoolOutput
+
+func (s StakerPoolOutputs) csvRecords() [][]string {
+	records := [][]string{{"validatorId", "pool", "appId"}}
+	for _, pool := range s {
+		records = append(records, []string{strconv.FormatUint(pool.ValidatorID, 10), strconv.FormatUint(pool.Pool, 10), strconv.FormatUint(pool.AppID, 10)})
+	}
+	return records
+}
+
 func DisplayStakerData(ctx context.Context, command *cli.Command) error {
 	// account, amount, validator, pool
 	stakerAddr, err := types.DecodeAddress(command.String("account"))
@@ -319,16 +1194,18 @@ func DisplayStakerData(ctx context.Context, command *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	out := new(strings.Builder)
-	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
-	fmt.Fprintln(tw, "Validator ID\tPool ID\tApp ID\t")
-
+	output := StakerPoolOutputs{}
 	for _, key := range poolKeys {
-		fmt.Fprintf(tw, "%d\t%d\t%d\t\n", key.ID, key.PoolId, key.PoolAppId)
+		output = append(output, StakerPoolOutput{ValidatorID: key.ID, Pool: key.PoolId, AppID: key.PoolAppId})
 	}
-	tw.Flush()
-	slog.Info(out.String())
-	return nil
+	return printOutput(command, output, func(out io.Writer) {
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(tw, "Validator ID\tPool ID\tApp ID\t")
+		for _, pool := range output {
+			fmt.Fprintf(tw, "%d\t%d\t%d\t\n", pool.ValidatorID, pool.Pool, pool.AppID)
+		}
+		tw.Flush()
+	})
 }
 
 func exportAllStakers(ctx context.Context, command *cli.Command) error {
@@ -457,18 +1334,20 @@ func refundAllPools(signerAddr types.Address) (reti.ValidatorInfo, []error) {
 }
 
 func emptyTokenRewards(ctx context.Context, command *cli.Command) error {
-	signer, err := App.signer.FindFirstSigner([]string{App.retiClient.Info().Config.Owner})
+	info := App.retiClient.Info()
+	signerAddr, err := getOwnerSigner(info)
 	if err != nil {
-		return fmt.Errorf("owner address for your validator doesn't have local keys present")
+		return err
 	}
 	receiverAddr, err := types.DecodeAddress(command.String("account"))
 	if err != nil {
 		return err
 	}
-	signerAddr, _ := types.DecodeAddress(signer)
-	info := App.retiClient.Info()
 
 	err = App.retiClient.EmptyTokenRewards(info.Config.ID, signerAddr, receiverAddr)
+	if errors.Is(err, algo.ErrTxnsExported) {
+		return err
+	}
 	if err != nil {
 		misc.Errorf(App.logger, "error emptying token rewards, err:%v", err)
 	}
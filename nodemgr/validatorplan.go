@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+
+	"github.com/algorandfoundation/reti/internal/lib/reti"
+)
+
+const (
+	ownerRole   = "owner"
+	managerRole = "manager"
+)
+
+// fieldDiff is a validator setting whose on-chain value differs from the value in the spec
+type fieldDiff struct {
+	Name    string
+	Current string
+	Desired string
+}
+
+// planStep is a single transaction needed to bring the on-chain validator in line with its spec
+type planStep struct {
+	Desc string
+	// Role is the validator account (owner or manager) whose keys have to sign the step
+	Role  string
+	Diffs []fieldDiff
+	apply func(ctx context.Context, ownerAddr types.Address) error
+}
+
+// ValidatorPlan is the set of changes needed to reconcile an existing validator with a ValidatorSpec.
+// Steps signed by the owner are always ordered first, so a manager change takes effect before the manager
+// signs for any pools being added.
+type ValidatorPlan struct {
+	ValidatorID uint64
+	Steps       []planStep
+	// Immutable lists settings differing from the spec that the registry contract doesn't allow changing once the
+	// validator has been created
+	Immutable []fieldDiff
+	// Notes describes differences that can't be reconciled for other reasons (ie: pools can't be removed)
+	Notes []string
+	// Result is the validator config as it will be once the plan is applied
+	Result reti.ValidatorConfig
+}
+
+func (p *ValidatorPlan) HasChanges() bool {
+	return len(p.Steps) > 0
+}
+
+func (p *ValidatorPlan) String() string {
+	var out strings.Builder
+
+	if !p.HasChanges() {
+		out.WriteString(fmt.Sprintf("validator id:%d matches the spec, no changes needed\n", p.ValidatorID))
+	} else {
+		out.WriteString(fmt.Sprintf("validator id:%d needs %d change(s):\n", p.ValidatorID, len(p.Steps)))
+	}
+	for i, step := range p.Steps {
+		out.WriteString(fmt.Sprintf("%d. %s (signed by %s)\n", i+1, step.Desc, step.Role))
+		for _, diff := range step.Diffs {
+			out.WriteString(fmt.Sprintf("   ~ %s: %s -> %s\n", diff.Name, diff.Current, diff.Desired))
+		}
+	}
+	if len(p.Immutable) > 0 {
+		out.WriteString("can't be changed after creation (the contract doesn't allow it):\n")
+		for _, diff := range p.Immutable {
+			out.WriteString(fmt.Sprintf("   ! %s: %s (spec: %s)\n", diff.Name, diff.Current, diff.Desired))
+		}
+	}
+	if len(p.Notes) > 0 {
+		out.WriteString("can't be reconciled:\n")
+		for _, note := range p.Notes {
+			out.WriteString(fmt.Sprintf("   ! %s\n", note))
+		}
+	}
+	return out.String()
+}
+
+// PlanValidator compares the desired validator config (and pools per node) against the on-chain state of the
+// validator, returning the changes needed to reconcile them.
+func PlanValidator(desired reti.ValidatorConfig, nfdName string, nodePools []int, info reti.ValidatorInfo) (*ValidatorPlan, error) {
+	var (
+		current = info.Config
+		plan    = &ValidatorPlan{ValidatorID: current.ID, Result: current}
+	)
+
+	for _, diff := range [][]fieldDiff{
+		appendDiff(nil, "owner", current.Owner, desired.Owner),
+		appendDiff(nil, "rewardTokenId", current.RewardTokenId, desired.RewardTokenId),
+		appendDiff(nil, "epochRoundLength", current.EpochRoundLength, desired.EpochRoundLength),
+		appendDiff(nil, "percentToValidator", current.PercentToValidator, desired.PercentToValidator),
+		appendDiff(nil, "minEntryStake", current.MinEntryStake, desired.MinEntryStake),
+		appendDiff(nil, "maxAlgoPerPool", current.MaxAlgoPerPool, desired.MaxAlgoPerPool),
+		appendDiff(nil, "poolsPerNode", current.PoolsPerNode, desired.PoolsPerNode),
+	} {
+		plan.Immutable = append(plan.Immutable, diff...)
+	}
+
+	if diffs := appendDiff(nil, "manager", current.Manager, desired.Manager); len(diffs) > 0 {
+		managerAddr, err := types.DecodeAddress(desired.Manager)
+		if err != nil {
+			return nil, fmt.Errorf("invalid manager address:%s", desired.Manager)
+		}
+		plan.Result.Manager = desired.Manager
+		plan.Steps = append(plan.Steps, planStep{
+			Desc:  "change manager",
+			Role:  ownerRole,
+			Diffs: diffs,
+			apply: func(ctx context.Context, ownerAddr types.Address) error {
+				return App.retiClient.ChangeValidatorManagerAddress(current.ID, ownerAddr, managerAddr)
+			},
+		})
+	}
+
+	if diffs := appendDiff(nil, "validatorCommissionAddress", current.ValidatorCommissionAddress, desired.ValidatorCommissionAddress); len(diffs) > 0 {
+		commissionAddr, err := types.DecodeAddress(desired.ValidatorCommissionAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid commission address:%s", desired.ValidatorCommissionAddress)
+		}
+		plan.Result.ValidatorCommissionAddress = desired.ValidatorCommissionAddress
+		plan.Steps = append(plan.Steps, planStep{
+			Desc:  "change commission address",
+			Role:  ownerRole,
+			Diffs: diffs,
+			apply: func(ctx context.Context, ownerAddr types.Address) error {
+				return App.retiClient.ChangeValidatorCommissionAddress(current.ID, ownerAddr, commissionAddr)
+			},
+		})
+	}
+
+	switch {
+	case desired.NFDForInfo == current.NFDForInfo:
+	case desired.NFDForInfo == 0:
+		plan.Notes = append(plan.Notes, fmt.Sprintf("nfd app id:%d can be replaced but not removed once set", current.NFDForInfo))
+	default:
+		plan.Result.NFDForInfo = desired.NFDForInfo
+		plan.Steps = append(plan.Steps, planStep{
+			Desc: "change nfd",
+			Role: ownerRole,
+			Diffs: []fieldDiff{{
+				Name:    "nfd",
+				Current: fmt.Sprintf("app id:%d", current.NFDForInfo),
+				Desired: fmt.Sprintf("%s (app id:%d)", nfdName, desired.NFDForInfo),
+			}},
+			apply: func(ctx context.Context, ownerAddr types.Address) error {
+				return App.retiClient.ChangeValidatorNFD(current.ID, ownerAddr, desired.NFDForInfo, nfdName)
+			},
+		})
+	}
+
+	var rewardDiffs []fieldDiff
+	rewardDiffs = appendDiff(rewardDiffs, "entryGatingType", current.EntryGatingType, desired.EntryGatingType)
+	rewardDiffs = appendDiff(rewardDiffs, "entryGatingAddress", optionalAddress(current.EntryGatingAddress), optionalAddress(desired.EntryGatingAddress))
+	rewardDiffs = appendDiff(rewardDiffs, "entryGatingAssets", gatingAssets(current.EntryGatingAssets), gatingAssets(desired.EntryGatingAssets))
+	rewardDiffs = appendDiff(rewardDiffs, "gatingAssetMinBalance", current.GatingAssetMinBalance, desired.GatingAssetMinBalance)
+	rewardDiffs = appendDiff(rewardDiffs, "rewardPerPayout", current.RewardPerPayout, desired.RewardPerPayout)
+	if len(rewardDiffs) > 0 {
+		plan.Result.EntryGatingType = desired.EntryGatingType
+		plan.Result.EntryGatingAddress = desired.EntryGatingAddress
+		plan.Result.EntryGatingAssets = desired.EntryGatingAssets
+		plan.Result.GatingAssetMinBalance = desired.GatingAssetMinBalance
+		plan.Result.RewardPerPayout = desired.RewardPerPayout
+		rewardConfig := plan.Result
+		plan.Steps = append(plan.Steps, planStep{
+			Desc:  "change reward and entry gating info",
+			Role:  ownerRole,
+			Diffs: rewardDiffs,
+			apply: func(ctx context.Context, ownerAddr types.Address) error {
+				return App.retiClient.ChangeValidatorRewardInfo(&rewardConfig, ownerAddr)
+			},
+		})
+	}
+
+	var sunsetDiffs []fieldDiff
+	sunsetDiffs = appendDiff(sunsetDiffs, "sunsettingOn", sunsetTime(current.SunsettingOn), sunsetTime(desired.SunsettingOn))
+	sunsetDiffs = appendDiff(sunsetDiffs, "sunsettingTo", current.SunsettingTo, desired.SunsettingTo)
+	if len(sunsetDiffs) > 0 {
+		if desired.SunsettingTo != 0 && desired.SunsettingOn == 0 {
+			return nil, fmt.Errorf("sunsettingTo (validator:%d) is only valid with a sunsettingOn date", desired.SunsettingTo)
+		}
+		if desired.SunsettingOn != 0 && desired.SunsettingOn != current.SunsettingOn && !time.Now().Before(time.Unix(int64(desired.SunsettingOn), 0)) {
+			return nil, fmt.Errorf("sunsettingOn %s is in the past - the sunset date has to be in the future", sunsetTime(desired.SunsettingOn))
+		}
+		plan.Result.SunsettingOn, plan.Result.SunsettingTo = desired.SunsettingOn, desired.SunsettingTo
+		plan.Steps = append(plan.Steps, planStep{
+			Desc:  "change sunset info",
+			Role:  ownerRole,
+			Diffs: sunsetDiffs,
+			apply: func(ctx context.Context, ownerAddr types.Address) error {
+				return App.retiClient.ChangeValidatorSunsetInfo(current.ID, ownerAddr, desired.SunsettingOn, desired.SunsettingTo)
+			},
+		})
+	}
+
+	if len(nodePools) > len(info.NodePoolAssignments.Nodes) {
+		return nil, fmt.Errorf("spec defines pools for %d nodes but at most %d nodes are allowed", len(nodePools), len(info.NodePoolAssignments.Nodes))
+	}
+	for i, numPools := range nodePools {
+		nodeNum := uint64(i + 1)
+		curPools := len(info.NodePoolAssignments.Nodes[i].PoolAppIds)
+		if numPools > current.PoolsPerNode {
+			return nil, fmt.Errorf("spec wants %d pools on node %d but the validator allows at most %d pools per node", numPools, nodeNum, current.PoolsPerNode)
+		}
+		if numPools < curPools {
+			plan.Notes = append(plan.Notes, fmt.Sprintf("node %d has %d pools but spec wants %d - pools can't be removed, only moved to another node", nodeNum, curPools, numPools))
+			continue
+		}
+		for added := curPools; added < numPools; added++ {
+			plan.Steps = append(plan.Steps, planStep{
+				Desc: fmt.Sprintf("add pool to node %d", nodeNum),
+				Role: managerRole,
+				Diffs: []fieldDiff{{
+					Name:    fmt.Sprintf("node %d pools", nodeNum),
+					Current: fmt.Sprint(added),
+					Desired: fmt.Sprint(added + 1),
+				}},
+				apply: func(ctx context.Context, ownerAddr types.Address) error {
+					poolKey, err := App.retiClient.AddStakingPool(nodeNum)
+					if err != nil {
+						return err
+					}
+					fmt.Printf("added pool %s\n", poolKey.String())
+					return nil
+				},
+			})
+		}
+	}
+	return plan, nil
+}
+
+// appendDiff appends a fieldDiff to diffs if the current and desired values differ
+func appendDiff(diffs []fieldDiff, name string, current any, desired any) []fieldDiff {
+	currentStr, desiredStr := fmt.Sprint(current), fmt.Sprint(desired)
+	if currentStr == desiredStr {
+		return diffs
+	}
+	return append(diffs, fieldDiff{Name: name, Current: currentStr, Desired: desiredStr})
+}
+
+// optionalAddress returns the address the way the contract stores it - the zero address if not set
+func optionalAddress(address string) string {
+	if address == "" {
+		return types.ZeroAddress.String()
+	}
+	return address
+}
+
+// gatingAssets returns the gating assets the way the contract stores them - always 4, unused ones 0
+func gatingAssets(assets []uint64) [4]uint64 {
+	var fixed [4]uint64
+	copy(fixed[:], assets)
+	return fixed
+}
+
+func sunsetTime(sunsettingOn uint64) string {
+	if sunsettingOn == 0 {
+		return "none"
+	}
+	return time.Unix(int64(sunsettingOn), 0).Format(time.RFC3339)
+}
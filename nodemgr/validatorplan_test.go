@@ -0,0 +1,123 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+
+	"github.com/algorandfoundation/reti/internal/lib/reti"
+)
+
+func testValidatorInfo() reti.ValidatorInfo {
+	owner, manager := crypto.GenerateAccount().Address.String(), crypto.GenerateAccount().Address.String()
+	info := reti.ValidatorInfo{
+		Config: reti.ValidatorConfig{
+			ID:                         1,
+			Owner:                      owner,
+			Manager:                    manager,
+			EntryGatingAssets:          []uint64{0, 0, 0, 0},
+			EpochRoundLength:           1440,
+			PercentToValidator:         50_000,
+			ValidatorCommissionAddress: owner,
+			MinEntryStake:              1_000_000,
+			MaxAlgoPerPool:             70_000_000_000_000,
+			PoolsPerNode:               3,
+		},
+	}
+	info.NodePoolAssignments.Nodes = make([]reti.NodeConfig, 8)
+	info.NodePoolAssignments.Nodes[0].PoolAppIds = []uint64{2000}
+	return info
+}
+
+func planStepDescs(plan *ValidatorPlan) []string {
+	var descs []string
+	for _, step := range plan.Steps {
+		descs = append(descs, step.Desc)
+	}
+	return descs
+}
+
+func TestPlanValidator(t *testing.T) {
+	var (
+		future     = uint64(time.Now().Add(30 * 24 * time.Hour).Unix())
+		past       = uint64(time.Now().Add(-time.Hour).Unix())
+		newManager = crypto.GenerateAccount().Address.String()
+	)
+	tests := []struct {
+		name      string
+		current   func(config *reti.ValidatorConfig)
+		desired   func(config *reti.ValidatorConfig)
+		nodePools []int
+		steps     []string
+		err       string
+	}{
+		{name: "no changes", nodePools: []int{1}},
+		{
+			name:      "manager change and pools",
+			desired:   func(config *reti.ValidatorConfig) { config.Manager = newManager },
+			nodePools: []int{2, 1},
+			steps:     []string{"change manager", "add pool to node 1", "add pool to node 2"},
+		},
+		{
+			name:    "future sunset",
+			desired: func(config *reti.ValidatorConfig) { config.SunsettingOn, config.SunsettingTo = future, 2 },
+			steps:   []string{"change sunset info"},
+		},
+		{
+			name:    "past sunset",
+			desired: func(config *reti.ValidatorConfig) { config.SunsettingOn = past },
+			err:     "in the past",
+		},
+		{
+			name:    "unchanged past sunset",
+			current: func(config *reti.ValidatorConfig) { config.SunsettingOn = past },
+			desired: func(config *reti.ValidatorConfig) { config.SunsettingOn = past },
+		},
+		{
+			name:    "sunset removed",
+			current: func(config *reti.ValidatorConfig) { config.SunsettingOn = future },
+			desired: func(config *reti.ValidatorConfig) { config.SunsettingOn = 0 },
+			steps:   []string{"change sunset info"},
+		},
+		{
+			name:    "sunsettingTo without sunsettingOn",
+			desired: func(config *reti.ValidatorConfig) { config.SunsettingTo = 2 },
+			err:     "only valid with a sunsettingOn date",
+		},
+		{
+			name:      "too many pools",
+			nodePools: []int{4},
+			err:       "at most 3 pools per node",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := testValidatorInfo()
+			if tt.current != nil {
+				tt.current(&info.Config)
+			}
+			desired := info.Config
+			if tt.desired != nil {
+				tt.desired(&desired)
+			}
+			plan, err := PlanValidator(desired, "", tt.nodePools, info)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected an error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PlanValidator: %v", err)
+			}
+			if descs := planStepDescs(plan); strings.Join(descs, ",") != strings.Join(tt.steps, ",") {
+				t.Fatalf("expected steps %q, got %q", tt.steps, descs)
+			}
+			if plan.Result.SunsettingOn != desired.SunsettingOn || plan.Result.Manager != desired.Manager {
+				t.Fatalf("plan result %+v doesn't match the desired config", plan.Result)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/algorandfoundation/reti/internal/lib/reti"
+)
+
+// ValidatorSpec is the (yaml or json) file format used to define a validator without prompting.  Field names and
+// units match reti.ValidatorConfig - all algo amounts are in microAlgo.
+type ValidatorSpec struct {
+	Owner   string `json:"owner" yaml:"owner"`
+	Manager string `json:"manager" yaml:"manager"`
+	// NFD is the (optional) NFD name (ie: myvalidator.algo) describing the validator - it must be owned by Owner
+	NFD string `json:"nfd,omitempty" yaml:"nfd,omitempty"`
+
+	EntryGatingType       uint8    `json:"entryGatingType,omitempty" yaml:"entryGatingType,omitempty"`
+	EntryGatingAddress    string   `json:"entryGatingAddress,omitempty" yaml:"entryGatingAddress,omitempty"`
+	EntryGatingAssets     []uint64 `json:"entryGatingAssets,omitempty" yaml:"entryGatingAssets,omitempty"`
+	GatingAssetMinBalance uint64   `json:"gatingAssetMinBalance,omitempty" yaml:"gatingAssetMinBalance,omitempty"`
+
+	RewardTokenId   uint64 `json:"rewardTokenId,omitempty" yaml:"rewardTokenId,omitempty"`
+	RewardPerPayout uint64 `json:"rewardPerPayout,omitempty" yaml:"rewardPerPayout,omitempty"`
+
+	EpochRoundLength int `json:"epochRoundLength" yaml:"epochRoundLength"`
+	// PercentToValidator is the commission with four decimals - ie: 50000 = 5%
+	PercentToValidator         int    `json:"percentToValidator" yaml:"percentToValidator"`
+	ValidatorCommissionAddress string `json:"validatorCommissionAddress" yaml:"validatorCommissionAddress"`
+	MinEntryStake              uint64 `json:"minEntryStake" yaml:"minEntryStake"`
+	MaxAlgoPerPool             uint64 `json:"maxAlgoPerPool,omitempty" yaml:"maxAlgoPerPool,omitempty"`
+	PoolsPerNode               int    `json:"poolsPerNode" yaml:"poolsPerNode"`
+
+	SunsettingOn uint64 `json:"sunsettingOn,omitempty" yaml:"sunsettingOn,omitempty"`
+	SunsettingTo uint64 `json:"sunsettingTo,omitempty" yaml:"sunsettingTo,omitempty"`
+
+	// NodePools is the desired number of pools on each node (node 1 first) - used by validator plan/apply.
+	// If not specified the pools are left as they are.
+	NodePools []int `json:"nodePools,omitempty" yaml:"nodePools,omitempty"`
+}
+
+// LoadValidatorSpec reads a validator spec file - json if it has a .json extension, yaml otherwise.
+// Unknown fields are rejected so typos don't silently leave settings at their defaults.
+func LoadValidatorSpec(filename string) (*ValidatorSpec, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var spec ValidatorSpec
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&spec)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&spec)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", filename, err)
+	}
+	return &spec, nil
+}
+
+// Config returns the validator configuration the spec describes, resolving the NFD name to its app id
+func (s *ValidatorSpec) Config(ctx context.Context) (reti.ValidatorConfig, error) {
+	config := reti.ValidatorConfig{
+		Owner:                      s.Owner,
+		Manager:                    s.Manager,
+		EntryGatingType:            s.EntryGatingType,
+		EntryGatingAddress:         s.EntryGatingAddress,
+		EntryGatingAssets:          s.EntryGatingAssets,
+		GatingAssetMinBalance:      s.GatingAssetMinBalance,
+		RewardTokenId:              s.RewardTokenId,
+		RewardPerPayout:            s.RewardPerPayout,
+		EpochRoundLength:           s.EpochRoundLength,
+		PercentToValidator:         s.PercentToValidator,
+		ValidatorCommissionAddress: s.ValidatorCommissionAddress,
+		MinEntryStake:              s.MinEntryStake,
+		MaxAlgoPerPool:             s.MaxAlgoPerPool,
+		PoolsPerNode:               s.PoolsPerNode,
+		SunsettingOn:               s.SunsettingOn,
+		SunsettingTo:               s.SunsettingTo,
+	}
+	if config.Manager == "" {
+		config.Manager = config.Owner
+	}
+	if config.ValidatorCommissionAddress == "" {
+		config.ValidatorCommissionAddress = config.Owner
+	}
+	if s.NFD != "" {
+		if err := IsNFDNameValid(s.NFD); err != nil {
+			return config, err
+		}
+		nfd, _, err := App.nfdApi.NfdApi.NfdGetNFD(ctx, s.NFD, nil)
+		if err != nil {
+			return config, fmt.Errorf("unable to fetch nfd:%s, err:%w", s.NFD, err)
+		}
+		if nfd.Owner != config.Owner {
+			return config, fmt.Errorf("nfd owner:%s is not same as validator owner:%s", nfd.Owner, config.Owner)
+		}
+		config.NFDForInfo = uint64(nfd.AppID)
+	}
+	return config, nil
+}